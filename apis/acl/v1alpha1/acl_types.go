@@ -9,39 +9,97 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
-// ACLRule
-// type ACLRule struct {
-// 	Action         string   `json:"action"`
-// 	ClusterScope   string   `json:"clusterScope"`
-// 	ConsumerGroup  string   `json:"consumerGroup"`
-// 	Operations     []string `json:"operations"`
-// 	Prefix         string   `json:"prefix"`
-// 	ServiceAccount string   `json:"serviceAccount"`
-// 	Topic          string   `json:"topic"`
-// 	Environment    string   `json:"environment"`
-// 	Cluster        string   `json:"cluster"`
-// }
-
-// ACLRule object
+// ACLRule object. The legacy fields are accepted for manifests written before bindings were declared by resource type & name, they are
+// converted into the current fields when the ACL is reconciled.
 type ACLRule struct {
-	Operation    string `json:"operation"`
-	PatternType  string `json:"patternType"` // LITERAL, PREFIXED
-	Permission   string `json:"permission"`  // ALLOW, DENY
-	Principal    string `json:"principal"`   // sa-00000
-	ResourceName string `json:"resourceName"`
-	ResourceType string `json:"resourceType"` // TOPIC, CONSUMER_GROUP, CLUSTER
+	LegacyACLRule `json:",inline"`
+	// Operation of the binding, e.g. READ.
+	// +kubebuilder:validation:Enum=ALTER;ALTER_CONFIGS;CLUSTER_ACTION;CREATE;DELETE;DESCRIBE;DESCRIBE_CONFIGS;IDEMPOTENT_WRITE;READ;WRITE
+	// +optional
+	Operation string `json:"operation,omitempty"`
+	// Operations is expanded into one binding per operation. Exactly one of Operation and Operations must be set. The operations are checked
+	// by the provider rather than the API server, as legacy rules list them in lower case.
+	// +optional
+	Operations []string `json:"operations,omitempty"`
+	// PatternType matches the resource name exactly when LITERAL, or every resource name starting with it when PREFIXED. Defaults to LITERAL.
+	// +kubebuilder:validation:Enum=LITERAL;PREFIXED
+	// +kubebuilder:default=LITERAL
+	// +optional
+	PatternType string `json:"patternType,omitempty"`
+	// +kubebuilder:validation:Enum=ALLOW;DENY
+	// +optional
+	Permission string `json:"permission,omitempty"` // ALLOW, DENY
+	// +optional
+	Principal string `json:"principal,omitempty"` // User:sa-00000
+	// PrincipalRef references a ServiceAccount to bind the operations to, its ID is resolved into Principal.
+	// +optional
+	PrincipalRef *xpv1.Reference `json:"principalRef,omitempty"`
+	// PrincipalSelector selects a reference to a ServiceAccount to bind the operations to, its ID is resolved into Principal.
+	// +optional
+	PrincipalSelector *xpv1.Selector `json:"principalSelector,omitempty"`
+	// +optional
+	ResourceName string `json:"resourceName,omitempty"`
+	// +kubebuilder:validation:Enum=TOPIC;CONSUMER_GROUP;CLUSTER
+	// +optional
+	ResourceType string `json:"resourceType,omitempty"` // TOPIC, CONSUMER_GROUP, CLUSTER. Cluster-scoped bindings have ResourceName kafka-cluster or empty
+}
+
+// LegacyACLRule is the previous shape of an ACLRule, which mirrored the flags of the Confluent CLI.
+type LegacyACLRule struct {
+	// Action is ALLOW or DENY. Deprecated: Use Permission.
+	// +optional
+	Action string `json:"action,omitempty"`
+	// ClusterScope binds the operations to the cluster when "true". Deprecated: Use ResourceType CLUSTER.
+	// +optional
+	ClusterScope string `json:"clusterScope,omitempty"`
+	// ConsumerGroup binds the operations to a consumer group. Deprecated: Use ResourceType CONSUMER_GROUP.
+	// +optional
+	ConsumerGroup string `json:"consumerGroup,omitempty"`
+	// Prefix matches every resource name starting with the topic or consumer group when "true". Deprecated: Use PatternType PREFIXED.
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+	// ServiceAccount the operations are bound to. Deprecated: Use Principal.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Topic binds the operations to a topic. Deprecated: Use ResourceType TOPIC.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+	// Environment of the bindings. Deprecated: Use the environment of the ACL.
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// Cluster of the bindings. Deprecated: Use the cluster of the ACL.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
 }
 
 // ACLParameters are the configurable fields of a ACL.
 type ACLParameters struct {
-	ACLRule     ACLRule `json:"aclRule"`
-	Environment string  `json:"environment"`
-	Cluster     string  `json:"cluster"`
+	ACLRule ACLRule `json:"aclRule"`
+	// Environment of the bindings. Required, unless set by the legacy fields of the rule.
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// Cluster of the bindings. Required, unless set by the legacy fields of the rule.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+	// Atomic rolls back the bindings created in a reconcile when creating any other binding of the ACL fails.
+	// +optional
+	Atomic bool `json:"atomic,omitempty"`
+	// UnmanagedBindings decides what happens to the bindings of the principal in the cluster that no ACL declares. Keep leaves them alone,
+	// DeleteUnmanaged deletes them so the ACLs of the principal are authoritative. Defaults to Keep.
+	// +kubebuilder:validation:Enum=Keep;DeleteUnmanaged
+	// +optional
+	UnmanagedBindings string `json:"unmanagedBindings,omitempty"`
 }
 
 // ACLObservation are the observable fields of a ACL.
 type ACLObservation struct {
 	ACLP ACLParameters `json:"aclParameters"`
+	// DesiredCount is the number of bindings the ACL declares, one per operation.
+	// +optional
+	DesiredCount int `json:"desiredCount,omitempty"`
+	// ObservedCount is the number of declared bindings that exist in the cluster.
+	// +optional
+	ObservedCount int `json:"observedCount,omitempty"`
 }
 
 // ACLSpec defines the desired state of a ACL.
@@ -62,6 +120,8 @@ type ACLStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="DESIRED",type="integer",JSONPath=".status.atProvider.desiredCount"
+// +kubebuilder:printcolumn:name="OBSERVED",type="integer",JSONPath=".status.atProvider.observedCount"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
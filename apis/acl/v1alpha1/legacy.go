@@ -0,0 +1,112 @@
+package v1alpha1
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errLegacyResources   = "aclRule must set exactly one of topic, consumerGroup or clusterScope"
+	errLegacyUnsupported = "aclRule with resourceType %s has no legacy shape"
+
+	principalPrefix     = "User:"
+	clusterResourceName = "kafka-cluster"
+)
+
+// IsLegacy Checks if any legacy field of the rule is set
+func (r ACLRule) IsLegacy() bool {
+	return r.LegacyACLRule != LegacyACLRule{}
+}
+
+// ConvertLegacy Converts the legacy fields of the rule of p into the current fields & clears them. The legacy fields take precedence
+// over current fields set alongside them, as manifests re-applied in the legacy shape only set those. Operations are normalized to the
+// upper case names Confluent reports, e.g. describe-configs becomes DESCRIBE_CONFIGS
+func (p *ACLParameters) ConvertLegacy() error {
+	l := p.ACLRule.LegacyACLRule
+	if l == (LegacyACLRule{}) {
+		return nil
+	}
+
+	rule := ACLRule{
+		Permission:  strings.ToUpper(l.Action),
+		Principal:   l.ServiceAccount,
+		PatternType: "LITERAL",
+	}
+	if rule.Principal != "" && !strings.HasPrefix(rule.Principal, principalPrefix) {
+		rule.Principal = principalPrefix + rule.Principal
+	}
+	if isTrue(l.Prefix) {
+		rule.PatternType = "PREFIXED"
+	}
+
+	resources := 0
+	if l.Topic != "" {
+		rule.ResourceType, rule.ResourceName = "TOPIC", l.Topic
+		resources++
+	}
+	if l.ConsumerGroup != "" {
+		rule.ResourceType, rule.ResourceName = "CONSUMER_GROUP", l.ConsumerGroup
+		resources++
+	}
+	if isTrue(l.ClusterScope) {
+		rule.ResourceType, rule.ResourceName = "CLUSTER", clusterResourceName
+		resources++
+	}
+	if resources != 1 {
+		return errors.New(errLegacyResources)
+	}
+
+	for _, op := range p.ACLRule.Operations {
+		rule.Operations = append(rule.Operations, strings.ToUpper(strings.ReplaceAll(op, "-", "_")))
+	}
+
+	p.ACLRule = rule
+	if l.Environment != "" {
+		p.Environment = l.Environment
+	}
+	if l.Cluster != "" {
+		p.Cluster = l.Cluster
+	}
+	return nil
+}
+
+// ToLegacy Returns p with its rule in the legacy shape, the inverse of ConvertLegacy. A single Operation becomes Operations, rules on
+// resource types other than topics, consumer groups & the cluster have no legacy shape
+func (p ACLParameters) ToLegacy() (ACLParameters, error) {
+	r := p.ACLRule
+	l := LegacyACLRule{
+		Action:         r.Permission,
+		ServiceAccount: strings.TrimPrefix(r.Principal, principalPrefix),
+		Environment:    p.Environment,
+		Cluster:        p.Cluster,
+	}
+	if r.PatternType == "PREFIXED" {
+		l.Prefix = "true"
+	}
+
+	switch r.ResourceType {
+	case "TOPIC":
+		l.Topic = r.ResourceName
+	case "CONSUMER_GROUP":
+		l.ConsumerGroup = r.ResourceName
+	case "CLUSTER":
+		l.ClusterScope = "true"
+	default:
+		return ACLParameters{}, errors.Errorf(errLegacyUnsupported, r.ResourceType)
+	}
+
+	legacy := p
+	legacy.ACLRule = ACLRule{LegacyACLRule: l, Operations: append([]string(nil), r.Operations...)}
+	if r.Operation != "" {
+		legacy.ACLRule.Operations = []string{r.Operation}
+	}
+	legacy.Environment, legacy.Cluster = "", ""
+	return legacy, nil
+}
+
+func isTrue(s string) bool {
+	b, err := strconv.ParseBool(s)
+	return err == nil && b
+}
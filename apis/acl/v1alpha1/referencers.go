@@ -0,0 +1,53 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/common"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+// ServiceAccountPrincipal extracts the principal of a ServiceAccount, which
+// is only known once the service account exists.
+func ServiceAccountPrincipal() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		sa, ok := mg.(*saapi.ServiceAccount)
+		if !ok || sa.Status.AtProvider.ID == "" {
+			return ""
+		}
+		return "User:" + sa.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this ACL resolves the principal of the ServiceAccount
+// its operations are bound to.
+func (mg *ACL) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rule := &mg.Spec.ForProvider.ACLRule
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: rule.Principal,
+		Extract:      ServiceAccountPrincipal(),
+		Reference:    rule.PrincipalRef,
+		Selector:     rule.PrincipalSelector,
+		To: reference.To{
+			List:    &saapi.ServiceAccountList{},
+			Managed: &saapi.ServiceAccount{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.aclRule.principal")
+	}
+	if err := common.ReferenceReady(ctx, c, rsp.ResolvedReference, &saapi.ServiceAccount{}); err != nil {
+		return errors.Wrap(err, "spec.forProvider.aclRule.principal")
+	}
+	rule.Principal = rsp.ResolvedValue
+	rule.PrincipalRef = rsp.ResolvedReference
+
+	return nil
+}
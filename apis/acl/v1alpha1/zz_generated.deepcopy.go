@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -87,7 +88,7 @@ func (in *ACLList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACLObservation) DeepCopyInto(out *ACLObservation) {
 	*out = *in
-	out.ACLP = in.ACLP
+	in.ACLP.DeepCopyInto(&out.ACLP)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLObservation.
@@ -103,7 +104,7 @@ func (in *ACLObservation) DeepCopy() *ACLObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACLParameters) DeepCopyInto(out *ACLParameters) {
 	*out = *in
-	out.ACLRule = in.ACLRule
+	in.ACLRule.DeepCopyInto(&out.ACLRule)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLParameters.
@@ -119,6 +120,22 @@ func (in *ACLParameters) DeepCopy() *ACLParameters {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ACLRule) DeepCopyInto(out *ACLRule) {
 	*out = *in
+	out.LegacyACLRule = in.LegacyACLRule
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrincipalRef != nil {
+		in, out := &in.PrincipalRef, &out.PrincipalRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.PrincipalSelector != nil {
+		in, out := &in.PrincipalSelector, &out.PrincipalSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLRule.
@@ -135,7 +152,7 @@ func (in *ACLRule) DeepCopy() *ACLRule {
 func (in *ACLSpec) DeepCopyInto(out *ACLSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLSpec.
@@ -152,7 +169,7 @@ func (in *ACLSpec) DeepCopy() *ACLSpec {
 func (in *ACLStatus) DeepCopyInto(out *ACLStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ACLStatus.
@@ -164,3 +181,18 @@ func (in *ACLStatus) DeepCopy() *ACLStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegacyACLRule) DeepCopyInto(out *LegacyACLRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegacyACLRule.
+func (in *LegacyACLRule) DeepCopy() *LegacyACLRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LegacyACLRule)
+	in.DeepCopyInto(out)
+	return out
+}
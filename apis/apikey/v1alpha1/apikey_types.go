@@ -9,12 +9,80 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// Owner types of an APIKey.
+const (
+	OwnerTypeServiceAccount = "ServiceAccount"
+	OwnerTypeUser           = "User"
+)
+
+// Scopes of an APIKey.
+const (
+	ScopeKafka          = "Kafka"
+	ScopeSchemaRegistry = "SchemaRegistry"
+)
+
+// APIKeyOwner is the owner of an APIKey, either a service account or a user.
+type APIKeyOwner struct {
+	// ServiceAccount is the ID of the service account owning the key.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// ServiceAccountRef references a ServiceAccount to retrieve its ID.
+	// +optional
+	ServiceAccountRef *xpv1.Reference `json:"serviceAccountRef,omitempty"`
+
+	// ServiceAccountSelector selects a reference to a ServiceAccount to retrieve its ID.
+	// +optional
+	ServiceAccountSelector *xpv1.Selector `json:"serviceAccountSelector,omitempty"`
+
+	// User is the resource ID of the user owning the key, e.g. u-abc123. Mutually
+	// exclusive with a service account owner.
+	// +optional
+	User string `json:"user,omitempty"`
+}
+
+// ConnectionSecretKeyMapping names the keys the API key & secret are written to
+// in the connection secret.
+type ConnectionSecretKeyMapping struct {
+	// Key is the connection secret key holding the API key. Defaults to username.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Key string `json:"key,omitempty"`
+
+	// Secret is the connection secret key holding the API secret. Defaults to
+	// password.
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Secret string `json:"secret,omitempty"`
+}
+
 // APIKeyParameters are the configurable fields of a APIKey.
 type APIKeyParameters struct {
-	Resource       string `json:"resource"`
-	ServiceAccount string `json:"serviceAccount"`
-	Environment    string `json:"environment"`
-	Description    string `json:"description"`
+	Resource string `json:"resource"`
+
+	// ServiceAccount is the ID of the service account owning the key.
+	// Deprecated: Use Owner.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+
+	// Owner of the key, either a service account or a user.
+	// +optional
+	Owner *APIKeyOwner `json:"owner,omitempty"`
+
+	Environment string `json:"environment"`
+	Description string `json:"description"`
+
+	// Scope the key is meant for, either Kafka for a key of a Kafka cluster or
+	// SchemaRegistry for a key of a schema registry. The resource is validated
+	// against it when set.
+	// +optional
+	// +kubebuilder:validation:Enum=Kafka;SchemaRegistry
+	Scope string `json:"scope,omitempty"`
+
+	// ConnectionSecretKeyMapping names the keys of the connection secret. The
+	// mapping applies when the key is created or recreated.
+	// +optional
+	ConnectionSecretKeyMapping *ConnectionSecretKeyMapping `json:"connectionSecretKeyMapping,omitempty"`
 }
 
 // APIKeyObservation are the observable fields of a APIKey.
@@ -23,6 +91,20 @@ type APIKeyObservation struct {
 	Resource       string `json:"resource"`
 	ServiceAccount string `json:"serviceAccount"`
 	Environment    string `json:"environment"`
+
+	// Owner is the resource ID of the service account or user owning the key, as
+	// reported by Confluent Cloud.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// OwnerType is the type of the owner, either ServiceAccount or User.
+	// +optional
+	OwnerType string `json:"ownerType,omitempty"`
+
+	// Scope of the key, either Kafka or SchemaRegistry, as told by its resource.
+	// Empty for keys of other resources.
+	// +optional
+	Scope string `json:"scope,omitempty"`
 }
 
 // APIKeySpec defines the desired state of a APIKey.
@@ -43,6 +125,7 @@ type APIKeyStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="OWNER",type="string",JSONPath=".status.atProvider.owner"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
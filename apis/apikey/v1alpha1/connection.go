@@ -0,0 +1,20 @@
+package v1alpha1
+
+import (
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConnectionSecretKeys Returns the connection secret keys the API key & secret of this APIKey are written to, defaulting to username &
+// password.
+func (mg *APIKey) ConnectionSecretKeys() (string, string) {
+	key, secret := xpv1.ResourceCredentialsSecretUserKey, xpv1.ResourceCredentialsSecretPasswordKey
+	if m := mg.Spec.ForProvider.ConnectionSecretKeyMapping; m != nil {
+		if m.Key != "" {
+			key = m.Key
+		}
+		if m.Secret != "" {
+			secret = m.Secret
+		}
+	}
+	return key, secret
+}
@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/common"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+// ServiceAccountID extracts the Confluent ID of a ServiceAccount, which unlike
+// its external name is only known once the service account exists.
+func ServiceAccountID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		sa, ok := mg.(*saapi.ServiceAccount)
+		if !ok {
+			return ""
+		}
+		return sa.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this APIKey resolves the ID of the ServiceAccount owning it.
+func (mg *APIKey) ResolveReferences(ctx context.Context, c client.Reader) error {
+	owner := mg.Spec.ForProvider.Owner
+	if owner == nil {
+		return nil
+	}
+
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: owner.ServiceAccount,
+		Extract:      ServiceAccountID(),
+		Reference:    owner.ServiceAccountRef,
+		Selector:     owner.ServiceAccountSelector,
+		To: reference.To{
+			List:    &saapi.ServiceAccountList{},
+			Managed: &saapi.ServiceAccount{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.owner.serviceAccount")
+	}
+	if err := common.ReferenceReady(ctx, c, rsp.ResolvedReference, &saapi.ServiceAccount{}); err != nil {
+		return errors.Wrap(err, "spec.forProvider.owner.serviceAccount")
+	}
+	owner.ServiceAccount = rsp.ResolvedValue
+	owner.ServiceAccountRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -22,6 +22,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -99,9 +100,44 @@ func (in *APIKeyObservation) DeepCopy() *APIKeyObservation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIKeyOwner) DeepCopyInto(out *APIKeyOwner) {
+	*out = *in
+	if in.ServiceAccountRef != nil {
+		in, out := &in.ServiceAccountRef, &out.ServiceAccountRef
+		*out = new(v1.Reference)
+		**out = **in
+	}
+	if in.ServiceAccountSelector != nil {
+		in, out := &in.ServiceAccountSelector, &out.ServiceAccountSelector
+		*out = new(v1.Selector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyOwner.
+func (in *APIKeyOwner) DeepCopy() *APIKeyOwner {
+	if in == nil {
+		return nil
+	}
+	out := new(APIKeyOwner)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *APIKeyParameters) DeepCopyInto(out *APIKeyParameters) {
 	*out = *in
+	if in.Owner != nil {
+		in, out := &in.Owner, &out.Owner
+		*out = new(APIKeyOwner)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ConnectionSecretKeyMapping != nil {
+		in, out := &in.ConnectionSecretKeyMapping, &out.ConnectionSecretKeyMapping
+		*out = new(ConnectionSecretKeyMapping)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeyParameters.
@@ -118,7 +154,7 @@ func (in *APIKeyParameters) DeepCopy() *APIKeyParameters {
 func (in *APIKeySpec) DeepCopyInto(out *APIKeySpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIKeySpec.
@@ -147,3 +183,18 @@ func (in *APIKeyStatus) DeepCopy() *APIKeyStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConnectionSecretKeyMapping) DeepCopyInto(out *ConnectionSecretKeyMapping) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionSecretKeyMapping.
+func (in *ConnectionSecretKeyMapping) DeepCopy() *ConnectionSecretKeyMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionSecretKeyMapping)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1 @@
+package certificateauthority //nolint
@@ -0,0 +1,101 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// CertificateAuthorityParameters are the configurable fields of a
+// CertificateAuthority.
+type CertificateAuthorityParameters struct {
+	// DisplayName of the certificate authority shown in Confluent Cloud.
+	DisplayName string `json:"displayName"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// CertificateChain is the PEM encoded X.509 certificate chain of the
+	// certificate authority, starting with its own certificate.
+	// +kubebuilder:validation:Pattern=`^\s*-----BEGIN CERTIFICATE-----`
+	CertificateChain string `json:"certificateChain"`
+	// CRLURL is the URL of the certificate revocation list of the certificate
+	// authority.
+	// +optional
+	CRLURL string `json:"crlURL,omitempty"`
+}
+
+// CertificateAuthorityObservation are the observable fields of a
+// CertificateAuthority.
+type CertificateAuthorityObservation struct {
+	// ID of the certificate authority, e.g. op-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Fingerprints are the SHA-1 fingerprints of the certificates in the chain.
+	// +optional
+	Fingerprints []string `json:"fingerprints,omitempty"`
+	// ExpirationDates of the certificates in the chain.
+	// +optional
+	ExpirationDates []string `json:"expirationDates,omitempty"`
+	// +optional
+	CRLURL string `json:"crlURL,omitempty"`
+	// State of the certificate authority, e.g. ACTIVE.
+	// +optional
+	State string `json:"state,omitempty"`
+}
+
+// CertificateAuthoritySpec defines the desired state of a CertificateAuthority.
+type CertificateAuthoritySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CertificateAuthorityParameters `json:"forProvider"`
+}
+
+// CertificateAuthorityStatus represents the observed state of a CertificateAuthority.
+type CertificateAuthorityStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CertificateAuthorityObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CertificateAuthority is a certificate authority trusted by Confluent Cloud to
+// authenticate clients by mTLS. Its CertificatePools map the client certificates
+// it issued to identities.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type CertificateAuthority struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CertificateAuthoritySpec   `json:"spec"`
+	Status            CertificateAuthorityStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CertificateAuthorityList contains a list of CertificateAuthority
+type CertificateAuthorityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificateAuthority `json:"items"`
+}
+
+// CertificateAuthority type metadata.
+var (
+	CertificateAuthorityKind             = reflect.TypeOf(CertificateAuthority{}).Name()
+	CertificateAuthorityGroupKind        = schema.GroupKind{Group: Group, Kind: CertificateAuthorityKind}.String()
+	CertificateAuthorityKindAPIVersion   = CertificateAuthorityKind + "." + SchemeGroupVersion.String()
+	CertificateAuthorityGroupVersionKind = SchemeGroupVersion.WithKind(CertificateAuthorityKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CertificateAuthority{}, &CertificateAuthorityList{})
+}
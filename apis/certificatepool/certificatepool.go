@@ -0,0 +1 @@
+package certificatepool //nolint
@@ -0,0 +1,108 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// CertificatePoolParameters are the configurable fields of a CertificatePool.
+type CertificatePoolParameters struct {
+	// CertificateAuthority is the ID of the certificate authority issuing the
+	// certificates of the pool, e.g. op-abc123.
+	// +optional
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// CertificateAuthorityRef references a CertificateAuthority to retrieve its ID.
+	// +optional
+	CertificateAuthorityRef *xpv1.Reference `json:"certificateAuthorityRef,omitempty"`
+	// CertificateAuthoritySelector selects a reference to a CertificateAuthority to
+	// retrieve its ID.
+	// +optional
+	CertificateAuthoritySelector *xpv1.Selector `json:"certificateAuthoritySelector,omitempty"`
+	// DisplayName of the certificate pool shown in Confluent Cloud.
+	DisplayName string `json:"displayName"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// ExternalIdentifier is the claim of the client certificates identifying them,
+	// e.g. UID or CN.
+	ExternalIdentifier string `json:"externalIdentifier"`
+	// Filter is the expression matching the client certificates of the pool, e.g.
+	// certificate.o == "dfds".
+	Filter string `json:"filter"`
+}
+
+// CertificatePoolObservation are the observable fields of a CertificatePool.
+type CertificatePoolObservation struct {
+	// ID of the certificate pool, e.g. pool-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Principal of the certificate pool in role bindings and ACLs, e.g.
+	// User:pool-abc123.
+	// +optional
+	Principal string `json:"principal,omitempty"`
+	// CertificateAuthority the certificate pool belongs to.
+	// +optional
+	CertificateAuthority string `json:"certificateAuthority,omitempty"`
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	ExternalIdentifier string `json:"externalIdentifier,omitempty"`
+	// +optional
+	Filter string `json:"filter,omitempty"`
+}
+
+// CertificatePoolSpec defines the desired state of a CertificatePool.
+type CertificatePoolSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CertificatePoolParameters `json:"forProvider"`
+}
+
+// CertificatePoolStatus represents the observed state of a CertificatePool.
+type CertificatePoolStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CertificatePoolObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CertificatePool maps the client certificates issued by a
+// CertificateAuthority to an identity. Certificates matching its filter
+// authenticate as the pool, identified by the claim of its external identifier.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type CertificatePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CertificatePoolSpec   `json:"spec"`
+	Status            CertificatePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CertificatePoolList contains a list of CertificatePool
+type CertificatePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificatePool `json:"items"`
+}
+
+// CertificatePool type metadata.
+var (
+	CertificatePoolKind             = reflect.TypeOf(CertificatePool{}).Name()
+	CertificatePoolGroupKind        = schema.GroupKind{Group: Group, Kind: CertificatePoolKind}.String()
+	CertificatePoolKindAPIVersion   = CertificatePoolKind + "." + SchemeGroupVersion.String()
+	CertificatePoolGroupVersionKind = SchemeGroupVersion.WithKind(CertificatePoolKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CertificatePool{}, &CertificatePoolList{})
+}
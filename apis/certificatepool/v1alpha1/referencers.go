@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	caapi "github.com/dfds/provider-confluent/apis/certificateauthority/v1alpha1"
+	"github.com/dfds/provider-confluent/apis/common"
+)
+
+// CertificateAuthorityID extracts the Confluent ID of a CertificateAuthority, which is only known once the certificate authority exists.
+func CertificateAuthorityID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		ca, ok := mg.(*caapi.CertificateAuthority)
+		if !ok {
+			return ""
+		}
+		return ca.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this CertificatePool resolves the ID of the CertificateAuthority issuing its certificates.
+func (mg *CertificatePool) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.CertificateAuthority,
+		Extract:      CertificateAuthorityID(),
+		Reference:    mg.Spec.ForProvider.CertificateAuthorityRef,
+		Selector:     mg.Spec.ForProvider.CertificateAuthoritySelector,
+		To: reference.To{
+			List:    &caapi.CertificateAuthorityList{},
+			Managed: &caapi.CertificateAuthority{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.certificateAuthority")
+	}
+	if err := common.ReferenceReady(ctx, c, rsp.ResolvedReference, &caapi.CertificateAuthority{}); err != nil {
+		return errors.Wrap(err, "spec.forProvider.certificateAuthority")
+	}
+	mg.Spec.ForProvider.CertificateAuthority = rsp.ResolvedValue
+	mg.Spec.ForProvider.CertificateAuthorityRef = rsp.ResolvedReference
+
+	return nil
+}
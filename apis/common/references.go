@@ -0,0 +1,63 @@
+// Package common contains helpers shared by the managed resources of the Confluent provider.
+package common
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+const (
+	errReferenceNotReady = "referenced %s %s is not ready"
+	errGetReferenced     = "cannot get referenced resource"
+)
+
+// ReferenceNotReadyError is returned by ResolveReferences when a referenced managed resource exists but is not Ready yet, so the
+// referencing resource is not created against a half-ready dependency
+type ReferenceNotReadyError struct {
+	Kind string
+	Name string
+}
+
+func (e *ReferenceNotReadyError) Error() string {
+	return fmt.Sprintf(errReferenceNotReady, e.Kind, e.Name)
+}
+
+// IsReferenceNotReady reports whether err is, or wraps, a ReferenceNotReadyError
+func IsReferenceNotReady(err error) bool {
+	var e *ReferenceNotReadyError
+	return errors.As(err, &e)
+}
+
+// ReferenceReady Checks that the managed resource referenced by ref is Ready, reading it into to. Nothing is checked without a reference,
+// e.g. when the referenced value is set directly
+func ReferenceReady(ctx context.Context, c client.Reader, ref *xpv1.Reference, to resource.Managed) error {
+	if ref == nil {
+		return nil
+	}
+	if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, to); err != nil {
+		return errors.Wrap(err, errGetReferenced)
+	}
+	if to.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+		return &ReferenceNotReadyError{Kind: reflect.TypeOf(to).Elem().Name(), Name: ref.Name}
+	}
+	return nil
+}
+
+// ReferencesReady Checks ReferenceReady for each of refs, reading them into the managed resources returned by newTo
+func ReferencesReady(ctx context.Context, c client.Reader, refs []xpv1.Reference, newTo func() resource.Managed) error {
+	for i := range refs {
+		if err := ReferenceReady(ctx, c, &refs[i], newTo()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
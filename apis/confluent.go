@@ -22,9 +22,22 @@ import (
 
 	aclv1alpha1 "github.com/dfds/provider-confluent/apis/acl/v1alpha1"
 	apikeyv1alpha1 "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	certificateauthorityv1alpha1 "github.com/dfds/provider-confluent/apis/certificateauthority/v1alpha1"
+	certificatepoolv1alpha1 "github.com/dfds/provider-confluent/apis/certificatepool/v1alpha1"
+	consumergroupv1alpha1 "github.com/dfds/provider-confluent/apis/consumergroup/v1alpha1"
+	customconnectorpluginv1alpha1 "github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	dnsforwarderv1alpha1 "github.com/dfds/provider-confluent/apis/dnsforwarder/v1alpha1"
+	environmentrolebindingv1alpha1 "github.com/dfds/provider-confluent/apis/environmentrolebinding/v1alpha1"
+	flinkstatementv1alpha1 "github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	groupmappingv1alpha1 "github.com/dfds/provider-confluent/apis/groupmapping/v1alpha1"
+	ipfilterv1alpha1 "github.com/dfds/provider-confluent/apis/ipfilter/v1alpha1"
+	ipgroupv1alpha1 "github.com/dfds/provider-confluent/apis/ipgroup/v1alpha1"
+	kafkaclusterv1alpha1 "github.com/dfds/provider-confluent/apis/kafkacluster/v1alpha1"
 	schemav1alpha1 "github.com/dfds/provider-confluent/apis/schema/v1alpha1"
 	serviceaccountv1alpha1 "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+	tableflowtopicv1alpha1 "github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
 	topicv1alpha1 "github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+	usagereportv1alpha1 "github.com/dfds/provider-confluent/apis/usagereport/v1alpha1"
 	confluentv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
 )
 
@@ -37,6 +50,19 @@ func init() {
 		apikeyv1alpha1.SchemeBuilder.AddToScheme,
 		aclv1alpha1.SchemeBuilder.AddToScheme,
 		topicv1alpha1.SchemeBuilder.AddToScheme,
+		tableflowtopicv1alpha1.SchemeBuilder.AddToScheme,
+		flinkstatementv1alpha1.SchemeBuilder.AddToScheme,
+		ipgroupv1alpha1.SchemeBuilder.AddToScheme,
+		ipfilterv1alpha1.SchemeBuilder.AddToScheme,
+		customconnectorpluginv1alpha1.SchemeBuilder.AddToScheme,
+		consumergroupv1alpha1.SchemeBuilder.AddToScheme,
+		dnsforwarderv1alpha1.SchemeBuilder.AddToScheme,
+		groupmappingv1alpha1.SchemeBuilder.AddToScheme,
+		usagereportv1alpha1.SchemeBuilder.AddToScheme,
+		environmentrolebindingv1alpha1.SchemeBuilder.AddToScheme,
+		certificateauthorityv1alpha1.SchemeBuilder.AddToScheme,
+		certificatepoolv1alpha1.SchemeBuilder.AddToScheme,
+		kafkaclusterv1alpha1.SchemeBuilder.AddToScheme,
 	)
 }
 
@@ -0,0 +1 @@
+package consumergroup //nolint
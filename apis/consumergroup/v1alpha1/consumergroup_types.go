@@ -0,0 +1,100 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ConsumerGroupParameters identify the observed consumer group. The group is
+// not managed, these fields only select which group is observed.
+type ConsumerGroupParameters struct {
+	// GroupID is the ID of the consumer group.
+	GroupID     string `json:"groupId"`
+	Environment string `json:"environment"`
+	Cluster     string `json:"cluster"`
+}
+
+// PartitionLag is the lag of the consumer group on a single partition.
+type PartitionLag struct {
+	Topic     string `json:"topic"`
+	Partition int    `json:"partition"`
+	// CurrentOffset is the offset committed by the consumer group.
+	CurrentOffset int64 `json:"currentOffset"`
+	// LogEndOffset is the offset of the next message written to the partition.
+	LogEndOffset int64 `json:"logEndOffset"`
+	Lag          int64 `json:"lag"`
+	// ConsumerID is the member consuming the partition, if any.
+	// +optional
+	ConsumerID string `json:"consumerId,omitempty"`
+}
+
+// ConsumerGroupObservation are the observable fields of a ConsumerGroup.
+type ConsumerGroupObservation struct {
+	// State of the consumer group, e.g. STABLE, EMPTY or DEAD.
+	// +optional
+	State string `json:"state,omitempty"`
+	// Members are the IDs of the consumers in the group.
+	// +optional
+	Members []string `json:"members,omitempty"`
+	// TotalLag is the sum of the lag of all partitions.
+	// +optional
+	TotalLag int64 `json:"totalLag,omitempty"`
+	// Partitions is the lag per partition.
+	// +optional
+	Partitions []PartitionLag `json:"partitions,omitempty"`
+}
+
+// ConsumerGroupSpec defines the desired state of a ConsumerGroup.
+type ConsumerGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ConsumerGroupParameters `json:"forProvider"`
+}
+
+// ConsumerGroupStatus represents the observed state of a ConsumerGroup.
+type ConsumerGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ConsumerGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ConsumerGroup observes the state and lag of an existing Kafka consumer group.
+// It is observation-only: the provider never creates, changes or deletes the
+// consumer group, and deleting the ConsumerGroup leaves the group untouched.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type ConsumerGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              ConsumerGroupSpec   `json:"spec"`
+	Status            ConsumerGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ConsumerGroupList contains a list of ConsumerGroup
+type ConsumerGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ConsumerGroup `json:"items"`
+}
+
+// ConsumerGroup type metadata.
+var (
+	ConsumerGroupKind             = reflect.TypeOf(ConsumerGroup{}).Name()
+	ConsumerGroupGroupKind        = schema.GroupKind{Group: Group, Kind: ConsumerGroupKind}.String()
+	ConsumerGroupKindAPIVersion   = ConsumerGroupKind + "." + SchemeGroupVersion.String()
+	ConsumerGroupGroupVersionKind = SchemeGroupVersion.WithKind(ConsumerGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&ConsumerGroup{}, &ConsumerGroupList{})
+}
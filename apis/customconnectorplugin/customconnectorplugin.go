@@ -0,0 +1 @@
+package customconnectorplugin //nolint
@@ -0,0 +1,114 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// PluginArtifact is the .zip or .jar file of a CustomConnectorPlugin. Exactly one of URL and SecretRef must be set.
+type PluginArtifact struct {
+	// URL the plugin file is downloaded from. Its path must end in .zip or .jar.
+	// +optional
+	URL string `json:"url,omitempty"`
+	// SecretRef selects a secret key holding the plugin file. The key must end in .zip or .jar. Secrets are limited to 1MiB.
+	// +optional
+	SecretRef *xpv1.SecretKeySelector `json:"secretRef,omitempty"`
+}
+
+// CustomConnectorPluginParameters are the configurable fields of a CustomConnectorPlugin.
+type CustomConnectorPluginParameters struct {
+	// PluginName is the name of the plugin shown in Confluent Cloud.
+	PluginName string `json:"pluginName"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// DocumentationLink is a link to the documentation of the plugin.
+	// +optional
+	DocumentationLink string `json:"documentationLink,omitempty"`
+	// ConnectorClass is the Java class of the connector, e.g. io.confluent.kafka.connect.datagen.DatagenConnector. It cannot be changed once uploaded.
+	ConnectorClass string `json:"connectorClass"`
+	// ConnectorType of the plugin. It cannot be changed once uploaded.
+	// +kubebuilder:validation:Enum=SOURCE;SINK
+	ConnectorType string `json:"connectorType"`
+	// SensitiveProperties are the connector properties whose values are masked, e.g. passwords.
+	// +optional
+	SensitiveProperties []string `json:"sensitiveProperties,omitempty"`
+	// Cloud provider the plugin is uploaded to. It cannot be changed once uploaded.
+	// +kubebuilder:validation:Enum=AWS;AZURE;GCP
+	// +kubebuilder:default=AWS
+	// +optional
+	Cloud string `json:"cloud,omitempty"`
+	// Artifact is the plugin file to upload. It is uploaded once, changing it requires replacing the resource.
+	Artifact PluginArtifact `json:"artifact"`
+}
+
+// CustomConnectorPluginObservation are the observable fields of a CustomConnectorPlugin.
+type CustomConnectorPluginObservation struct {
+	// ID of the plugin, e.g. ccp-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	PluginName string `json:"pluginName,omitempty"`
+	// +optional
+	ConnectorClass string `json:"connectorClass,omitempty"`
+	// +optional
+	ConnectorType string `json:"connectorType,omitempty"`
+	// PendingName is the name of a plugin that is being uploaded. It is
+	// recorded before the plugin is uploaded, so a retry after an upload
+	// that failed without a response adopts the plugin of the name instead
+	// of uploading another one.
+	// +optional
+	PendingName string `json:"pendingName,omitempty"`
+}
+
+// CustomConnectorPluginSpec defines the desired state of a CustomConnectorPlugin.
+type CustomConnectorPluginSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CustomConnectorPluginParameters `json:"forProvider"`
+}
+
+// CustomConnectorPluginStatus represents the observed state of a CustomConnectorPlugin.
+type CustomConnectorPluginStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CustomConnectorPluginObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A CustomConnectorPlugin is a connector plugin uploaded to Confluent Cloud to run custom connectors.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type CustomConnectorPlugin struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              CustomConnectorPluginSpec   `json:"spec"`
+	Status            CustomConnectorPluginStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CustomConnectorPluginList contains a list of CustomConnectorPlugin
+type CustomConnectorPluginList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CustomConnectorPlugin `json:"items"`
+}
+
+// CustomConnectorPlugin type metadata.
+var (
+	CustomConnectorPluginKind             = reflect.TypeOf(CustomConnectorPlugin{}).Name()
+	CustomConnectorPluginGroupKind        = schema.GroupKind{Group: Group, Kind: CustomConnectorPluginKind}.String()
+	CustomConnectorPluginKindAPIVersion   = CustomConnectorPluginKind + "." + SchemeGroupVersion.String()
+	CustomConnectorPluginGroupVersionKind = SchemeGroupVersion.WithKind(CustomConnectorPluginKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&CustomConnectorPlugin{}, &CustomConnectorPluginList{})
+}
@@ -0,0 +1 @@
+package dnsforwarder //nolint
@@ -0,0 +1,93 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// DNSForwarderParameters are the configurable fields of a DNSForwarder.
+type DNSForwarderParameters struct {
+	// Name of the DNS forwarder shown in Confluent Cloud.
+	// +optional
+	Name        string `json:"name,omitempty"`
+	Environment string `json:"environment"`
+	// Gateway is the ID of the gateway of the network the DNS forwarder serves,
+	// e.g. gw-abc123. It cannot be changed once the DNS forwarder is created.
+	Gateway string `json:"gateway"`
+	// Domains are forwarded to the DNS servers, e.g. example.com.
+	// +kubebuilder:validation:MinItems=1
+	Domains []string `json:"domains"`
+	// DNSServerIPs are the IP addresses of the DNS servers queries are forwarded
+	// to.
+	// +kubebuilder:validation:MinItems=1
+	DNSServerIPs []string `json:"dnsServerIps"`
+}
+
+// DNSForwarderObservation are the observable fields of a DNSForwarder.
+type DNSForwarderObservation struct {
+	// ID of the DNS forwarder, e.g. dnsf-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Phase of the DNS forwarder, e.g. PROVISIONING, READY or FAILED.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// +optional
+	Domains []string `json:"domains,omitempty"`
+	// +optional
+	DNSServerIPs []string `json:"dnsServerIps,omitempty"`
+}
+
+// DNSForwarderSpec defines the desired state of a DNSForwarder.
+type DNSForwarderSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DNSForwarderParameters `json:"forProvider"`
+}
+
+// DNSForwarderStatus represents the observed state of a DNSForwarder.
+type DNSForwarderStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DNSForwarderObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DNSForwarder forwards DNS queries for a set of domains from the Confluent
+// Cloud network of a gateway to DNS servers of the customer, which resolve the
+// private endpoints of PrivateLink connections.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type DNSForwarder struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSForwarderSpec   `json:"spec"`
+	Status            DNSForwarderStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DNSForwarderList contains a list of DNSForwarder
+type DNSForwarderList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSForwarder `json:"items"`
+}
+
+// DNSForwarder type metadata.
+var (
+	DNSForwarderKind             = reflect.TypeOf(DNSForwarder{}).Name()
+	DNSForwarderGroupKind        = schema.GroupKind{Group: Group, Kind: DNSForwarderKind}.String()
+	DNSForwarderKindAPIVersion   = DNSForwarderKind + "." + SchemeGroupVersion.String()
+	DNSForwarderGroupVersionKind = SchemeGroupVersion.WithKind(DNSForwarderKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&DNSForwarder{}, &DNSForwarderList{})
+}
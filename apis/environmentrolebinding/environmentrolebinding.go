@@ -0,0 +1 @@
+package environmentrolebinding //nolint
@@ -0,0 +1,99 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// EnvironmentRoleBindingParameters are the configurable fields of an
+// EnvironmentRoleBinding.
+type EnvironmentRoleBindingParameters struct {
+	// ServiceAccount is the ID of the service account the role is bound to, e.g.
+	// sa-abc123.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// ServiceAccountRef references a ServiceAccount to retrieve its ID.
+	// +optional
+	ServiceAccountRef *xpv1.Reference `json:"serviceAccountRef,omitempty"`
+	// ServiceAccountSelector selects a reference to a ServiceAccount to retrieve its ID.
+	// +optional
+	ServiceAccountSelector *xpv1.Selector `json:"serviceAccountSelector,omitempty"`
+	// Role is the name of the Confluent Cloud role.
+	// +kubebuilder:validation:Enum=OrganizationAdmin;AccountAdmin;BillingAdmin;MetricsViewer;EnvironmentAdmin;DataDiscovery;DataSteward;FlinkAdmin;FlinkDeveloper;CloudClusterAdmin;Operator;ResourceKeyAdmin;DeveloperRead;DeveloperWrite;DeveloperManage;ResourceOwner
+	Role string `json:"role"`
+	// Environments are the IDs of the environments the role is bound in, e.g.
+	// env-abc123. Bindings of the role in environments removed from the list are
+	// removed.
+	// +kubebuilder:validation:MinItems=1
+	Environments []string `json:"environments"`
+}
+
+// EnvironmentRoleBindingObservation are the observable fields of an
+// EnvironmentRoleBinding.
+type EnvironmentRoleBindingObservation struct {
+	// Principal the role is bound to, e.g. User:sa-abc123.
+	// +optional
+	Principal string `json:"principal,omitempty"`
+	// Role that is bound.
+	// +optional
+	Role string `json:"role,omitempty"`
+	// Environments the role is bound in, among the current and previous
+	// environments of the EnvironmentRoleBinding.
+	// +optional
+	Environments []string `json:"environments,omitempty"`
+}
+
+// EnvironmentRoleBindingSpec defines the desired state of a EnvironmentRoleBinding.
+type EnvironmentRoleBindingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       EnvironmentRoleBindingParameters `json:"forProvider"`
+}
+
+// EnvironmentRoleBindingStatus represents the observed state of a EnvironmentRoleBinding.
+type EnvironmentRoleBindingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          EnvironmentRoleBindingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An EnvironmentRoleBinding binds a role to a service account in each of a list
+// of environments. Environments added to or removed from the list are bound or
+// unbound on the next reconcile.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type EnvironmentRoleBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              EnvironmentRoleBindingSpec   `json:"spec"`
+	Status            EnvironmentRoleBindingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EnvironmentRoleBindingList contains a list of EnvironmentRoleBinding
+type EnvironmentRoleBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EnvironmentRoleBinding `json:"items"`
+}
+
+// EnvironmentRoleBinding type metadata.
+var (
+	EnvironmentRoleBindingKind             = reflect.TypeOf(EnvironmentRoleBinding{}).Name()
+	EnvironmentRoleBindingGroupKind        = schema.GroupKind{Group: Group, Kind: EnvironmentRoleBindingKind}.String()
+	EnvironmentRoleBindingKindAPIVersion   = EnvironmentRoleBindingKind + "." + SchemeGroupVersion.String()
+	EnvironmentRoleBindingGroupVersionKind = SchemeGroupVersion.WithKind(EnvironmentRoleBindingKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&EnvironmentRoleBinding{}, &EnvironmentRoleBindingList{})
+}
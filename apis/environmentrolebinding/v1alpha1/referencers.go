@@ -0,0 +1,39 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikeyapi "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	"github.com/dfds/provider-confluent/apis/common"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+// ResolveReferences of this EnvironmentRoleBinding resolves the ID of the ServiceAccount the role is bound to.
+func (mg *EnvironmentRoleBinding) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ServiceAccount,
+		Extract:      apikeyapi.ServiceAccountID(),
+		Reference:    mg.Spec.ForProvider.ServiceAccountRef,
+		Selector:     mg.Spec.ForProvider.ServiceAccountSelector,
+		To: reference.To{
+			List:    &saapi.ServiceAccountList{},
+			Managed: &saapi.ServiceAccount{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.serviceAccount")
+	}
+	if err := common.ReferenceReady(ctx, c, rsp.ResolvedReference, &saapi.ServiceAccount{}); err != nil {
+		return errors.Wrap(err, "spec.forProvider.serviceAccount")
+	}
+	mg.Spec.ForProvider.ServiceAccount = rsp.ResolvedValue
+	mg.Spec.ForProvider.ServiceAccountRef = rsp.ResolvedReference
+
+	return nil
+}
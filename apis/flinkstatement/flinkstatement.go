@@ -0,0 +1 @@
+package flinkstatement //nolint
@@ -0,0 +1,97 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// FlinkStatementParameters are the configurable fields of a FlinkStatement.
+type FlinkStatementParameters struct {
+	// Statement is the Flink SQL statement text. Statements are immutable, changing the text deletes the statement and submits it again.
+	Statement string `json:"statement"`
+	// ComputePool is the ID of the Flink compute pool running the statement, e.g. lfcp-abc123.
+	ComputePool string `json:"computePool"`
+	Environment string `json:"environment"`
+	// Database is the Kafka cluster used as the default database of the statement.
+	// +optional
+	Database string `json:"database,omitempty"`
+	// ServiceAccount is the ID of the service account the statement runs as.
+	// +optional
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+	// Properties are the Flink SQL properties the statement is submitted with, e.g. sql.local-time-zone.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// FlinkStatementObservation are the observable fields of a FlinkStatement.
+type FlinkStatementObservation struct {
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// ComputePool is the ID of the compute pool the statement was submitted to.
+	// +optional
+	ComputePool string `json:"computePool,omitempty"`
+	// Statement is the statement text that was submitted.
+	// +optional
+	Statement string `json:"statement,omitempty"`
+	// Phase of the statement reported by Flink, e.g. PENDING, RUNNING, COMPLETED or FAILED.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// ExceptionMessage is the reason reported by Flink when the statement failed.
+	// +optional
+	ExceptionMessage string `json:"exceptionMessage,omitempty"`
+}
+
+// FlinkStatementSpec defines the desired state of a FlinkStatement.
+type FlinkStatementSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FlinkStatementParameters `json:"forProvider"`
+}
+
+// FlinkStatementStatus represents the observed state of a FlinkStatement.
+type FlinkStatementStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FlinkStatementObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A FlinkStatement is a Flink SQL statement running on a Confluent Cloud Flink compute pool.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type FlinkStatement struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              FlinkStatementSpec   `json:"spec"`
+	Status            FlinkStatementStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FlinkStatementList contains a list of FlinkStatement
+type FlinkStatementList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FlinkStatement `json:"items"`
+}
+
+// FlinkStatement type metadata.
+var (
+	FlinkStatementKind             = reflect.TypeOf(FlinkStatement{}).Name()
+	FlinkStatementGroupKind        = schema.GroupKind{Group: Group, Kind: FlinkStatementKind}.String()
+	FlinkStatementKindAPIVersion   = FlinkStatementKind + "." + SchemeGroupVersion.String()
+	FlinkStatementGroupVersionKind = SchemeGroupVersion.WithKind(FlinkStatementKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&FlinkStatement{}, &FlinkStatementList{})
+}
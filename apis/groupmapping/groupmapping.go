@@ -0,0 +1 @@
+package groupmapping //nolint
@@ -0,0 +1,109 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// GroupMappingParameters are the configurable fields of a GroupMapping.
+type GroupMappingParameters struct {
+	// DisplayName of the group mapping shown in Confluent Cloud.
+	DisplayName string `json:"displayName"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// Filter is the expression matching the group claims of SSO users, e.g.
+	// "engineering" in groups.
+	Filter string `json:"filter"`
+	// RoleBindings are the roles granted to the users of the group. Role bindings
+	// of the group not listed here are removed.
+	// +optional
+	RoleBindings []GroupRoleBinding `json:"roleBindings,omitempty"`
+}
+
+// GroupRoleBinding grants a role to the users of a group mapping. The role is
+// bound to the organization, or to an environment or cluster when given.
+type GroupRoleBinding struct {
+	// Role is the name of the Confluent Cloud role.
+	// +kubebuilder:validation:Enum=OrganizationAdmin;AccountAdmin;BillingAdmin;MetricsViewer;EnvironmentAdmin;DataDiscovery;DataSteward;FlinkAdmin;FlinkDeveloper;CloudClusterAdmin;Operator;ResourceKeyAdmin;DeveloperRead;DeveloperWrite;DeveloperManage;ResourceOwner
+	Role string `json:"role"`
+	// Environment the role is bound to. Required when Cluster is set.
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// Cluster the role is bound to.
+	// +optional
+	Cluster string `json:"cluster,omitempty"`
+}
+
+// GroupMappingObservation are the observable fields of a GroupMapping.
+type GroupMappingObservation struct {
+	// ID of the group mapping, e.g. group-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// Principal of the group mapping in role bindings, e.g. User:group-abc123.
+	// +optional
+	Principal string `json:"principal,omitempty"`
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +optional
+	Filter string `json:"filter,omitempty"`
+	// RoleBindings of the group mapping in the scopes of its current and previous
+	// role bindings.
+	// +optional
+	RoleBindings []GroupRoleBinding `json:"roleBindings,omitempty"`
+}
+
+// GroupMappingSpec defines the desired state of a GroupMapping.
+type GroupMappingSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       GroupMappingParameters `json:"forProvider"`
+}
+
+// GroupMappingStatus represents the observed state of a GroupMapping.
+type GroupMappingStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          GroupMappingObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A GroupMapping maps the users of an SSO group to Confluent Cloud roles. Users
+// whose group claims match its filter get the roles of its role bindings.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type GroupMapping struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GroupMappingSpec   `json:"spec"`
+	Status            GroupMappingStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GroupMappingList contains a list of GroupMapping
+type GroupMappingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GroupMapping `json:"items"`
+}
+
+// GroupMapping type metadata.
+var (
+	GroupMappingKind             = reflect.TypeOf(GroupMapping{}).Name()
+	GroupMappingGroupKind        = schema.GroupKind{Group: Group, Kind: GroupMappingKind}.String()
+	GroupMappingKindAPIVersion   = GroupMappingKind + "." + SchemeGroupVersion.String()
+	GroupMappingGroupVersionKind = SchemeGroupVersion.WithKind(GroupMappingKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&GroupMapping{}, &GroupMappingList{})
+}
@@ -0,0 +1 @@
+package ipfilter //nolint
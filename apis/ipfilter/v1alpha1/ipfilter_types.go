@@ -0,0 +1,98 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// IPFilterParameters are the configurable fields of an IPFilter.
+type IPFilterParameters struct {
+	// FilterName is the name of the IP filter shown in Confluent Cloud.
+	FilterName string `json:"filterName"`
+	// ResourceGroup the filter applies to. management covers the management APIs only, multiple covers the operation groups given in OperationGroups.
+	// +kubebuilder:validation:Enum=management;multiple
+	// +kubebuilder:default=management
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// OperationGroups the filter applies to when ResourceGroup is multiple, e.g. MANAGEMENT, SCHEMA or FLINK.
+	// +optional
+	OperationGroups []string `json:"operationGroups,omitempty"`
+	// IPGroups are the IDs of the IP groups access is allowed from.
+	// +optional
+	IPGroups []string `json:"ipGroups,omitempty"`
+	// IPGroupRefs reference IPGroups to retrieve their IDs.
+	// +crossplane:generate:reference:type=github.com/dfds/provider-confluent/apis/ipgroup/v1alpha1.IPGroup
+	// +optional
+	IPGroupRefs []xpv1.Reference `json:"ipGroupRefs,omitempty"`
+	// IPGroupSelector selects references to IPGroups to retrieve their IDs.
+	// +optional
+	IPGroupSelector *xpv1.Selector `json:"ipGroupSelector,omitempty"`
+}
+
+// IPFilterObservation are the observable fields of an IPFilter.
+type IPFilterObservation struct {
+	// ID of the IP filter, e.g. ipf-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	FilterName string `json:"filterName,omitempty"`
+	// +optional
+	ResourceGroup string `json:"resourceGroup,omitempty"`
+	// +optional
+	OperationGroups []string `json:"operationGroups,omitempty"`
+	// +optional
+	IPGroups []string `json:"ipGroups,omitempty"`
+}
+
+// IPFilterSpec defines the desired state of a IPFilter.
+type IPFilterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       IPFilterParameters `json:"forProvider"`
+}
+
+// IPFilterStatus represents the observed state of a IPFilter.
+type IPFilterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          IPFilterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An IPFilter limits access to the resources of a Confluent Cloud organization to the CIDR blocks of its IP groups.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type IPFilter struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IPFilterSpec   `json:"spec"`
+	Status            IPFilterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPFilterList contains a list of IPFilter
+type IPFilterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPFilter `json:"items"`
+}
+
+// IPFilter type metadata.
+var (
+	IPFilterKind             = reflect.TypeOf(IPFilter{}).Name()
+	IPFilterGroupKind        = schema.GroupKind{Group: Group, Kind: IPFilterKind}.String()
+	IPFilterKindAPIVersion   = IPFilterKind + "." + SchemeGroupVersion.String()
+	IPFilterGroupVersionKind = SchemeGroupVersion.WithKind(IPFilterKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&IPFilter{}, &IPFilterList{})
+}
@@ -0,0 +1,50 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/common"
+	ipgroupapi "github.com/dfds/provider-confluent/apis/ipgroup/v1alpha1"
+)
+
+// IPGroupID extracts the Confluent ID of an IPGroup, which is only known once the ip group exists.
+func IPGroupID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		ig, ok := mg.(*ipgroupapi.IPGroup)
+		if !ok {
+			return ""
+		}
+		return ig.Status.AtProvider.ID
+	}
+}
+
+// ResolveReferences of this IPFilter resolves the IDs of the IPGroups it allows access from.
+func (mg *IPFilter) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.ForProvider.IPGroups,
+		Extract:       IPGroupID(),
+		References:    mg.Spec.ForProvider.IPGroupRefs,
+		Selector:      mg.Spec.ForProvider.IPGroupSelector,
+		To: reference.To{
+			List:    &ipgroupapi.IPGroupList{},
+			Managed: &ipgroupapi.IPGroup{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.ipGroups")
+	}
+	if err := common.ReferencesReady(ctx, c, rsp.ResolvedReferences, func() resource.Managed { return &ipgroupapi.IPGroup{} }); err != nil {
+		return errors.Wrap(err, "spec.forProvider.ipGroups")
+	}
+	mg.Spec.ForProvider.IPGroups = rsp.ResolvedValues
+	mg.Spec.ForProvider.IPGroupRefs = rsp.ResolvedReferences
+
+	return nil
+}
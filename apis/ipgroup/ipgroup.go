@@ -0,0 +1 @@
+package ipgroup //nolint
@@ -0,0 +1,79 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// IPGroupParameters are the configurable fields of an IPGroup.
+type IPGroupParameters struct {
+	// GroupName is the name of the IP group shown in Confluent Cloud.
+	GroupName string `json:"groupName"`
+	// CIDRBlocks are the IPv4 ranges of the group, e.g. 192.0.2.0/24.
+	// +kubebuilder:validation:MinItems=1
+	CIDRBlocks []string `json:"cidrBlocks"`
+}
+
+// IPGroupObservation are the observable fields of an IPGroup.
+type IPGroupObservation struct {
+	// ID of the IP group, e.g. ipg-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	GroupName string `json:"groupName,omitempty"`
+	// +optional
+	CIDRBlocks []string `json:"cidrBlocks,omitempty"`
+}
+
+// IPGroupSpec defines the desired state of a IPGroup.
+type IPGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       IPGroupParameters `json:"forProvider"`
+}
+
+// IPGroupStatus represents the observed state of a IPGroup.
+type IPGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          IPGroupObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An IPGroup is a named set of CIDR blocks that IP filters allow access from.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type IPGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IPGroupSpec   `json:"spec"`
+	Status            IPGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPGroupList contains a list of IPGroup
+type IPGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPGroup `json:"items"`
+}
+
+// IPGroup type metadata.
+var (
+	IPGroupKind             = reflect.TypeOf(IPGroup{}).Name()
+	IPGroupGroupKind        = schema.GroupKind{Group: Group, Kind: IPGroupKind}.String()
+	IPGroupKindAPIVersion   = IPGroupKind + "." + SchemeGroupVersion.String()
+	IPGroupGroupVersionKind = SchemeGroupVersion.WithKind(IPGroupKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&IPGroup{}, &IPGroupList{})
+}
@@ -0,0 +1 @@
+package kafkacluster //nolint
@@ -0,0 +1,120 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// KafkaClusterParameters are the configurable fields of a KafkaCluster.
+type KafkaClusterParameters struct {
+	// Environment of the cluster, e.g. env-abc123.
+	Environment string `json:"environment"`
+	// CloudProvider hosting the cluster. It cannot be changed once the cluster
+	// is created.
+	// +kubebuilder:validation:Enum=aws;gcp;azure
+	CloudProvider string `json:"cloudProvider"`
+	// Region of the cloud provider hosting the cluster, e.g. eu-west-1. It
+	// cannot be changed once the cluster is created.
+	Region string `json:"region"`
+	// Availability of the cluster. It cannot be changed once the cluster is
+	// created.
+	// +kubebuilder:validation:Enum=single-zone;multi-zone
+	// +kubebuilder:default=single-zone
+	// +optional
+	Availability string `json:"availability,omitempty"`
+	// Type of the cluster. It cannot be changed once the cluster is created.
+	// +kubebuilder:validation:Enum=basic;standard;dedicated
+	// +kubebuilder:default=basic
+	// +optional
+	Type string `json:"type,omitempty"`
+	// CKU is the number of Confluent units of a dedicated cluster. Changing it
+	// expands or shrinks the cluster.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	CKU *int `json:"cku,omitempty"`
+}
+
+// KafkaClusterObservation are the observable fields of a KafkaCluster.
+type KafkaClusterObservation struct {
+	// ID of the cluster, e.g. lkc-abc123.
+	// +optional
+	ID string `json:"id,omitempty"`
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// +optional
+	CloudProvider string `json:"cloudProvider,omitempty"`
+	// +optional
+	Region string `json:"region,omitempty"`
+	// +optional
+	Availability string `json:"availability,omitempty"`
+	// +optional
+	Type string `json:"type,omitempty"`
+	// +optional
+	CKU int `json:"cku,omitempty"`
+	// Endpoint is the bootstrap endpoint of the cluster.
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+	// Status of the cluster reported by Confluent Cloud, e.g. PROVISIONING or
+	// UP.
+	// +optional
+	Status string `json:"status,omitempty"`
+	// PendingName is the name of a cluster that is being created. It is
+	// recorded before the cluster is created, so a retry adopts the cluster
+	// of the recorded ID instead of creating another one.
+	// +optional
+	PendingName string `json:"pendingName,omitempty"`
+}
+
+// KafkaClusterSpec defines the desired state of a KafkaCluster.
+type KafkaClusterSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KafkaClusterParameters `json:"forProvider"`
+}
+
+// KafkaClusterStatus represents the observed state of a KafkaCluster.
+type KafkaClusterStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KafkaClusterObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KafkaCluster is a Kafka cluster in a Confluent Cloud environment. Its
+// connection secret holds the bootstrap endpoint of the cluster.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type KafkaCluster struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              KafkaClusterSpec   `json:"spec"`
+	Status            KafkaClusterStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KafkaClusterList contains a list of KafkaCluster
+type KafkaClusterList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KafkaCluster `json:"items"`
+}
+
+// KafkaCluster type metadata.
+var (
+	KafkaClusterKind             = reflect.TypeOf(KafkaCluster{}).Name()
+	KafkaClusterGroupKind        = schema.GroupKind{Group: Group, Kind: KafkaClusterKind}.String()
+	KafkaClusterKindAPIVersion   = KafkaClusterKind + "." + SchemeGroupVersion.String()
+	KafkaClusterGroupVersionKind = SchemeGroupVersion.WithKind(KafkaClusterKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&KafkaCluster{}, &KafkaClusterList{})
+}
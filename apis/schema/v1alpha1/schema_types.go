@@ -16,6 +16,13 @@ type SchemaParameters struct {
 	Schema        string `json:"schema"`
 	SchemaType    string `json:"schemaType"`
 	Environment   string `json:"environment"`
+
+	// APIKeyRef references an APIKey scoped to the schema registry of the
+	// environment. Its connection secret holds the key used for the schema
+	// registry. The API credentials of the ProviderConfig are used when it is not
+	// set.
+	// +optional
+	APIKeyRef *xpv1.Reference `json:"apiKeyRef,omitempty"`
 }
 
 // SchemaObservation are the observable fields of a Schema.
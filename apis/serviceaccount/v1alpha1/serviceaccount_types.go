@@ -10,13 +10,46 @@ import (
 )
 
 // ServiceAccountParameters are the configurable fields of a ServiceAccount.
+// Service accounts are scoped to the Confluent Cloud organization; there is no
+// environment-scoped variant, so a ServiceAccount is never bound to an environment.
 type ServiceAccountParameters struct {
-	Description string `json:"description"`
+	// Description of the service account. When omitted the description is
+	// late-initialized from Confluent Cloud; an explicit empty string clears it.
+	// +optional
+	Description *string `json:"description,omitempty"`
+
+	// APIKey, when set, creates an API key owned by the service account and
+	// writes it to the connection secret as api-key and api-secret. Confluent
+	// Cloud only returns the secret when the key is created, so the key is
+	// created once and never regenerated.
+	// +optional
+	APIKey *ServiceAccountAPIKey `json:"apiKey,omitempty"`
+}
+
+// ServiceAccountAPIKey is the API key created for a ServiceAccount.
+type ServiceAccountAPIKey struct {
+	// Resource the key grants access to, either cloud or the ID of a cluster,
+	// e.g. lkc-abc123.
+	Resource string `json:"resource"`
+
+	// Environment of the cluster. Required unless Resource is cloud.
+	// +optional
+	Environment string `json:"environment,omitempty"`
 }
 
 // ServiceAccountObservation are the observable fields of a ServiceAccount.
 type ServiceAccountObservation struct {
 	ID string `json:"id,omitempty"`
+
+	// PendingName is the name of a service account that is being created. It
+	// is recorded before the service account is created, so a retry after a
+	// failure to record the ID adopts the created service account.
+	// +optional
+	PendingName string `json:"pendingName,omitempty"`
+
+	// APIKey is the key of the API key created for the service account.
+	// +optional
+	APIKey string `json:"apiKey,omitempty"`
 }
 
 // ServiceAccountSpec defines the desired state of a ServiceAccount.
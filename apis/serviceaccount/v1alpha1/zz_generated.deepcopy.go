@@ -52,6 +52,21 @@ func (in *ServiceAccount) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountAPIKey) DeepCopyInto(out *ServiceAccountAPIKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountAPIKey.
+func (in *ServiceAccountAPIKey) DeepCopy() *ServiceAccountAPIKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountAPIKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountList) DeepCopyInto(out *ServiceAccountList) {
 	*out = *in
@@ -102,6 +117,16 @@ func (in *ServiceAccountObservation) DeepCopy() *ServiceAccountObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountParameters) DeepCopyInto(out *ServiceAccountParameters) {
 	*out = *in
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIKey != nil {
+		in, out := &in.APIKey, &out.APIKey
+		*out = new(ServiceAccountAPIKey)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountParameters.
@@ -118,7 +143,7 @@ func (in *ServiceAccountParameters) DeepCopy() *ServiceAccountParameters {
 func (in *ServiceAccountSpec) DeepCopyInto(out *ServiceAccountSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountSpec.
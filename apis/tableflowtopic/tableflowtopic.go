@@ -0,0 +1 @@
+package tableflowtopic //nolint
@@ -0,0 +1,38 @@
+package v1alpha1
+
+import (
+	"context"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/common"
+	topicv1alpha1 "github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+)
+
+// ResolveReferences of this TableflowTopic resolves the name of the referenced Topic.
+func (mg *TableflowTopic) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.Topic,
+		Extract:      reference.ExternalName(),
+		Reference:    mg.Spec.ForProvider.TopicRef,
+		Selector:     mg.Spec.ForProvider.TopicSelector,
+		To: reference.To{
+			List:    &topicv1alpha1.TopicList{},
+			Managed: &topicv1alpha1.Topic{},
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.topic")
+	}
+	if err := common.ReferenceReady(ctx, c, rsp.ResolvedReference, &topicv1alpha1.Topic{}); err != nil {
+		return errors.Wrap(err, "spec.forProvider.topic")
+	}
+	mg.Spec.ForProvider.Topic = rsp.ResolvedValue
+	mg.Spec.ForProvider.TopicRef = rsp.ResolvedReference
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// TableflowStorage is the storage configuration of a TableflowTopic
+type TableflowStorage struct {
+	// Type of the storage backing the materialized tables. Managed storage is provisioned by Confluent, BYOS stores the tables in a bucket of your own.
+	// +kubebuilder:validation:Enum=MANAGED;BYOS
+	// +kubebuilder:default=MANAGED
+	// +optional
+	Type string `json:"type,omitempty"`
+	// ProviderIntegration is the ID of the provider integration granting access to the bucket. Required for BYOS storage.
+	// +optional
+	ProviderIntegration string `json:"providerIntegration,omitempty"`
+	// BucketName is the name of the bucket the tables are stored in. Required for BYOS storage.
+	// +optional
+	BucketName string `json:"bucketName,omitempty"`
+}
+
+// TableflowTopicParameters are the configurable fields of a TableflowTopic.
+type TableflowTopicParameters struct {
+	// Topic is the name of the Kafka topic to materialize.
+	// +optional
+	Topic string `json:"topic,omitempty"`
+	// TopicRef references a Topic to retrieve its name.
+	// +optional
+	TopicRef *xpv1.Reference `json:"topicRef,omitempty"`
+	// TopicSelector selects a reference to a Topic to retrieve its name.
+	// +optional
+	TopicSelector *xpv1.Selector `json:"topicSelector,omitempty"`
+	Environment   string         `json:"environment"`
+	Cluster       string         `json:"cluster"`
+	// +optional
+	Storage TableflowStorage `json:"storage,omitempty"`
+	// TableFormats are the open table formats the topic is materialized to, ICEBERG and/or DELTA.
+	// +optional
+	TableFormats []string `json:"tableFormats,omitempty"`
+	// RetentionMs is how long the materialized table data is kept.
+	// +optional
+	RetentionMs int64 `json:"retentionMs,omitempty"`
+}
+
+// TableflowTopicObservation are the observable fields of a TableflowTopic.
+type TableflowTopicObservation struct {
+	Topic       string `json:"topic"`
+	Environment string `json:"environment"`
+	Cluster     string `json:"cluster"`
+	// Phase is the materialization state reported by Tableflow, e.g. PENDING, RUNNING, SUSPENDED or FAILED.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+	// ErrorMessage is the reason reported by Tableflow when materialization failed.
+	// +optional
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// TableflowTopicSpec defines the desired state of a TableflowTopic.
+type TableflowTopicSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TableflowTopicParameters `json:"forProvider"`
+}
+
+// TableflowTopicStatus represents the observed state of a TableflowTopic.
+type TableflowTopicStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TableflowTopicObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A TableflowTopic materializes a Kafka Topic to open table formats using Confluent Tableflow.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type TableflowTopic struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              TableflowTopicSpec   `json:"spec"`
+	Status            TableflowTopicStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TableflowTopicList contains a list of TableflowTopic
+type TableflowTopicList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TableflowTopic `json:"items"`
+}
+
+// TableflowTopic type metadata.
+var (
+	TableflowTopicKind             = reflect.TypeOf(TableflowTopic{}).Name()
+	TableflowTopicGroupKind        = schema.GroupKind{Group: Group, Kind: TableflowTopicKind}.String()
+	TableflowTopicKindAPIVersion   = TableflowTopicKind + "." + SchemeGroupVersion.String()
+	TableflowTopicGroupVersionKind = SchemeGroupVersion.WithKind(TableflowTopicKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&TableflowTopic{}, &TableflowTopicList{})
+}
@@ -9,9 +9,21 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// Config keys of a topic in Confluent Cloud that the fields of Config set
+const (
+	ConfigKeyRetention = "retention.ms"
+)
+
+// ConfigKeys are the config keys the provider can manage
+var ConfigKeys = []string{ConfigKeyRetention}
+
 // Config is the config of a TopicConfig
 type Config struct {
 	Retention int64 `json:"retention"`
+	// Settings are further config keys of the topic & their values, e.g. cleanup.policy: compact. Keys not listed keep the value
+	// Confluent defaults them to. retention.ms is set by Retention.
+	// +optional
+	Settings map[string]string `json:"settings,omitempty"`
 }
 
 // TopicConfig is the config of a Topic
@@ -19,6 +31,24 @@ type TopicConfig struct {
 	Name       string `json:"name"`
 	Partitions int    `json:"partitions"`
 	Config     Config `json:"config"`
+	// ManagedConfigKeys are the config keys of the topic the provider reads and writes, e.g. retention.ms. Keys not listed are left to
+	// other tools, an empty list leaves all of them. Defaults to all keys of Config.
+	// +optional
+	ManagedConfigKeys []string `json:"managedConfigKeys"`
+}
+
+// ManagesConfigKey Checks if the provider reads and writes the config key of the topic. Unset ManagedConfigKeys manage every key, unlike
+// an empty list, which is kept apart as the field is not omitted when empty
+func (tc TopicConfig) ManagesConfigKey(key string) bool {
+	if tc.ManagedConfigKeys == nil {
+		return true
+	}
+	for _, k := range tc.ManagedConfigKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
 }
 
 // TopicParameters are the configurable fields of a Topic.
@@ -28,11 +58,21 @@ type TopicParameters struct {
 	Cluster     string      `json:"cluster"`
 }
 
+// PartitionObservation is the observed replica assignment of a single partition of a Topic.
+type PartitionObservation struct {
+	Partition int   `json:"partition"`
+	Leader    int   `json:"leader"`
+	Replicas  []int `json:"replicas,omitempty"`
+	ISR       []int `json:"isr,omitempty"`
+}
+
 // TopicObservation are the observable fields of a Topic.
 type TopicObservation struct {
 	Environment string `json:"environment"`
 	Cluster     string `json:"cluster"`
 	Name        string `json:"name"`
+	// Partitions is the observed replica assignment and leader distribution of the Topic. It is observe-only.
+	Partitions []PartitionObservation `json:"partitions,omitempty"`
 }
 
 // TopicSpec defines the desired state of a Topic.
@@ -28,6 +28,13 @@ import (
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Config) DeepCopyInto(out *Config) {
 	*out = *in
+	if in.Settings != nil {
+		in, out := &in.Settings, &out.Settings
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Config.
@@ -40,6 +47,31 @@ func (in *Config) DeepCopy() *Config {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PartitionObservation) DeepCopyInto(out *PartitionObservation) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.ISR != nil {
+		in, out := &in.ISR, &out.ISR
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PartitionObservation.
+func (in *PartitionObservation) DeepCopy() *PartitionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PartitionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Topic) DeepCopyInto(out *Topic) {
 	*out = *in
@@ -70,7 +102,12 @@ func (in *Topic) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicConfig) DeepCopyInto(out *TopicConfig) {
 	*out = *in
-	out.Config = in.Config
+	in.Config.DeepCopyInto(&out.Config)
+	if in.ManagedConfigKeys != nil {
+		in, out := &in.ManagedConfigKeys, &out.ManagedConfigKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicConfig.
@@ -118,6 +155,13 @@ func (in *TopicList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicObservation) DeepCopyInto(out *TopicObservation) {
 	*out = *in
+	if in.Partitions != nil {
+		in, out := &in.Partitions, &out.Partitions
+		*out = make([]PartitionObservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicObservation.
@@ -133,7 +177,7 @@ func (in *TopicObservation) DeepCopy() *TopicObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TopicParameters) DeepCopyInto(out *TopicParameters) {
 	*out = *in
-	out.Topic = in.Topic
+	in.Topic.DeepCopyInto(&out.Topic)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicParameters.
@@ -150,7 +194,7 @@ func (in *TopicParameters) DeepCopy() *TopicParameters {
 func (in *TopicSpec) DeepCopyInto(out *TopicSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	out.ForProvider = in.ForProvider
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicSpec.
@@ -167,7 +211,7 @@ func (in *TopicSpec) DeepCopy() *TopicSpec {
 func (in *TopicStatus) DeepCopyInto(out *TopicStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopicStatus.
@@ -0,0 +1 @@
+package usagereport //nolint
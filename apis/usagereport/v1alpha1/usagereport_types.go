@@ -0,0 +1,121 @@
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// UsageReportParameters select the usage that is reported.
+type UsageReportParameters struct {
+	// Environment limits the report to the usage of a single environment. The usage
+	// of the whole organization is reported when it is empty.
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// Days is the number of days before today that are reported.
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=365
+	// +optional
+	Days int `json:"days,omitempty"`
+	// RefreshMinutes is the minimum time between two requests of the usage to
+	// Confluent Cloud. The usage is reported once a day by Confluent Cloud, so
+	// frequent refreshes only use up the quota of the billing API.
+	// +kubebuilder:default=360
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	RefreshMinutes int `json:"refreshMinutes,omitempty"`
+}
+
+// UsageLine is the usage of a single product line, e.g. the storage or the
+// network throughput of Kafka clusters.
+type UsageLine struct {
+	// Product is the billed product, e.g. KAFKA or CONNECT.
+	Product string `json:"product"`
+	// LineType is the billed part of the product, e.g. KAFKA_STORAGE or
+	// KAFKA_NETWORK_WRITE.
+	LineType string `json:"lineType"`
+	// Unit of the quantity, e.g. GB-hour or GB.
+	// +optional
+	Unit string `json:"unit,omitempty"`
+	// Quantity is the used amount of Unit.
+	Quantity string `json:"quantity"`
+	// Amount is the cost of the usage in USD.
+	Amount string `json:"amount"`
+}
+
+// UsageReportObservation are the observable fields of a UsageReport.
+type UsageReportObservation struct {
+	// Environment is the environment of the reported usage, if any.
+	// +optional
+	Environment string `json:"environment,omitempty"`
+	// StartDate is the first reported day.
+	// +optional
+	StartDate string `json:"startDate,omitempty"`
+	// EndDate is the day after the last reported day.
+	// +optional
+	EndDate string `json:"endDate,omitempty"`
+	// LastRefreshTime is when the usage was last requested from Confluent Cloud.
+	// +optional
+	LastRefreshTime string `json:"lastRefreshTime,omitempty"`
+	// TotalAmount is the cost of all reported usage in USD.
+	// +optional
+	TotalAmount string `json:"totalAmount,omitempty"`
+	// Usage is the reported usage per product line.
+	// +optional
+	Usage []UsageLine `json:"usage,omitempty"`
+}
+
+// UsageReportSpec defines the desired state of a UsageReport.
+type UsageReportSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       UsageReportParameters `json:"forProvider"`
+}
+
+// UsageReportStatus represents the observed state of a UsageReport.
+type UsageReportStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          UsageReportObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A UsageReport reports the usage and cost of the organization, or of a single
+// environment, over the last days. It is observation-only: the provider never
+// creates, changes or deletes anything in Confluent Cloud for it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,confluent}
+type UsageReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              UsageReportSpec   `json:"spec"`
+	Status            UsageReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UsageReportList contains a list of UsageReport
+type UsageReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UsageReport `json:"items"`
+}
+
+// UsageReport type metadata.
+var (
+	UsageReportKind             = reflect.TypeOf(UsageReport{}).Name()
+	UsageReportGroupKind        = schema.GroupKind{Group: Group, Kind: UsageReportKind}.String()
+	UsageReportKindAPIVersion   = UsageReportKind + "." + SchemeGroupVersion.String()
+	UsageReportGroupVersionKind = SchemeGroupVersion.WithKind(UsageReportKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&UsageReport{}, &UsageReportList{})
+}
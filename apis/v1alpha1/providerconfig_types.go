@@ -34,6 +34,41 @@ type ProviderConfigSpec struct {
 
 	// Credentials required to authenticate to this provider.
 	APICredentials []clients.APICredentials `json:"apiCredentials"`
+
+	// CABundleRef references additional PEM encoded CA certificates trusted
+	// for the Confluent endpoint, e.g. when running behind a TLS intercepting
+	// proxy.
+	// +optional
+	CABundleRef *clients.CABundleReference `json:"caBundleRef,omitempty"`
+
+	// Endpoint of the Confluent Cloud API to log in to, e.g. a regional
+	// endpoint, a gateway in front of Confluent Cloud or a mock server for
+	// tests. Defaults to the production endpoint of Confluent Cloud.
+	// +kubebuilder:validation:Pattern=`^https?://`
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Environments this ProviderConfig serves. Managed resources in one of these
+	// environments that don't reference a ProviderConfig, or reference the
+	// default one, are pointed at this ProviderConfig. An environment may only
+	// be served by one ProviderConfig.
+	// +optional
+	Environments []string `json:"environments,omitempty"`
+
+	// OrganizationID of the Confluent Cloud organization to log in to, for
+	// credentials with access to more than one organization. Resources are
+	// only created in and observed from this organization. Defaults to the
+	// default organization of the credentials.
+	// +optional
+	OrganizationID string `json:"organizationId,omitempty"`
+
+	// Proxy is the URL of an HTTP proxy the Confluent CLI connects through,
+	// overriding the HTTPS_PROXY and HTTP_PROXY environment variables of the
+	// provider. Hosts in NO_PROXY are still connected to directly. Defaults to
+	// the proxy environment variables.
+	// +kubebuilder:validation:Pattern=`^(https?|socks5)://`
+	// +optional
+	Proxy string `json:"proxy,omitempty"`
 }
 
 // ProviderCredentials required to authenticate.
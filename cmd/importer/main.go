@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/alecthomas/kingpin.v2"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/acl"
+	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/importer"
+)
+
+func main() {
+	var (
+		app            = kingpin.New(filepath.Base(os.Args[0]), "Write managed resources adopting the existing state of a Confluent Cloud organization.").DefaultEnvars()
+		environment    = app.Flag("environment", "Environment to import ACLs from. Requires --cluster.").String()
+		cluster        = app.Flag("cluster", "Cluster to import ACLs from. Requires --environment.").String()
+		fromPrincipal  = app.Flag("from-principal", "Only import the ACLs of this service account, e.g. sa-12345, merging bindings that differ only by operation. Requires --environment and --cluster.").String()
+		providerConfig = app.Flag("provider-config", "Name of the ProviderConfig the managed resources refer to.").Default("default").String()
+		deletionPolicy = app.Flag("deletion-policy", "Deletion policy of the managed resources.").Default(string(xpv1.DeletionOrphan)).Enum(string(xpv1.DeletionOrphan), string(xpv1.DeletionDelete))
+		output         = app.Flag("output", "File to write the managed resources to. Defaults to stdout.").Short('o').String()
+		email          = app.Flag("email", "Email to log in to Confluent Cloud with. Uses the current Confluent CLI login when omitted.").OverrideDefaultFromEnvar(clients.ConflientUsernameEnvKey).String()
+		password       = app.Flag("password", "Password to log in to Confluent Cloud with.").OverrideDefaultFromEnvar(clients.ConfluentPasswordEnvKey).String()
+	)
+	kingpin.MustParse(app.Parse(os.Args[1:]))
+	ctx := context.Background()
+
+	if *email != "" {
+		kingpin.FatalIfError(clients.NewClient(clients.Config{}).Authenticate(ctx, *email, *password), "Cannot log in to Confluent Cloud")
+	}
+
+	i := importer.Importer{
+		ServiceAccounts: serviceaccount.NewClient(serviceaccount.Config{MaxRetries: serviceaccount.DefaultMaxRetries, BaseDelay: serviceaccount.DefaultBaseDelay}),
+		ACLs:            acl.NewClient(acl.Config{}),
+	}
+	o := importer.Options{
+		Environment:    *environment,
+		Cluster:        *cluster,
+		ProviderConfig: *providerConfig,
+		DeletionPolicy: xpv1.DeletionPolicy(*deletionPolicy),
+	}
+
+	var manifests []importer.Manifest
+	var err error
+	if *fromPrincipal != "" {
+		manifests, err = i.ImportPrincipal(ctx, o, *fromPrincipal)
+	} else {
+		manifests, err = i.Import(ctx, o)
+	}
+	kingpin.FatalIfError(err, "Cannot import Confluent resources")
+
+	out, err := importer.Write(manifests)
+	kingpin.FatalIfError(err, "Cannot write managed resources")
+
+	if *output == "" {
+		_, err = os.Stdout.Write(out)
+	} else {
+		err = ioutil.WriteFile(*output, out, 0600)
+	}
+	kingpin.FatalIfError(err, "Cannot write managed resources")
+}
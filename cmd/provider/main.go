@@ -28,15 +28,48 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 
 	"github.com/dfds/provider-confluent/apis"
+	"github.com/dfds/provider-confluent/internal/clients"
 	"github.com/dfds/provider-confluent/internal/controller"
+	"github.com/dfds/provider-confluent/internal/controller/acl"
+	"github.com/dfds/provider-confluent/internal/controller/debuglog"
+	"github.com/dfds/provider-confluent/internal/controller/dnsforwarder"
+	"github.com/dfds/provider-confluent/internal/controller/errorbudget"
+	"github.com/dfds/provider-confluent/internal/controller/ipfilter"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/shutdown"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/tableflowtopic"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
 )
 
 func main() {
 	var (
 		app = kingpin.New(filepath.Base(os.Args[0]), "Template support for Crossplane.").DefaultEnvars()
 		// debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		syncPeriod     = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
-		leaderElection = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		syncPeriod       = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		leaderElection   = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		sessionTTL       = app.Flag("session-ttl", "How long a login of the Confluent CLI is reused before logging in again. 0 logs in on every reconcile.").Default("30m").Duration()
+		startupStagger   = app.Flag("startup-stagger", "Window after start over which the first reconcile of existing managed resources is spread. Resources received after it are not delayed. 0 disables staggering.").Default("0s").Duration()
+		syncInfoInterval = app.Flag("sync-annotation-interval", "Minimum interval between writes of the last-sync annotations when the last operation did not change.").Default("10m").Duration()
+		reconcileTimeout = app.Flag("reconcile-timeout", "Deadline of a single reconcile of a managed resource. A reconcile exceeding it is aborted and requeued, and the Confluent CLI command it waits on is killed.").Default("1m").Duration()
+		uploadTimeout    = app.Flag("upload-timeout", "Deadline of downloading the artifact of a custom connector plugin and uploading it to Confluent Cloud, which may outlast the reconcile timeout.").Default("30m").Duration()
+		enableTableflow  = app.Flag("enable-tableflow", "Enable the TableflowTopic controller. Requires Tableflow to be available for the managed clusters.").Default("false").OverrideDefaultFromEnvar("ENABLE_TABLEFLOW").Bool()
+		enableDNSForward = app.Flag("enable-dns-forwarders", "Enable the DNSForwarder controller. Requires PrivateLink gateways with DNS forwarding to be available for the organization.").Default("false").OverrideDefaultFromEnvar("ENABLE_DNS_FORWARDERS").Bool()
+		checkPrincipals  = app.Flag("check-acl-principals", "Report ACLs whose principal service account no longer exists as Degraded. Costs an extra API call per ACL observe.").Default("false").OverrideDefaultFromEnvar("CHECK_ACL_PRINCIPALS").Bool()
+		egressCIDRs      = app.Flag("egress-cidrs", "CIDR blocks or addresses the provider reaches Confluent Cloud from. IP filters not allowing access from all of them are reported as Degraded.").Strings()
+		saMarker         = app.Flag("service-account-marker", "Prefix of the description of service accounts created by the provider. Adopted service accounts without it are reported as not ProviderManaged. Empty disables the marker.").Default("").OverrideDefaultFromEnvar("SERVICE_ACCOUNT_MARKER").String()
+		budgetEnabled    = app.Flag("error-budget-throttling", "Throttle all reconciles while the error rate of Confluent commands exceeds the error budget.").Default("false").OverrideDefaultFromEnvar("ERROR_BUDGET_THROTTLING").Bool()
+		budgetThreshold  = app.Flag("error-budget-threshold", "Share of Confluent commands failing transiently within the error budget window, from 0 to 1, at which reconciles are throttled.").Default("0.5").Float64()
+		budgetRecovery   = app.Flag("error-budget-recovery-threshold", "Share of Confluent commands failing transiently within the error budget window below which throttling ends.").Default("0.1").Float64()
+		budgetWindow     = app.Flag("error-budget-window", "Rolling window over which the error rate of Confluent commands is computed.").Default("5m").Duration()
+		budgetMinCalls   = app.Flag("error-budget-min-calls", "Number of Confluent commands within the error budget window below which throttling never starts.").Default("20").Int()
+		budgetPollFactor = app.Flag("error-budget-poll-factor", "How many times longer resources wait before their next reconcile while throttled.").Default("4").Int()
+		budgetConcurrent = app.Flag("error-budget-concurrency", "Number of reconciles across all controllers that may run at once while throttled.").Default("1").Int()
+		shutdownGrace    = app.Flag("shutdown-grace-period", "How long reconciles in flight when the provider is shut down are given to finish before they are canceled. The pod must be given longer to terminate.").Default("30s").Duration()
+		extNameTemplates = app.Flag("external-name-template", "Template of the Confluent name of resources of a kind without an external name, as <kind>=<template>, e.g. ServiceAccount={{ .Namespace }}-{{ .Name }}. Supported for ServiceAccount and FlinkStatement.").StringMap()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -57,15 +90,43 @@ func main() {
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
+	shutdown.GracePeriod = *shutdownGrace
+	managerTimeout := shutdown.ManagerTimeout()
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		LeaderElection:   *leaderElection,
-		LeaderElectionID: "crossplane-leader-election-provider-confluent",
-		SyncPeriod:       syncPeriod,
+		LeaderElection:          *leaderElection,
+		LeaderElectionID:        "crossplane-leader-election-provider-confluent",
+		SyncPeriod:              syncPeriod,
+		GracefulShutdownTimeout: &managerTimeout,
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
+	clients.Log = log
+	debuglog.Log = log
+	clients.SessionTTL = *sessionTTL
+	syncinfo.Interval = *syncInfoInterval
+	timeout.Reconcile = *reconcileTimeout
+	timeout.Upload = *uploadTimeout
+	startup.Stagger = *startupStagger
+	tableflowtopic.Enabled = *enableTableflow
+	dnsforwarder.Enabled = *enableDNSForward
+	acl.CheckPrincipals = *checkPrincipals
+	ipfilter.EgressCIDRs = *egressCIDRs
+	serviceaccount.Marker = *saMarker
+	errorbudget.Enabled = *budgetEnabled
+	errorbudget.Threshold = *budgetThreshold
+	errorbudget.RecoveryThreshold = *budgetRecovery
+	errorbudget.Window = *budgetWindow
+	errorbudget.MinCalls = *budgetMinCalls
+	errorbudget.PollFactor = *budgetPollFactor
+	errorbudget.Concurrency = *budgetConcurrent
+	errorbudget.Log = log
+	kingpin.FatalIfError(externalname.SetTemplates(*extNameTemplates), "Cannot parse external name templates")
+
 	rl := ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS)
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add resource APIs to scheme")
 	kingpin.FatalIfError(controller.Setup(mgr, log, rl), "Cannot setup resource controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+
+	ctx := ctrl.SetupSignalHandler()
+	go refresh.Watch(ctx, mgr.GetClient(), log)
+	kingpin.FatalIfError(mgr.Start(ctx), "Cannot start controller manager")
 }
@@ -1,6 +1,7 @@
 package acl
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -20,15 +21,24 @@ const (
 	// errResourceNotFoundOrAccessForbidden    = "resource not found or access forbidden"
 	ErrACLNotExistsOrInvalidServiceAccount = "acl for service account does not exists or invalid service account"
 	// errUnknownApiKey                        = "unknow apikey"
+	ErrBindingNotExists = "acl binding does not exists"
 )
 
+// ErrBindingNotFound is returned when deleting a binding that does not exist in Confluent Cloud
+var ErrBindingNotFound = errors.New(ErrBindingNotExists)
+
+// IsBindingNotFound reports whether err is, or wraps, ErrBindingNotFound
+func IsBindingNotFound(err error) bool {
+	return errors.Is(err, ErrBindingNotFound)
+}
+
 // NewClient is a factory method for apikey client
 func NewClient(c Config) IClient {
 	return &Client{Config: c}
 }
 
 // ACLCreate create acl
-func (c *Client) ACLCreate(aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, error) {
+func (c *Client) ACLCreate(ctx context.Context, aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, error) {
 	var resp []v1alpha1.ACLRule
 
 	cmd, err := commands.NewACLCreateCommand(aclP)
@@ -36,7 +46,7 @@ func (c *Client) ACLCreate(aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, err
 		return resp, err
 	}
 
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return resp, errorParser(out)
@@ -56,13 +66,13 @@ func (c *Client) ACLCreate(aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, err
 }
 
 // ACLDelete delete ACL
-func (c *Client) ACLDelete(aclP v1alpha1.ACLParameters) error {
+func (c *Client) ACLDelete(ctx context.Context, aclP v1alpha1.ACLParameters) error {
 	cmd, err := commands.NewACLDeleteCommand(aclP)
 	if err != nil {
 		return err
 	}
 
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return errorParser(out)
@@ -72,11 +82,11 @@ func (c *Client) ACLDelete(aclP v1alpha1.ACLParameters) error {
 }
 
 // ACLList list ACL's
-func (c *Client) ACLList(serviceAccount string, environment string, cluster string) ([]v1alpha1.ACLRule, error) {
+func (c *Client) ACLList(ctx context.Context, serviceAccount string, environment string, cluster string) ([]v1alpha1.ACLRule, error) {
 	var resp []v1alpha1.ACLRule
 
 	cmd := commands.NewACLListCommand(environment, cluster, serviceAccount)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return resp, errorParser(out)
@@ -106,7 +116,9 @@ func errorParser(cmdout []byte) error {
 	switch {
 	case strings.Contains(str, "Error: service account") && strings.Contains(str, "not found"):
 		return errors.New(ErrACLNotExistsOrInvalidServiceAccount)
+	case strings.Contains(str, "ACL not found") || strings.Contains(strings.ToLower(str), "no acls"):
+		return ErrBindingNotFound
 	default:
-		return errors.Wrap(errors.New(errUnknown), str)
+		return errors.Wrap(clients.CommandError(cmdout), errUnknown)
 	}
 }
@@ -1,6 +1,7 @@
 package acl
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -42,17 +43,17 @@ func TestAclLifecycle(t *testing.T) {
 	clients.SkipCI(t)
 	assert := assert.New(t)
 
-	_, err := client.ACLList("sa-00000", environment, resource)
+	_, err := client.ACLList(context.Background(), "sa-00000", environment, resource)
 	if err != nil {
 		assert.Equal(ErrACLNotExistsOrInvalidServiceAccount, err.Error(), "empty acl should should return not exists")
 	}
 
-	_, err = client.ACLCreate(aclParam)
+	_, err = client.ACLCreate(context.Background(), aclParam)
 	if err != nil {
 		t.Errorf("acl creation not working")
 	}
 
-	resp, err := client.ACLList(serviceAccount, environment, resource)
+	resp, err := client.ACLList(context.Background(), serviceAccount, environment, resource)
 	if err != nil {
 		t.Errorf("acl list not working")
 	}
@@ -61,13 +62,22 @@ func TestAclLifecycle(t *testing.T) {
 		t.Errorf("Expected amount of ACLS after creation is not 1. Could be affected by external factors")
 	}
 
-	err = client.ACLDelete(aclParam)
+	err = client.ACLDelete(context.Background(), aclParam)
 	if err != nil {
 		t.Errorf("acl delete not working manual OBS: clean up required, please run the following command \"confluent kafka acl list | grep \"acltest_testacllifecycle\" | awk '{ print $1 }' | xargs -I {} confluent kafka acl delete {}\"")
 	}
 
-	_, err = client.ACLList(serviceAccount, environment, resource)
+	_, err = client.ACLList(context.Background(), serviceAccount, environment, resource)
 	if err == nil {
 		t.Errorf("acl deletion didn't work. 1 or more ACLS are attached to the specified service account, cluster & environment")
 	}
 }
+
+func TestErrorParser(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsBindingNotFound(errorParser([]byte("Error: ACL not found"))))
+	assert.True(IsBindingNotFound(errorParser([]byte("Error: no ACLs matched the given filter"))))
+	assert.Equal(ErrACLNotExistsOrInvalidServiceAccount, errorParser([]byte("Error: service account \"sa-00000\" not found")).Error())
+	assert.False(IsBindingNotFound(errorParser([]byte("Error: Forbidden"))))
+}
@@ -1,15 +1,16 @@
 package acl
 
 import (
+	"context"
 	"github.com/dfds/provider-confluent/apis/acl/v1alpha1"
 	"github.com/dfds/provider-confluent/internal/clients"
 )
 
 // IClient interface for service account client
 type IClient interface {
-	ACLCreate(aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, error)
-	ACLDelete(aclP v1alpha1.ACLParameters) error
-	ACLList(serviceAccount string, environment string, cluster string) ([]v1alpha1.ACLRule, error)
+	ACLCreate(ctx context.Context, aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, error)
+	ACLDelete(ctx context.Context, aclP v1alpha1.ACLParameters) error
+	ACLList(ctx context.Context, serviceAccount string, environment string, cluster string) ([]v1alpha1.ACLRule, error)
 }
 
 // Config is a configuration element for the service account client
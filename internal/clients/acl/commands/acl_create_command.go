@@ -13,7 +13,7 @@ const (
 	errPermissionInvalid                            = "permission type must be either ALLOW or DENY"
 	errPrincipalInvalid                             = "principal does only allow User:sa-55555 type input"
 	errResourceTypeInvalid                          = "resource type must be either TOPIC, CONSUMER_GROUP or CLUSTER"
-	errResourceNameSpecifiedWithResourceTypeCluster = "resource name can't be specified when resource type is CLUSTER"
+	errResourceNameSpecifiedWithResourceTypeCluster = "resource name must be empty or kafka-cluster when resource type is CLUSTER"
 )
 
 // NewACLCreateCommand is a factory method for ACL create command
@@ -39,7 +39,7 @@ func NewACLCreateCommand(aclP v1alpha1.ACLParameters) (exec.Cmd, error) {
 
 	err = parseResource(&command, aclP.ACLRule.ResourceName, aclP.ACLRule.ResourceType)
 	if err != nil {
-		return command, nil
+		return command, err
 	}
 	command.Args = append(command.Args, "--operation", aclP.ACLRule.Operation)
 
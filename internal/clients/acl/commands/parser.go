@@ -61,6 +61,9 @@ func ParsePrincipal(principal string) (string, error) {
 	return serviceAccount, nil
 }
 
+// ClusterResourceName is the resource name Confluent reports for bindings of resource type CLUSTER
+const ClusterResourceName = "kafka-cluster"
+
 func parseResource(cmd *exec.Cmd, rName string, rType string) error {
 	switch rType {
 	case "TOPIC":
@@ -70,7 +73,7 @@ func parseResource(cmd *exec.Cmd, rName string, rType string) error {
 		cmd.Args = append(cmd.Args, "--consumer-group", rName)
 		return nil
 	case "CLUSTER":
-		if rName != "" {
+		if rName != "" && rName != ClusterResourceName {
 			return errors.New(errResourceNameSpecifiedWithResourceTypeCluster)
 		}
 		cmd.Args = append(cmd.Args, "--cluster-scope")
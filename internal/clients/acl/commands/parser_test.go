@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"testing"
 
+	"github.com/dfds/provider-confluent/apis/acl/v1alpha1"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -166,6 +167,11 @@ func TestParseResource(t *testing.T) {
 		}
 	}
 
+	cmd = exec.Cmd{}
+	err = parseResource(&cmd, ClusterResourceName, "CLUSTER")
+	assert.NoError(err)
+	assert.Equal([]string{"--cluster-scope"}, cmd.Args)
+
 	cmd = exec.Cmd{}
 	err = parseResource(&cmd, rName, "CLUSTER")
 	if err == nil {
@@ -174,3 +180,72 @@ func TestParseResource(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestACLCommandsPatternType(t *testing.T) {
+	assert := assert.New(t)
+
+	aclP := v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operation:    "READ",
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:" + saID,
+			ResourceName: "orders-",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+
+	// Literal bindings are sent without --prefix
+	cmd, err := NewACLCreateCommand(aclP)
+	assert.NoError(err)
+	assert.NotContains(cmd.Args, "--prefix")
+	cmd, err = NewACLDeleteCommand(aclP)
+	assert.NoError(err)
+	assert.NotContains(cmd.Args, "--prefix")
+
+	// Prefixed bindings are sent with --prefix on both create & delete
+	aclP.ACLRule.PatternType = "PREFIXED"
+	cmd, err = NewACLCreateCommand(aclP)
+	assert.NoError(err)
+	assert.Contains(cmd.Args, "--prefix")
+	cmd, err = NewACLDeleteCommand(aclP)
+	assert.NoError(err)
+	assert.Contains(cmd.Args, "--prefix")
+}
+
+func TestACLCommandsClusterScope(t *testing.T) {
+	assert := assert.New(t)
+
+	aclP := v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operation:    "ALTER",
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:" + saID,
+			ResourceName: ClusterResourceName,
+			ResourceType: "CLUSTER",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+
+	// Cluster-scoped bindings are sent with --cluster-scope, never as a named topic
+	cmd, err := NewACLCreateCommand(aclP)
+	assert.NoError(err)
+	assert.Contains(cmd.Args, "--cluster-scope")
+	assert.NotContains(cmd.Args, "--topic")
+	assert.NotContains(cmd.Args, ClusterResourceName)
+	assert.Equal([]string{"--operation", "ALTER"}, cmd.Args[len(cmd.Args)-2:])
+	cmd, err = NewACLDeleteCommand(aclP)
+	assert.NoError(err)
+	assert.Contains(cmd.Args, "--cluster-scope")
+
+	// An invalid resource is reported instead of sending a binding without resource
+	aclP.ACLRule.ResourceName = rName
+	_, err = NewACLCreateCommand(aclP)
+	assert.EqualError(err, errResourceNameSpecifiedWithResourceTypeCluster)
+	_, err = NewACLDeleteCommand(aclP)
+	assert.EqualError(err, errResourceNameSpecifiedWithResourceTypeCluster)
+}
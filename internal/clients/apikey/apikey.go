@@ -1,6 +1,7 @@
 package apikey
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -25,12 +26,12 @@ func NewClient(c Config) IClient {
 	return &Client{Config: c}
 }
 
-// APIKeyCreate create API key
-func (c *Client) APIKeyCreate(resource string, description string, serviceAccount string, environment string) (APIKey, error) {
+// APIKeyCreate create API key owned by a service account or a user
+func (c *Client) APIKeyCreate(ctx context.Context, resource string, description string, owner string, environment string) (APIKey, error) {
 	var resp APIKey
 
-	var cmd = commands.NewAPIKeyCreateCommand(resource, description, serviceAccount, environment)
-	out, err := clients.ExecuteCommand(cmd)
+	var cmd = commands.NewAPIKeyCreateCommand(resource, description, owner, environment)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return resp, errorParser(out)
@@ -45,12 +46,12 @@ func (c *Client) APIKeyCreate(resource string, description string, serviceAccoun
 }
 
 // GetAPIKeyByKey get API key by key
-func (c *Client) GetAPIKeyByKey(key string) (Metadata, error) {
+func (c *Client) GetAPIKeyByKey(ctx context.Context, key string) (Metadata, error) {
 	var resp List
 	var akm Metadata
 
 	var cmd = commands.NewAPIKeyListCommand()
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return akm, errorParser(out)
@@ -72,9 +73,9 @@ func (c *Client) GetAPIKeyByKey(key string) (Metadata, error) {
 }
 
 // APIKeyUpdate update API key description by key
-func (c *Client) APIKeyUpdate(key string, description string) error {
+func (c *Client) APIKeyUpdate(ctx context.Context, key string, description string) error {
 	var cmd = commands.NewAPIKeyUpdateCommand(key, description)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return errorParser(out)
@@ -84,9 +85,9 @@ func (c *Client) APIKeyUpdate(key string, description string) error {
 }
 
 // APIKeyDelete delete API key by key
-func (c *Client) APIKeyDelete(key string) error {
+func (c *Client) APIKeyDelete(ctx context.Context, key string) error {
 	var cmd = commands.NewAPIKeyDeleteCommand(key)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return errorParser(out)
@@ -107,6 +108,6 @@ func errorParser(cmdout []byte) error {
 	case strings.Contains(str, "Error: Unknown API key"):
 		return errors.New(errUnknownAPIKey)
 	default:
-		return errors.Wrap(errors.New(errUnknown), str)
+		return errors.Wrap(clients.CommandError(cmdout), errUnknown)
 	}
 }
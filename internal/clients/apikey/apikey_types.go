@@ -1,13 +1,16 @@
 package apikey
 
-import "github.com/dfds/provider-confluent/internal/clients"
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
 
 // IClient interface for service account client
 type IClient interface {
-	APIKeyCreate(resource string, description string, serviceAccount string, environment string) (APIKey, error)
-	APIKeyDelete(key string) error
-	GetAPIKeyByKey(key string) (Metadata, error)
-	APIKeyUpdate(key string, description string) error
+	APIKeyCreate(ctx context.Context, resource string, description string, owner string, environment string) (APIKey, error)
+	APIKeyDelete(ctx context.Context, key string) error
+	GetAPIKeyByKey(ctx context.Context, key string) (Metadata, error)
+	APIKeyUpdate(ctx context.Context, key string, description string) error
 }
 
 // Config is a configuration element for the service account client
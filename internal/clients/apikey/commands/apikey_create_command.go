@@ -7,10 +7,10 @@ import (
 )
 
 // NewAPIKeyCreateCommand is a factory method for ApiKey create command
-func NewAPIKeyCreateCommand(resource string, description string, serviceAccount string, environment string) exec.Cmd {
+func NewAPIKeyCreateCommand(resource string, description string, owner string, environment string) exec.Cmd {
 	var command = exec.Cmd{
 		Path: clients.CliName,
-		Args: []string{"api-key", "create", "--resource", resource, "--description", description, "--service-account", serviceAccount, "--environment", environment, "-o", "json"},
+		Args: []string{"api-key", "create", "--resource", resource, "--description", description, "--service-account", owner, "--environment", environment, "-o", "json"},
 	}
 
 	return command
@@ -0,0 +1,51 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/billing/commands"
+)
+
+// Errors
+const (
+	errUnknown        = "unknown error"
+	ErrNotAvailable   = "billing is not available, the Confluent CLI does not support it or the credentials lack the BillingAdmin role"
+	errUnknownCommand = "unknown command \"billing\""
+)
+
+// NewClient is a factory method for billing client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// CostList Executes Confluent CLI command to retrieve the costs of the organization from startDate until, but excluding, endDate
+func (c *Client) CostList(ctx context.Context, startDate string, endDate string) ([]Cost, error) {
+	var resp []Cost
+
+	cmd := commands.NewBillingCostListCommand(startDate, endDate)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, errUnknownCommand) || strings.Contains(str, "forbidden") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrNotAvailable)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,39 @@
+package billing
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for billing client
+type IClient interface {
+	CostList(ctx context.Context, startDate string, endDate string) ([]Cost, error)
+}
+
+// Config is a configuration element for the billing client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for billing client
+type Client struct {
+	Config Config
+}
+
+// Cost struct for deserialising a single line of the Confluent Cloud cost list response
+type Cost struct {
+	StartDate         string  `json:"start_date"`
+	EndDate           string  `json:"end_date"`
+	Product           string  `json:"product"`
+	ResourceID        string  `json:"resource_id"`
+	ResourceName      string  `json:"resource_name"`
+	EnvironmentID     string  `json:"environment_id"`
+	NetworkAccessType string  `json:"network_access_type"`
+	LineType          string  `json:"line_type"`
+	Quantity          float64 `json:"quantity"`
+	Unit              string  `json:"unit"`
+	UnitPrice         float64 `json:"unit_price"`
+	OriginalAmount    float64 `json:"original_amount"`
+	DiscountAmount    float64 `json:"discount_amount"`
+	Amount            float64 `json:"amount"`
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewBillingCostListCommand is a factory method for billing cost list command
+func NewBillingCostListCommand(startDate string, endDate string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"billing", "cost", "list", "--start-date", startDate, "--end-date", endDate, "-o", "json"},
+	}
+
+	return command
+}
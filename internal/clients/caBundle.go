@@ -0,0 +1,175 @@
+package clients
+
+import (
+	"context"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Errors
+const (
+	errGetCABundle     = "cannot get CA bundle"
+	errCABundleKey     = "CA bundle key not found"
+	errCABundleKind    = "CA bundle kind must be either Secret or ConfigMap"
+	errInvalidCABundle = "CA bundle does not contain any valid PEM encoded certificate"
+	errWriteCABundle   = "cannot write CA bundle"
+)
+
+const (
+	caBundleKindSecret    = "Secret"
+	caBundleKindConfigMap = "ConfigMap"
+	caBundleDefaultKey    = "ca.crt"
+	caBundleDirName       = "provider-confluent-ca"
+	caBundleFileName      = "ca-bundle.pem"
+	sslCertDirEnvKey      = "SSL_CERT_DIR"
+	defaultSSLCertDirs    = "/etc/ssl/certs:/etc/pki/tls/certs"
+)
+
+// CABundleReference references a Secret or ConfigMap key holding additional PEM encoded CA certificates trusted for the Confluent endpoint
+type CABundleReference struct {
+	// Kind of the referenced object.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	// +kubebuilder:default=Secret
+	// +optional
+	Kind string `json:"kind,omitempty"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+
+	// Namespace of the referenced object.
+	Namespace string `json:"namespace"`
+
+	// Key of the CA bundle in the referenced object.
+	// +kubebuilder:default=ca.crt
+	// +optional
+	Key string `json:"key,omitempty"`
+}
+
+var (
+	caBundleMu  sync.RWMutex
+	caBundleDir string
+)
+
+// LoadCABundle reads & validates the CA bundle referenced by ref. A nil ref returns no bundle
+func LoadCABundle(ctx context.Context, kube client.Reader, ref *CABundleReference) ([]byte, error) {
+	if ref == nil {
+		return nil, nil
+	}
+
+	key := ref.Key
+	if key == "" {
+		key = caBundleDefaultKey
+	}
+
+	nn := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+
+	var bundle []byte
+	switch ref.Kind {
+	case caBundleKindSecret, "":
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, nn, s); err != nil {
+			return nil, errors.Wrap(err, errGetCABundle)
+		}
+		bundle = s.Data[key]
+	case caBundleKindConfigMap:
+		cm := &corev1.ConfigMap{}
+		if err := kube.Get(ctx, nn, cm); err != nil {
+			return nil, errors.Wrap(err, errGetCABundle)
+		}
+		bundle = []byte(cm.Data[key])
+	default:
+		return nil, errors.New(errCABundleKind)
+	}
+
+	if len(bundle) == 0 {
+		return nil, errors.Errorf("%s: %s", errCABundleKey, key)
+	}
+
+	if _, err := ParseCABundle(bundle); err != nil {
+		return nil, err
+	}
+
+	return bundle, nil
+}
+
+// ParseCABundle Parses the PEM encoded certificates of bundle into a certificate pool
+func ParseCABundle(bundle []byte) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bundle) {
+		return nil, errors.New(errInvalidCABundle)
+	}
+	return pool, nil
+}
+
+// useCABundle Makes the Confluent CLI trust the certificates of bundle in addition to the system roots. An empty bundle restores the system roots only
+func useCABundle(bundle []byte) error {
+	caBundleMu.Lock()
+	defer caBundleMu.Unlock()
+
+	if len(bundle) == 0 {
+		caBundleDir = ""
+		return nil
+	}
+
+	if _, err := ParseCABundle(bundle); err != nil {
+		return err
+	}
+
+	dir := filepath.Join(os.TempDir(), caBundleDirName)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Wrap(err, errWriteCABundle)
+	}
+
+	// Write to a temporary file first so a concurrently running CLI never reads a partial bundle
+	tmp, err := ioutil.TempFile(dir, ".bundle-")
+	if err != nil {
+		return errors.Wrap(err, errWriteCABundle)
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(bundle); err != nil {
+		tmp.Close() //nolint:errcheck
+		return errors.Wrap(err, errWriteCABundle)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrap(err, errWriteCABundle)
+	}
+	if err := os.Chmod(tmp.Name(), 0600); err != nil {
+		return errors.Wrap(err, errWriteCABundle)
+	}
+	if err := os.Rename(tmp.Name(), filepath.Join(dir, caBundleFileName)); err != nil {
+		return errors.Wrap(err, errWriteCABundle)
+	}
+
+	caBundleDir = dir
+	return nil
+}
+
+// commandEnv Returns the environment for Confluent CLI commands. When a CA bundle is in use, its directory is added to the certificate directories of the CLI,
+// when a proxy is in use it replaces the proxy of the provider
+func commandEnv() []string {
+	env := withProxy(os.Environ())
+
+	caBundleMu.RLock()
+	dir := caBundleDir
+	caBundleMu.RUnlock()
+
+	if dir == "" {
+		return env
+	}
+
+	certDirs := os.Getenv(sslCertDirEnvKey)
+	if certDirs == "" {
+		certDirs = defaultSSLCertDirs
+	}
+
+	return append(env, sslCertDirEnvKey+"="+dir+string(os.PathListSeparator)+certDirs)
+}
@@ -0,0 +1,120 @@
+package clients
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func testCertificatePEM(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "proxy-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestParseCABundle(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := ParseCABundle(testCertificatePEM(t))
+	assert.NoError(err)
+
+	_, err = ParseCABundle([]byte("not a certificate"))
+	assert.EqualError(err, errInvalidCABundle)
+}
+
+func TestLoadCABundle(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+	bundle := testCertificatePEM(t)
+
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		switch o := obj.(type) {
+		case *corev1.Secret:
+			o.Data = map[string][]byte{"ca.crt": bundle, "invalid": []byte("garbage")}
+		case *corev1.ConfigMap:
+			o.Data = map[string]string{"bundle.pem": string(bundle)}
+		}
+		return nil
+	}
+
+	// No reference
+	out, err := LoadCABundle(ctx, kube, nil)
+	assert.NoError(err)
+	assert.Nil(out)
+
+	// Secret with default key
+	out, err = LoadCABundle(ctx, kube, &CABundleReference{Name: "ca", Namespace: "crossplane-system"})
+	assert.NoError(err)
+	assert.Equal(bundle, out)
+
+	// ConfigMap
+	out, err = LoadCABundle(ctx, kube, &CABundleReference{Kind: "ConfigMap", Name: "ca", Namespace: "crossplane-system", Key: "bundle.pem"})
+	assert.NoError(err)
+	assert.Equal(bundle, out)
+
+	// Missing key
+	_, err = LoadCABundle(ctx, kube, &CABundleReference{Name: "ca", Namespace: "crossplane-system", Key: "missing"})
+	assert.Error(err)
+
+	// Invalid PEM
+	_, err = LoadCABundle(ctx, kube, &CABundleReference{Name: "ca", Namespace: "crossplane-system", Key: "invalid"})
+	assert.EqualError(err, errInvalidCABundle)
+
+	// Unknown kind
+	_, err = LoadCABundle(ctx, kube, &CABundleReference{Kind: "Pod", Name: "ca", Namespace: "crossplane-system"})
+	assert.EqualError(err, errCABundleKind)
+}
+
+func TestUseCABundle(t *testing.T) {
+	assert := assert.New(t)
+
+	hasCertDir := func(env []string) bool {
+		for _, e := range env {
+			if strings.HasPrefix(e, sslCertDirEnvKey+"=") && strings.Contains(e, caBundleDirName) {
+				return true
+			}
+		}
+		return false
+	}
+
+	assert.Error(useCABundle([]byte("not a certificate")))
+	assert.False(hasCertDir(commandEnv()))
+
+	assert.NoError(useCABundle(testCertificatePEM(t)))
+	assert.True(hasCertDir(commandEnv()))
+
+	// An empty bundle restores the system roots
+	assert.NoError(useCABundle(nil))
+	assert.False(hasCertDir(commandEnv()))
+}
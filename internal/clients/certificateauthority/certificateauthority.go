@@ -0,0 +1,144 @@
+package certificateauthority
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/certificateauthority/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	errWriteChain   = "cannot write certificate chain to a temporary file"
+	ErrNotExists    = "certificate authority does not exist"
+	ErrInvalidInput = "input given may be invalid like a malformed certificate chain"
+)
+
+// ErrNotFound is returned when a certificate authority does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for certificate authority client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// CertificateAuthorityCreate Executes Confluent CLI command to create a certificate authority in Confluent Cloud & return the created
+// CertificateAuthority
+func (c *Client) CertificateAuthorityCreate(ctx context.Context, name string, description string, chain string, crlURL string) (CertificateAuthority, error) {
+	var resp CertificateAuthority
+
+	// The CLI only reads the certificate chain from a file
+	filename, err := writeChain(chain)
+	if err != nil {
+		return resp, err
+	}
+	defer os.Remove(filename) //nolint:errcheck
+
+	cmd := commands.NewCertificateAuthorityCreateCommand(name, description, filename, crlURL)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// CertificateAuthorityDescribe Executes Confluent CLI command to retrieve a certificate authority by id from Confluent Cloud
+func (c *Client) CertificateAuthorityDescribe(ctx context.Context, id string) (CertificateAuthority, error) {
+	var resp CertificateAuthority
+
+	cmd := commands.NewCertificateAuthorityDescribeCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// CertificateAuthorityUpdate Executes Confluent CLI command to rename a certificate authority or change its description, certificate chain
+// or CRL URL in Confluent Cloud
+func (c *Client) CertificateAuthorityUpdate(ctx context.Context, id string, u Update) error {
+	var filename string
+	if u.CertificateChain != "" {
+		var err error
+		if filename, err = writeChain(u.CertificateChain); err != nil {
+			return err
+		}
+		defer os.Remove(filename) //nolint:errcheck
+	}
+
+	cmd := commands.NewCertificateAuthorityUpdateCommand(id, u.Name, u.Description, filename, u.CRLURL)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// CertificateAuthorityDelete Executes Confluent CLI command to delete a certificate authority from Confluent Cloud
+func (c *Client) CertificateAuthorityDelete(ctx context.Context, id string) error {
+	cmd := commands.NewCertificateAuthorityDeleteCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// writeChain Writes a PEM encoded certificate chain to a temporary file & returns its name
+func writeChain(chain string) (string, error) {
+	f, err := ioutil.TempFile("", "certificate-chain-*.pem")
+	if err != nil {
+		return "", errors.Wrap(err, errWriteChain)
+	}
+
+	_, err = f.WriteString(chain)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+		return "", errors.Wrap(err, errWriteChain)
+	}
+
+	return f.Name(), nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,44 @@
+package certificateauthority
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for certificate authority client
+type IClient interface {
+	CertificateAuthorityCreate(ctx context.Context, name string, description string, chain string, crlURL string) (CertificateAuthority, error)
+	CertificateAuthorityDescribe(ctx context.Context, id string) (CertificateAuthority, error)
+	CertificateAuthorityUpdate(ctx context.Context, id string, u Update) error
+	CertificateAuthorityDelete(ctx context.Context, id string) error
+}
+
+// Config is a configuration element for the certificate authority client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for certificate authority client
+type Client struct {
+	Config Config
+}
+
+// CertificateAuthority is a struct used for deserialising the response of CertificateAuthorityCreate & CertificateAuthorityDescribe
+type CertificateAuthority struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	Description     string   `json:"description"`
+	Fingerprints    []string `json:"fingerprints"`
+	ExpirationDates []string `json:"expiration_dates"`
+	CRLURL          string   `json:"crl_url"`
+	State           string   `json:"state"`
+}
+
+// Update describes the changes CertificateAuthorityUpdate applies to a certificate authority. Empty fields are left unchanged, nil
+// pointers too
+type Update struct {
+	Name             string
+	Description      *string
+	CertificateChain string
+	CRLURL           *string
+}
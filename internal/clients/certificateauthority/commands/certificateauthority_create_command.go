@@ -0,0 +1,26 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificateAuthorityCreateCommand is a factory method for CertificateAuthority create command
+func NewCertificateAuthorityCreateCommand(name string, description string, chainFilename string, crlURL string) exec.Cmd {
+	args := []string{"iam", "certificate-authority", "create", name, "--certificate-chain-filename", chainFilename}
+
+	if description != "" {
+		args = append(args, "--description", description)
+	}
+	if crlURL != "" {
+		args = append(args, "--crl-url", crlURL)
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
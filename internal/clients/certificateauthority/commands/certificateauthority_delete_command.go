@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificateAuthorityDeleteCommand is a factory method for CertificateAuthority delete command
+func NewCertificateAuthorityDeleteCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "certificate-authority", "delete", id, "--force"},
+	}
+
+	return command
+}
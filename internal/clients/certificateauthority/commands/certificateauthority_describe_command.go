@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificateAuthorityDescribeCommand is a factory method for CertificateAuthority describe command
+func NewCertificateAuthorityDescribeCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "certificate-authority", "describe", id, "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificateAuthorityUpdateCommand is a factory method for CertificateAuthority update command
+func NewCertificateAuthorityUpdateCommand(id string, name string, description *string, chainFilename string, crlURL *string) exec.Cmd {
+	args := []string{"iam", "certificate-authority", "update", id}
+
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if description != nil {
+		args = append(args, "--description", *description)
+	}
+	if chainFilename != "" {
+		args = append(args, "--certificate-chain-filename", chainFilename)
+	}
+	if crlURL != nil {
+		args = append(args, "--crl-url", *crlURL)
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
@@ -0,0 +1,107 @@
+package certificatepool
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/certificatepool/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "certificate pool does not exist"
+	ErrInvalidInput = "input given may be invalid like a malformed filter"
+)
+
+// ErrNotFound is returned when a certificate pool, or the certificate authority it belongs to, does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for certificate pool client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// CertificatePoolCreate Executes Confluent CLI command to create a certificate pool of the provider certificate authority in Confluent
+// Cloud & return the created CertificatePool
+func (c *Client) CertificatePoolCreate(ctx context.Context, provider string, name string, description string, externalIdentifier string, filter string) (CertificatePool, error) {
+	var resp CertificatePool
+
+	cmd := commands.NewCertificatePoolCreateCommand(provider, name, description, externalIdentifier, filter)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// CertificatePoolDescribe Executes Confluent CLI command to retrieve a certificate pool of the provider certificate authority by id from
+// Confluent Cloud
+func (c *Client) CertificatePoolDescribe(ctx context.Context, provider string, id string) (CertificatePool, error) {
+	var resp CertificatePool
+
+	cmd := commands.NewCertificatePoolDescribeCommand(provider, id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// CertificatePoolUpdate Executes Confluent CLI command to rename a certificate pool or change its description, external identifier or
+// filter in Confluent Cloud
+func (c *Client) CertificatePoolUpdate(ctx context.Context, provider string, id string, u Update) error {
+	cmd := commands.NewCertificatePoolUpdateCommand(provider, id, u.Name, u.Description, u.ExternalIdentifier, u.Filter)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// CertificatePoolDelete Executes Confluent CLI command to delete a certificate pool from Confluent Cloud
+func (c *Client) CertificatePoolDelete(ctx context.Context, provider string, id string) error {
+	cmd := commands.NewCertificatePoolDeleteCommand(provider, id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,46 @@
+package certificatepool
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for certificate pool client
+type IClient interface {
+	CertificatePoolCreate(ctx context.Context, provider string, name string, description string, externalIdentifier string, filter string) (CertificatePool, error)
+	CertificatePoolDescribe(ctx context.Context, provider string, id string) (CertificatePool, error)
+	CertificatePoolUpdate(ctx context.Context, provider string, id string, u Update) error
+	CertificatePoolDelete(ctx context.Context, provider string, id string) error
+}
+
+// Config is a configuration element for the certificate pool client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for certificate pool client
+type Client struct {
+	Config Config
+}
+
+// CertificatePool is a struct used for deserialising the response of CertificatePoolCreate & CertificatePoolDescribe
+type CertificatePool struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	Description        string `json:"description"`
+	ExternalIdentifier string `json:"external_identifier"`
+	Filter             string `json:"filter"`
+}
+
+// Principal Returns the principal of the certificate pool in role bindings & ACLs
+func (cp CertificatePool) Principal() string {
+	return "User:" + cp.ID
+}
+
+// Update describes the changes CertificatePoolUpdate applies to a certificate pool. Empty fields are left unchanged, a nil Description too
+type Update struct {
+	Name               string
+	Description        *string
+	ExternalIdentifier string
+	Filter             string
+}
@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificatePoolCreateCommand is a factory method for CertificatePool create command
+func NewCertificatePoolCreateCommand(provider string, name string, description string, externalIdentifier string, filter string) exec.Cmd {
+	args := []string{"iam", "certificate-pool", "create", name, "--provider", provider, "--external-identifier", externalIdentifier, "--filter", filter}
+
+	if description != "" {
+		args = append(args, "--description", description)
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificatePoolDeleteCommand is a factory method for CertificatePool delete command
+func NewCertificatePoolDeleteCommand(provider string, id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "certificate-pool", "delete", id, "--provider", provider, "--force"},
+	}
+
+	return command
+}
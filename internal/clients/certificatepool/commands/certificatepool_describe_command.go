@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificatePoolDescribeCommand is a factory method for CertificatePool describe command
+func NewCertificatePoolDescribeCommand(provider string, id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "certificate-pool", "describe", id, "--provider", provider, "-o", "json"},
+	}
+
+	return command
+}
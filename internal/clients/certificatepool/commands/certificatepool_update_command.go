@@ -0,0 +1,32 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCertificatePoolUpdateCommand is a factory method for CertificatePool update command
+func NewCertificatePoolUpdateCommand(provider string, id string, name string, description *string, externalIdentifier string, filter string) exec.Cmd {
+	args := []string{"iam", "certificate-pool", "update", id, "--provider", provider}
+
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if description != nil {
+		args = append(args, "--description", *description)
+	}
+	if externalIdentifier != "" {
+		args = append(args, "--external-identifier", externalIdentifier)
+	}
+	if filter != "" {
+		args = append(args, "--filter", filter)
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
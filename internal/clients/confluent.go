@@ -1,29 +1,49 @@
 package clients
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
 	"os/exec"
 
 	"github.com/pkg/errors"
 )
 
 const (
-	errNotLoggedIn = "not logged in"
+	errNotLoggedIn          = "not logged in"
+	errDescribeOrganization = "cannot describe the organization logged in to"
+	errWrongOrganization    = "logged in to organization %s instead of %s"
 )
 
 // IClient interface for confluent client
 type IClient interface {
-	Authenticate(email string, password string) error
+	Authenticate(ctx context.Context, email string, password string) error
+}
+
+// Config is a configuration element for the confluent client
+type Config struct {
+	// CABundle holds additional PEM encoded CA certificates trusted for the Confluent endpoint
+	CABundle []byte
+
+	// OrganizationID is the organization to log in to. Empty logs in to the default organization of the credentials
+	OrganizationID string
+
+	// Endpoint is the URL of the Confluent Cloud API to log in to. Empty logs in to the production endpoint the CLI defaults to
+	Endpoint string
+
+	// Proxy is the URL of the HTTP proxy the CLI connects through, overriding HTTPS_PROXY & HTTP_PROXY. Empty uses the proxy environment
+	// variables of the provider
+	Proxy string
 }
 
 // NewClient is a factory method for confluent client
-func NewClient() IClient {
-	return &Client{}
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
 }
 
 // Client is a struct for confluent client
 type Client struct {
+	Config Config
 }
 
 // ConflientUsernameEnvKey is the environment key used to assign the username used by the confluent CLI
@@ -35,15 +55,66 @@ const ConfluentPasswordEnvKey = "CONFLUENT_CLOUD_PASSWORD"
 // CliName is the name of the confluent CLI application
 const CliName = "confluent"
 
-// Authenticate a user via the confluent client
-func (c *Client) Authenticate(email string, password string) error {
-	cmd := exec.Command(CliName, "login", "--save")
-	cmd.Env = os.Environ()
+// Authenticate a user via the confluent client. When an organization is configured, the login is checked to have landed in it, so no resource
+// is created in or observed from another organization the credentials have access to. A login with the same credentials is reused for
+// SessionTTL
+func (c *Client) Authenticate(ctx context.Context, email string, password string) error {
+	return sessions.login(sessionKey(email, password, c.Config), func() error { return c.login(ctx, email, password) })
+}
+
+// login Logs the confluent client in with email & password
+func (c *Client) login(ctx context.Context, email string, password string) error {
+	if err := useCABundle(c.Config.CABundle); err != nil {
+		return err
+	}
+	if err := useProxy(c.Config.Proxy); err != nil {
+		return err
+	}
+
+	cmd := exec.CommandContext(ctx, CliName, loginArgs(c.Config)...) //nolint:gosec
+	cmd.Env = commandEnv()
 	cmd.Env = append(cmd.Env, fmt.Sprintf("%v=%v", ConflientUsernameEnvKey, email), fmt.Sprintf("%v=%v", ConfluentPasswordEnvKey, password))
 	cmdOutput, err := cmd.CombinedOutput()
 	if err != nil {
 		return errors.Wrap(errors.New(errNotLoggedIn), string(cmdOutput))
 	}
 
-	return err
+	if c.Config.OrganizationID == "" {
+		return nil
+	}
+
+	out, err := ExecuteCommandContext(ctx, exec.Cmd{Path: CliName, Args: []string{"organization", "describe", "-o", "json"}})
+	if err != nil {
+		return errors.Wrap(CommandError(out), errDescribeOrganization)
+	}
+
+	return checkOrganization(out, c.Config.OrganizationID)
+}
+
+// loginArgs Returns the arguments of the login command for cfg
+func loginArgs(cfg Config) []string {
+	args := []string{"login", "--save"}
+	if cfg.OrganizationID != "" {
+		args = append(args, "--organization", cfg.OrganizationID)
+	}
+	if cfg.Endpoint != "" {
+		args = append(args, "--url", cfg.Endpoint)
+	}
+	return args
+}
+
+// checkOrganization Checks that the organization described by out is the organization with id
+func checkOrganization(out []byte, id string) error {
+	var org struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(out, &org); err != nil {
+		return errors.Wrap(err, errDescribeOrganization)
+	}
+
+	if org.ID != id {
+		return errors.Errorf(errWrongOrganization, org.ID, id)
+	}
+
+	return nil
 }
@@ -2,16 +2,39 @@
 package clients
 
 import (
+	"context"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
 	"go.dfds.cloud/utils/config"
 )
 
 func TestClientAuthenticate(t *testing.T) {
 	SkipCI(t)
-	client := NewClient()
-	err := client.Authenticate(config.GetEnvValue(ConflientUsernameEnvKey, ""), config.GetEnvValue(ConfluentPasswordEnvKey, ""))
+	client := NewClient(Config{})
+	err := client.Authenticate(context.Background(), config.GetEnvValue(ConflientUsernameEnvKey, ""), config.GetEnvValue(ConfluentPasswordEnvKey, ""))
 	if err != nil {
 		t.Error(err)
 	}
 }
+
+func TestLoginArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]string{"login", "--save"}, loginArgs(Config{}))
+	assert.Equal([]string{"login", "--save", "--organization", "org-12345"}, loginArgs(Config{OrganizationID: "org-12345"}))
+	assert.Equal([]string{"login", "--save", "--url", "https://confluent.example.com"}, loginArgs(Config{Endpoint: "https://confluent.example.com"}))
+
+	// Another endpoint is never served by the login of the default one
+	assert.NotEqual(sessionKey("alice@example.com", "password", Config{}), sessionKey("alice@example.com", "password", Config{Endpoint: "https://confluent.example.com"}))
+	assert.NotEqual(sessionKey("alice@example.com", "password", Config{}), sessionKey("alice@example.com", "password", Config{Proxy: "http://proxy.example.com:3128"}))
+}
+
+func TestCheckOrganization(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(checkOrganization([]byte(`{"id": "org-12345", "name": "dfds"}`), "org-12345"))
+	assert.EqualError(checkOrganization([]byte(`{"id": "org-67890", "name": "other"}`), "org-12345"), "logged in to organization org-67890 instead of org-12345")
+	assert.Error(checkOrganization([]byte(`not json`), "org-12345"))
+}
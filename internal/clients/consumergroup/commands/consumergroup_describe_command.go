@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewConsumerGroupDescribeCommand is a factory method for ConsumerGroup describe command
+func NewConsumerGroupDescribeCommand(groupID string, environment string, cluster string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "consumer", "group", "describe", groupID, "--environment", environment, "--cluster", cluster, "-o", "json"},
+	}
+
+	return command
+}
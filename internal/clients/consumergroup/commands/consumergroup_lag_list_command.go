@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewConsumerGroupLagListCommand is a factory method for ConsumerGroup lag list command
+func NewConsumerGroupLagListCommand(groupID string, environment string, cluster string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "consumer", "group", "lag", "list", groupID, "--environment", environment, "--cluster", cluster, "-o", "json"},
+	}
+
+	return command
+}
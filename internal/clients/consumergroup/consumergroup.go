@@ -0,0 +1,77 @@
+package consumergroup
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/consumergroup/commands"
+)
+
+// Errors
+const (
+	errUnknown   = "unknown error"
+	ErrNotExists = "consumer group does not exist"
+)
+
+// ErrNotFound is returned when a consumer group does not exist in the cluster
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for consumer group client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// ConsumerGroupDescribe Executes Confluent CLI command to retrieve the state of a consumer group from Confluent Cloud
+func (c *Client) ConsumerGroupDescribe(ctx context.Context, groupID string, environment string, cluster string) (ConsumerGroup, error) {
+	var resp ConsumerGroup
+
+	cmd := commands.NewConsumerGroupDescribeCommand(groupID, environment, cluster)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// ConsumerGroupLagList Executes Confluent CLI command to retrieve the lag of a consumer group per partition from Confluent Cloud
+func (c *Client) ConsumerGroupLagList(ctx context.Context, groupID string, environment string, cluster string) ([]Lag, error) {
+	var resp []Lag
+
+	cmd := commands.NewConsumerGroupLagListCommand(groupID, environment, cluster)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") || strings.Contains(str, "does not exist") {
+		return ErrNotFound
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
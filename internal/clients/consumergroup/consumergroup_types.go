@@ -0,0 +1,42 @@
+package consumergroup
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for consumer group client
+type IClient interface {
+	ConsumerGroupDescribe(ctx context.Context, groupID string, environment string, cluster string) (ConsumerGroup, error)
+	ConsumerGroupLagList(ctx context.Context, groupID string, environment string, cluster string) ([]Lag, error)
+}
+
+// Config is a configuration element for the consumer group client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for consumer group client
+type Client struct {
+	Config Config
+}
+
+// ConsumerGroup struct for deserialising Confluent Cloud describe response
+type ConsumerGroup struct {
+	ConsumerGroupID   string `json:"consumer_group_id"`
+	Coordinator       string `json:"coordinator"`
+	IsSimple          bool   `json:"is_simple"`
+	PartitionAssignor string `json:"partition_assignor"`
+	State             string `json:"state"`
+}
+
+// Lag struct for deserialising the lag of a single partition in the Confluent Cloud lag list response
+type Lag struct {
+	Topic         string `json:"topic"`
+	Partition     int    `json:"partition"`
+	CurrentOffset int64  `json:"current_offset"`
+	LogEndOffset  int64  `json:"log_end_offset"`
+	Lag           int64  `json:"lag"`
+	ConsumerID    string `json:"consumer"`
+	ClientID      string `json:"client"`
+}
@@ -0,0 +1,34 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCustomConnectorPluginCreateCommand is a factory method for CustomConnectorPlugin create command
+func NewCustomConnectorPluginCreateCommand(file string, cp v1alpha1.CustomConnectorPluginParameters) exec.Cmd {
+	args := []string{"connect", "custom-plugin", "create", cp.PluginName, "--plugin-file", file, "--connector-class", cp.ConnectorClass, "--connector-type", strings.ToLower(cp.ConnectorType)}
+
+	if cp.Description != "" {
+		args = append(args, "--description", cp.Description)
+	}
+	if cp.DocumentationLink != "" {
+		args = append(args, "--documentation-link", cp.DocumentationLink)
+	}
+	if len(cp.SensitiveProperties) > 0 {
+		args = append(args, "--sensitive-properties", strings.Join(cp.SensitiveProperties, ","))
+	}
+	if cp.Cloud != "" {
+		args = append(args, "--cloud", strings.ToLower(cp.Cloud))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
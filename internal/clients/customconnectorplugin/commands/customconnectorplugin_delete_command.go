@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCustomConnectorPluginDeleteCommand is a factory method for CustomConnectorPlugin delete command
+func NewCustomConnectorPluginDeleteCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"connect", "custom-plugin", "delete", id, "--force"},
+	}
+
+	return command
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCustomConnectorPluginDescribeCommand is a factory method for CustomConnectorPlugin describe command
+func NewCustomConnectorPluginDescribeCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"connect", "custom-plugin", "describe", id, "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCustomConnectorPluginListCommand is a factory method for CustomConnectorPlugin list command
+func NewCustomConnectorPluginListCommand() exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"connect", "custom-plugin", "list", "-o", "json"},
+	}
+
+	return command
+}
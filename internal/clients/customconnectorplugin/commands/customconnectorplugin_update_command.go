@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewCustomConnectorPluginUpdateCommand is a factory method for CustomConnectorPlugin update command
+func NewCustomConnectorPluginUpdateCommand(id string, cp v1alpha1.CustomConnectorPluginParameters) exec.Cmd {
+	args := []string{"connect", "custom-plugin", "update", id, "--name", cp.PluginName, "--description", cp.Description, "--documentation-link", cp.DocumentationLink}
+
+	if len(cp.SensitiveProperties) > 0 {
+		args = append(args, "--sensitive-properties", strings.Join(cp.SensitiveProperties, ","))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
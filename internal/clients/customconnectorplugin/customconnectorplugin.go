@@ -0,0 +1,152 @@
+package customconnectorplugin
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/customconnectorplugin/commands"
+)
+
+// Errors
+const (
+	errUnknown       = "unknown error"
+	errAmbiguousName = "organization has %d custom connector plugins named %s, set the ID of one of them as external name to adopt it"
+	ErrNotExists     = "custom connector plugin does not exist"
+	ErrInUseByOther  = "custom connector plugin is in use by connectors, delete them first"
+	ErrInvalidInput  = "input given may be invalid like an unsupported plugin file or connector class"
+)
+
+var (
+	// ErrNotFound is returned when a custom connector plugin does not exist in Confluent Cloud
+	ErrNotFound = errors.New(ErrNotExists)
+
+	// ErrInUse is returned when a custom connector plugin cannot be deleted as connectors still use it
+	ErrInUse = errors.New(ErrInUseByOther)
+)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsInUse reports whether err is, or wraps, ErrInUse
+func IsInUse(err error) bool {
+	return errors.Is(err, ErrInUse)
+}
+
+// NewClient is a factory method for custom connector plugin client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// CustomConnectorPluginCreate Executes Confluent CLI command to upload a plugin file to Confluent Cloud & return the created Plugin
+func (c *Client) CustomConnectorPluginCreate(ctx context.Context, file string, cp v1alpha1.CustomConnectorPluginParameters) (Plugin, error) {
+	var resp Plugin
+
+	cmd := commands.NewCustomConnectorPluginCreateCommand(file, cp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// CustomConnectorPluginDescribe Executes Confluent CLI command to retrieve a custom connector plugin by id from Confluent Cloud
+func (c *Client) CustomConnectorPluginDescribe(ctx context.Context, id string) (Plugin, error) {
+	var resp Plugin
+
+	cmd := commands.NewCustomConnectorPluginDescribeCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// CustomConnectorPluginByName Executes Confluent CLI command to list the custom connector plugins of the organization & return the plugin of
+// name. Several plugins of name are refused rather than picking one of them
+func (c *Client) CustomConnectorPluginByName(ctx context.Context, name string) (Plugin, error) {
+	cmd := commands.NewCustomConnectorPluginListCommand()
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return Plugin{}, errorParser(out)
+	}
+
+	var resp []Plugin
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return Plugin{}, err
+	}
+
+	var found []Plugin
+	for _, v := range resp {
+		if v.Name == name {
+			found = append(found, v)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Plugin{}, ErrNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return Plugin{}, errors.Errorf(errAmbiguousName, len(found), name)
+	}
+}
+
+// CustomConnectorPluginUpdate Executes Confluent CLI command to change the name, description, documentation link & sensitive properties of a custom connector plugin in Confluent Cloud
+func (c *Client) CustomConnectorPluginUpdate(ctx context.Context, id string, cp v1alpha1.CustomConnectorPluginParameters) error {
+	cmd := commands.NewCustomConnectorPluginUpdateCommand(id, cp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// CustomConnectorPluginDelete Executes Confluent CLI command to delete a custom connector plugin from Confluent Cloud
+func (c *Client) CustomConnectorPluginDelete(ctx context.Context, id string) error {
+	cmd := commands.NewCustomConnectorPluginDeleteCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "in use") || strings.Contains(str, "being used") {
+		return errors.Wrap(ErrInUse, clients.CommandError(cmdout).Error())
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
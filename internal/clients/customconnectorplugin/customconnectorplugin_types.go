@@ -0,0 +1,36 @@
+package customconnectorplugin
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for custom connector plugin client
+type IClient interface {
+	CustomConnectorPluginCreate(ctx context.Context, file string, cp v1alpha1.CustomConnectorPluginParameters) (Plugin, error)
+	CustomConnectorPluginDescribe(ctx context.Context, id string) (Plugin, error)
+	CustomConnectorPluginByName(ctx context.Context, name string) (Plugin, error)
+	CustomConnectorPluginUpdate(ctx context.Context, id string, cp v1alpha1.CustomConnectorPluginParameters) error
+	CustomConnectorPluginDelete(ctx context.Context, id string) error
+}
+
+// Config is a configuration element for the custom connector plugin client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for custom connector plugin client
+type Client struct {
+	Config Config
+}
+
+// Plugin is a struct used for deserialising the response of CustomConnectorPluginCreate, CustomConnectorPluginDescribe & CustomConnectorPluginByName
+type Plugin struct {
+	ID                  string   `json:"id"`
+	Name                string   `json:"name"`
+	Description         string   `json:"description"`
+	ConnectorClass      string   `json:"connector_class"`
+	ConnectorType       string   `json:"connector_type"`
+	SensitiveProperties []string `json:"sensitive_properties"`
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewDNSForwarderCreateCommand is a factory method for DNSForwarder create command
+func NewDNSForwarderCreateCommand(name string, environment string, gateway string, domains []string, dnsServerIPs []string) exec.Cmd {
+	args := []string{"network", "dns", "forwarder", "create"}
+
+	if name != "" {
+		args = append(args, name)
+	}
+	args = append(args, "--gateway", gateway, "--domains", strings.Join(domains, ","), "--dns-server-ips", strings.Join(dnsServerIPs, ","), "--environment", environment, "-o", "json")
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewDNSForwarderDeleteCommand is a factory method for DNSForwarder delete command
+func NewDNSForwarderDeleteCommand(id string, environment string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"network", "dns", "forwarder", "delete", id, "--environment", environment, "--force"},
+	}
+
+	return command
+}
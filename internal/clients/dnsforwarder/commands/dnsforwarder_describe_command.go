@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewDNSForwarderDescribeCommand is a factory method for DNSForwarder describe command
+func NewDNSForwarderDescribeCommand(id string, environment string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"network", "dns", "forwarder", "describe", id, "--environment", environment, "-o", "json"},
+	}
+
+	return command
+}
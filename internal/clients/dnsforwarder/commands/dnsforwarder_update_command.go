@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewDNSForwarderUpdateCommand is a factory method for DNSForwarder update command
+func NewDNSForwarderUpdateCommand(id string, environment string, name string, domains []string, dnsServerIPs []string) exec.Cmd {
+	args := []string{"network", "dns", "forwarder", "update", id, "--environment", environment}
+
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if len(domains) > 0 {
+		args = append(args, "--domains", strings.Join(domains, ","))
+	}
+	if len(dnsServerIPs) > 0 {
+		args = append(args, "--dns-server-ips", strings.Join(dnsServerIPs, ","))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
@@ -0,0 +1,108 @@
+package dnsforwarder
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/dnsforwarder/commands"
+)
+
+// Errors
+const (
+	errUnknown        = "unknown error"
+	ErrNotExists      = "dns forwarder does not exist"
+	ErrInvalidInput   = "input given may be invalid like a malformed domain or ip address"
+	ErrNotAvailable   = "dns forwarders are not available, the Confluent CLI or organization does not support them"
+	errUnknownCommand = "unknown command \"dns\""
+)
+
+// ErrNotFound is returned when a dns forwarder does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for dns forwarder client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// DNSForwarderCreate Executes Confluent CLI command to create a dns forwarder in Confluent Cloud & return the created DNSForwarder
+func (c *Client) DNSForwarderCreate(ctx context.Context, f DNSForwarder) (DNSForwarder, error) {
+	var resp DNSForwarder
+
+	cmd := commands.NewDNSForwarderCreateCommand(f.Name, f.Environment, f.Gateway, f.Domains, f.DNSServerIPs)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// DNSForwarderDescribe Executes Confluent CLI command to retrieve a dns forwarder by id from Confluent Cloud
+func (c *Client) DNSForwarderDescribe(ctx context.Context, id string, environment string) (DNSForwarder, error) {
+	var resp DNSForwarder
+
+	cmd := commands.NewDNSForwarderDescribeCommand(id, environment)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// DNSForwarderUpdate Executes Confluent CLI command to rename a dns forwarder or change its domains & dns servers in Confluent Cloud
+func (c *Client) DNSForwarderUpdate(ctx context.Context, id string, environment string, u Update) error {
+	cmd := commands.NewDNSForwarderUpdateCommand(id, environment, u.Name, u.Domains, u.DNSServerIPs)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// DNSForwarderDelete Executes Confluent CLI command to delete a dns forwarder from Confluent Cloud
+func (c *Client) DNSForwarderDelete(ctx context.Context, id string, environment string) error {
+	cmd := commands.NewDNSForwarderDeleteCommand(id, environment)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, errUnknownCommand) {
+		return errors.Wrap(clients.CommandError(cmdout), ErrNotAvailable)
+	} else if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,43 @@
+package dnsforwarder
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for dns forwarder client
+type IClient interface {
+	DNSForwarderCreate(ctx context.Context, f DNSForwarder) (DNSForwarder, error)
+	DNSForwarderDescribe(ctx context.Context, id string, environment string) (DNSForwarder, error)
+	DNSForwarderUpdate(ctx context.Context, id string, environment string, u Update) error
+	DNSForwarderDelete(ctx context.Context, id string, environment string) error
+}
+
+// Config is a configuration element for the dns forwarder client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for dns forwarder client
+type Client struct {
+	Config Config
+}
+
+// DNSForwarder is a struct used for deserialising the response of DNSForwarderCreate & DNSForwarderDescribe
+type DNSForwarder struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	Environment  string   `json:"environment"`
+	Gateway      string   `json:"gateway"`
+	Domains      []string `json:"domains"`
+	DNSServerIPs []string `json:"dns_server_ips"`
+	Phase        string   `json:"phase"`
+}
+
+// Update describes the changes DNSForwarderUpdate applies to a dns forwarder. Empty fields are left unchanged, non-empty lists replace the
+// current ones
+type Update struct {
+	Name         string
+	Domains      []string
+	DNSServerIPs []string
+}
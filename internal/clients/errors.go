@@ -0,0 +1,253 @@
+package clients
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+)
+
+const (
+	errCommandFailed = "confluent command failed"
+	errorPrefix      = "Error: "
+	maxMessageLength = 256
+)
+
+// Redacted replaces secret values in logged CLI output
+const Redacted = "<redacted>"
+
+// secretOutput matches secret values in the output of Confluent CLI commands, e.g. "api_secret": "abc" or password=abc
+var secretOutput = regexp.MustCompile(`(?i)("?[a-z_\-]*(?:secret|password|token|credential)[a-z_\-]*"?\s*[:=]\s*)("[^"]*"|[^\s,}]+)`)
+
+// RedactOutput Returns the output of a Confluent CLI command with secret values replaced by Redacted
+func RedactOutput(output string) string {
+	return secretOutput.ReplaceAllString(output, "${1}"+Redacted)
+}
+
+// Log receives the full output of failed Confluent CLI commands. It discards everything unless set
+var Log = logging.NewNopLogger()
+
+// Error is a failed Confluent CLI command. Its message is kept short so it reads well in a condition, the full output of the command is kept in Output
+type Error struct {
+	Status  string
+	Message string
+	Output  string
+}
+
+func (e *Error) Error() string {
+	if e.Status != "" {
+		return e.Status + ": " + e.Message
+	}
+	return e.Message
+}
+
+// TransientError is a failed Confluent CLI command that is expected to succeed when retried, like a 5xx or 429 response or a timeout
+type TransientError struct {
+	Err *Error
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap Returns the underlying Error
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// TemporaryError is a failure outside of Confluent that is expected to resolve by itself, like a Kubernetes object that is briefly missing. It
+// is retried as soon as a TransientError, but doesn't use up the error budget of Confluent commands
+type TemporaryError struct {
+	Err error
+}
+
+func (e *TemporaryError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap Returns the underlying error
+func (e *TemporaryError) Unwrap() error {
+	return e.Err
+}
+
+// IsTemporary reports whether err is, or wraps, a TemporaryError
+func IsTemporary(err error) bool {
+	var te *TemporaryError
+	return errors.As(err, &te)
+}
+
+// transientOutput are fragments of CLI output that indicate a transient failure when no status was reported
+var transientOutput = []string{
+	"timeout",
+	"timed out",
+	"connection reset",
+	"connection refused",
+	"too many requests",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+	"temporarily unavailable",
+}
+
+// CommandError Parses the output of a failed Confluent CLI command like ParseError & returns it as a TransientError when it is transient
+func CommandError(out []byte) error {
+	e := ParseError(out)
+	if e.transient() {
+		return &TransientError{Err: e}
+	}
+	return e
+}
+
+// IsTransient reports whether err is, or wraps, a TransientError
+func IsTransient(err error) bool {
+	var te *TransientError
+	return errors.As(err, &te)
+}
+
+// IsPermanent reports whether err is, or wraps, a failed Confluent CLI command that is not transient. Retrying it fails the same way until the input changes
+func IsPermanent(err error) bool {
+	var e *Error
+	return !IsTransient(err) && errors.As(err, &e)
+}
+
+// IsRejected reports whether Confluent answered a command with a status that is not transient, so the command had no effect. A command that
+// was killed or failed without a status may still have taken effect
+func IsRejected(err error) bool {
+	var e *Error
+	return IsPermanent(err) && errors.As(err, &e) && e.Status != ""
+}
+
+// IsForbidden reports whether err is, or wraps, a failed Confluent CLI command denied for lack of permission, like a 403 response. Retrying it
+// fails the same way until the credentials are granted the permission
+func IsForbidden(err error) bool {
+	var e *Error
+	return errors.As(err, &e) && e.forbidden()
+}
+
+// forbidden Reports whether the command failed with a 403 status or, without a status, with output indicating a denied permission
+func (e *Error) forbidden() bool {
+	if e.Status != "" {
+		return e.Status == "403"
+	}
+	return strings.Contains(strings.ToLower(e.Output), "forbidden")
+}
+
+// transient Reports whether the command failed with a 5xx or 429 status or, without a status, with output indicating a transient failure
+func (e *Error) transient() bool {
+	if status, err := strconv.Atoi(e.Status); err == nil {
+		// Schema Registry error codes extend the HTTP status with two digits, e.g. 40401
+		if status >= 10000 {
+			status /= 100
+		}
+		return status >= 500 || status == 429
+	}
+
+	output := strings.ToLower(e.Output)
+	for _, o := range transientOutput {
+		if strings.Contains(output, o) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorBody is the error format of the Confluent Cloud APIs
+type errorBody struct {
+	Errors []struct {
+		Status interface{} `json:"status"`
+		Detail string      `json:"detail"`
+	} `json:"errors"`
+	ErrorCode interface{} `json:"error_code"`
+	Message   string      `json:"message"`
+}
+
+// ParseError Parses the output of a failed Confluent CLI command into a concise Error. JSON error bodies of the Confluent APIs are reduced to their status & details, any other output to its first error line. The full output is logged to Log with secret values redacted
+func ParseError(out []byte) *Error {
+	output := strings.TrimSpace(string(out))
+	Log.Debug(errCommandFailed, "output", RedactOutput(output))
+
+	e := &Error{Output: output}
+	if body, ok := parseErrorBody(out); ok {
+		e.Status, e.Message = body.status(), body.detail()
+	}
+
+	if e.Message == "" {
+		e.Message = firstErrorLine(output)
+	}
+	if e.Message == "" {
+		e.Message = errCommandFailed
+	}
+
+	e.Message = truncate(e.Message, maxMessageLength)
+	return e
+}
+
+// parseErrorBody Finds & decodes a JSON error body in the output of a CLI command, which may be surrounded by other text
+func parseErrorBody(out []byte) (errorBody, bool) {
+	var body errorBody
+
+	start := bytes.IndexByte(out, '{')
+	end := bytes.LastIndexByte(out, '}')
+	if start < 0 || end < start {
+		return body, false
+	}
+
+	if err := json.Unmarshal(out[start:end+1], &body); err != nil {
+		return body, false
+	}
+
+	return body, len(body.Errors) > 0 || body.Message != ""
+}
+
+func (b errorBody) status() string {
+	if len(b.Errors) > 0 && b.Errors[0].Status != nil {
+		return fmt.Sprint(b.Errors[0].Status)
+	}
+	if b.ErrorCode != nil {
+		return fmt.Sprint(b.ErrorCode)
+	}
+	return ""
+}
+
+func (b errorBody) detail() string {
+	var details []string
+	for _, e := range b.Errors {
+		if e.Detail != "" {
+			details = append(details, e.Detail)
+		}
+	}
+	if len(details) == 0 && b.Message != "" {
+		details = append(details, b.Message)
+	}
+	return strings.Join(details, "; ")
+}
+
+// firstErrorLine Returns the first line of output starting with "Error: ", without the prefix, or else the first non-empty line
+func firstErrorLine(output string) string {
+	var first string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, errorPrefix) {
+			return strings.TrimPrefix(line, errorPrefix)
+		}
+		if first == "" {
+			first = line
+		}
+	}
+	return first
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
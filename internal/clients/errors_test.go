@@ -0,0 +1,140 @@
+package clients
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeLogger struct {
+	logging.Logger
+	lines *[]string
+}
+
+func (l fakeLogger) Debug(msg string, keysAndValues ...interface{}) {
+	*l.lines = append(*l.lines, fmt.Sprint(append([]interface{}{msg}, keysAndValues...)...))
+}
+
+func TestParseError(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		name    string
+		out     string
+		status  string
+		message string
+	}{
+		{
+			name:    "errors array",
+			out:     "Error: REST request failed: {\"errors\":[{\"status\":\"404\",\"detail\":\"Topic 'orders' not found.\"}]}\n",
+			status:  "404",
+			message: "Topic 'orders' not found.",
+		},
+		{
+			name:    "multiple errors with numeric status",
+			out:     `{"errors":[{"status":400,"detail":"invalid name"},{"status":400,"detail":"invalid partitions"}]}`,
+			status:  "400",
+			message: "invalid name; invalid partitions",
+		},
+		{
+			name:    "error code and message",
+			out:     `Error: {"error_code":40401,"message":"Subject 'orders-value' not found."}`,
+			status:  "40401",
+			message: "Subject 'orders-value' not found.",
+		},
+		{
+			name:    "plain text",
+			out:     "Usage: confluent kafka topic describe\n\nError: unknown topic \"orders\"\n",
+			message: "unknown topic \"orders\"",
+		},
+		{
+			name:    "invalid json",
+			out:     "Something went wrong {not json}\n",
+			message: "Something went wrong {not json}",
+		},
+		{
+			name:    "empty output",
+			out:     "",
+			message: errCommandFailed,
+		},
+	}
+
+	for _, c := range cases {
+		e := ParseError([]byte(c.out))
+		assert.Equal(c.status, e.Status, c.name)
+		assert.Equal(c.message, e.Message, c.name)
+		assert.Equal(strings.TrimSpace(c.out), e.Output, c.name)
+	}
+
+	e := ParseError([]byte(`{"errors":[{"status":"403","detail":"Forbidden"}]}`))
+	assert.Equal("403: Forbidden", e.Error())
+
+	e = ParseError([]byte("Error: " + strings.Repeat("x", 1000)))
+	assert.Equal(maxMessageLength+len("..."), len(e.Message))
+}
+
+func TestCommandError(t *testing.T) {
+	assert := assert.New(t)
+
+	cases := []struct {
+		name      string
+		out       string
+		transient bool
+	}{
+		{name: "bad request", out: `Error: REST request failed: {"errors":[{"status":"400","detail":"Invalid partition count."}]}`},
+		{name: "not found", out: `{"error_code":40401,"message":"Subject 'orders-value' not found."}`},
+		{name: "400 mentioning a timeout", out: `{"errors":[{"status":"400","detail":"Invalid value for request.timeout.ms"}]}`},
+		{name: "service unavailable", out: `Error: REST request failed: {"errors":[{"status":"503","detail":"Service Unavailable"}]}`, transient: true},
+		{name: "rate limited", out: `{"errors":[{"status":429,"detail":"Too Many Requests"}]}`, transient: true},
+		{name: "schema registry backend error", out: `{"error_code":50001,"message":"Error in the backend datastore"}`, transient: true},
+		{name: "timeout without status", out: "Error: Get \"https://api.confluent.cloud/iam/v2/service-accounts\": net/http: request canceled (Client.Timeout exceeded)", transient: true},
+	}
+
+	for _, c := range cases {
+		err := errors.Wrap(CommandError([]byte(c.out)), "unknown error")
+		assert.Equal(c.transient, IsTransient(err), c.name)
+		assert.Equal(!c.transient, IsPermanent(err), c.name)
+	}
+
+	assert.False(IsTransient(errors.New("conflict")))
+	assert.False(IsForbidden(errors.New("forbidden")), "errors not caused by a command are never forbidden")
+	assert.False(IsPermanent(errors.New("conflict")), "errors not caused by a command are neither")
+}
+
+func TestIsRejected(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsRejected(CommandError([]byte(`{"errors":[{"status":"400","detail":"Invalid plugin"}]}`))))
+	assert.False(IsRejected(CommandError([]byte(`{"errors":[{"status":"503","detail":"Service Unavailable"}]}`))), "transient failures are retried")
+	assert.False(IsRejected(CommandError([]byte("signal: killed"))), "a command without a status may have taken effect")
+	assert.False(IsRejected(errors.New("conflict")))
+}
+
+func TestIsForbidden(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(IsForbidden(errors.Wrap(CommandError([]byte(`Error: REST request failed: {"errors":[{"status":"403","detail":"Forbidden Access"}]}`)), "unknown error")))
+	assert.True(IsForbidden(CommandError([]byte("Error: error creating service account: Forbidden"))))
+	assert.False(IsForbidden(CommandError([]byte(`{"errors":[{"status":"404","detail":"Forbidden topic name not found"}]}`))), "the status decides over the output")
+	assert.False(IsForbidden(CommandError([]byte(`{"errors":[{"status":"401","detail":"Unauthorized"}]}`))))
+}
+
+func TestParseErrorRedactsLog(t *testing.T) {
+	assert := assert.New(t)
+
+	var lines []string
+	defer func(l logging.Logger) { Log = l }(Log)
+	Log = fakeLogger{lines: &lines}
+
+	ParseError([]byte(`Error: failed to store key: {"api_key":"ABC","api_secret":"s3cr3t"} password=hunter2`))
+
+	assert.Len(lines, 1)
+	assert.NotContains(lines[0], "s3cr3t")
+	assert.NotContains(lines[0], "hunter2")
+	assert.Contains(lines[0], `"api_key":"ABC"`)
+	assert.Equal("Error: password="+Redacted+" token: "+Redacted, RedactOutput("Error: password=hunter2 token: abc"))
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"os/exec"
+	"sort"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewFlinkStatementCreateCommand is a factory method for FlinkStatement create command
+func NewFlinkStatementCreateCommand(name string, fp v1alpha1.FlinkStatementParameters) exec.Cmd {
+	args := []string{"flink", "statement", "create", name, "--sql", fp.Statement, "--compute-pool", fp.ComputePool, "--environment", fp.Environment}
+
+	if fp.Database != "" {
+		args = append(args, "--database", fp.Database)
+	}
+	if fp.ServiceAccount != "" {
+		args = append(args, "--service-account", fp.ServiceAccount)
+	}
+
+	// Sorted so the command is stable across reconciles
+	keys := make([]string, 0, len(fp.Properties))
+	for k := range fp.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "--property", k+"="+fp.Properties[k])
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
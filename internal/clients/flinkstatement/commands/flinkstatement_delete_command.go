@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewFlinkStatementDeleteCommand is a factory method for FlinkStatement delete command
+func NewFlinkStatementDeleteCommand(fo v1alpha1.FlinkStatementObservation) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"flink", "statement", "delete", fo.Name, "--environment", fo.Environment, "--force"},
+	}
+
+	return command
+}
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewFlinkStatementDescribeCommand is a factory method for FlinkStatement describe command
+func NewFlinkStatementDescribeCommand(fo v1alpha1.FlinkStatementObservation) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"flink", "statement", "describe", fo.Name, "--environment", fo.Environment, "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewFlinkStatementStopCommand is a factory method for FlinkStatement stop command
+func NewFlinkStatementStopCommand(fo v1alpha1.FlinkStatementObservation) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"flink", "statement", "stop", fo.Name, "--environment", fo.Environment},
+	}
+
+	return command
+}
@@ -0,0 +1,98 @@
+package flinkstatement
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/flinkstatement/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "flink statement does not exist"
+	ErrInvalidInput = "input given may be invalid like an invalid statement or compute pool"
+)
+
+// ErrNotFound is returned when a Flink statement does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for flink statement client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// FlinkStatementCreate Executes Confluent CLI command to submit a Flink statement with the given name to a compute pool in Confluent Cloud
+func (c *Client) FlinkStatementCreate(ctx context.Context, name string, fp v1alpha1.FlinkStatementParameters) error {
+	cmd := commands.NewFlinkStatementCreateCommand(name, fp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// FlinkStatementDescribe Executes Confluent CLI command to retrieve a Flink statement and its phase from Confluent Cloud
+func (c *Client) FlinkStatementDescribe(ctx context.Context, fo v1alpha1.FlinkStatementObservation) (DescribeResponse, error) {
+	var resp DescribeResponse
+
+	cmd := commands.NewFlinkStatementDescribeCommand(fo)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// FlinkStatementStop Executes Confluent CLI command to stop a running Flink statement in Confluent Cloud
+func (c *Client) FlinkStatementStop(ctx context.Context, fo v1alpha1.FlinkStatementObservation) error {
+	cmd := commands.NewFlinkStatementStopCommand(fo)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// FlinkStatementDelete Executes Confluent CLI command to delete a Flink statement from Confluent Cloud
+func (c *Client) FlinkStatementDelete(ctx context.Context, fo v1alpha1.FlinkStatementObservation) error {
+	cmd := commands.NewFlinkStatementDeleteCommand(fo)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := string(cmdout)
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "Error: REST request failed") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,35 @@
+package flinkstatement
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for flink statement client
+type IClient interface {
+	FlinkStatementCreate(ctx context.Context, name string, fp v1alpha1.FlinkStatementParameters) error
+	FlinkStatementDescribe(ctx context.Context, fo v1alpha1.FlinkStatementObservation) (DescribeResponse, error)
+	FlinkStatementStop(ctx context.Context, fo v1alpha1.FlinkStatementObservation) error
+	FlinkStatementDelete(ctx context.Context, fo v1alpha1.FlinkStatementObservation) error
+}
+
+// Config is a configuration element for the flink statement client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for flink statement client
+type Client struct {
+	Config Config
+}
+
+// DescribeResponse is a struct used for deserialising the response of FlinkStatementDescribe
+type DescribeResponse struct {
+	Name         string            `json:"name"`
+	Statement    string            `json:"statement"`
+	ComputePool  string            `json:"compute_pool"`
+	Status       string            `json:"status"`
+	StatusDetail string            `json:"status_detail"`
+	Properties   map[string]string `json:"properties"`
+}
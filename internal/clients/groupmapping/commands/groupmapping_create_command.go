@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewGroupMappingCreateCommand is a factory method for GroupMapping create command
+func NewGroupMappingCreateCommand(name string, description string, filter string) exec.Cmd {
+	args := []string{"iam", "group-mapping", "create", name, "--filter", filter}
+
+	if description != "" {
+		args = append(args, "--description", description)
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
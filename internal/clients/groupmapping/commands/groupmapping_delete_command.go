@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewGroupMappingDeleteCommand is a factory method for GroupMapping delete command
+func NewGroupMappingDeleteCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "group-mapping", "delete", id, "--force"},
+	}
+
+	return command
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewGroupMappingDescribeCommand is a factory method for GroupMapping describe command
+func NewGroupMappingDescribeCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "group-mapping", "describe", id, "-o", "json"},
+	}
+
+	return command
+}
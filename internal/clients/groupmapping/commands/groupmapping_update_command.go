@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewGroupMappingUpdateCommand is a factory method for GroupMapping update command
+func NewGroupMappingUpdateCommand(id string, name string, description *string, filter string) exec.Cmd {
+	args := []string{"iam", "group-mapping", "update", id}
+
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if description != nil {
+		args = append(args, "--description", *description)
+	}
+	if filter != "" {
+		args = append(args, "--filter", filter)
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
@@ -0,0 +1,104 @@
+package groupmapping
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/groupmapping/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "group mapping does not exist"
+	ErrInvalidInput = "input given may be invalid like a malformed filter"
+)
+
+// ErrNotFound is returned when a group mapping does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for group mapping client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// GroupMappingCreate Executes Confluent CLI command to create a group mapping in Confluent Cloud & return the created GroupMapping
+func (c *Client) GroupMappingCreate(ctx context.Context, name string, description string, filter string) (GroupMapping, error) {
+	var resp GroupMapping
+
+	cmd := commands.NewGroupMappingCreateCommand(name, description, filter)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// GroupMappingDescribe Executes Confluent CLI command to retrieve a group mapping by id from Confluent Cloud
+func (c *Client) GroupMappingDescribe(ctx context.Context, id string) (GroupMapping, error) {
+	var resp GroupMapping
+
+	cmd := commands.NewGroupMappingDescribeCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// GroupMappingUpdate Executes Confluent CLI command to rename a group mapping or change its description or filter in Confluent Cloud
+func (c *Client) GroupMappingUpdate(ctx context.Context, id string, u Update) error {
+	cmd := commands.NewGroupMappingUpdateCommand(id, u.Name, u.Description, u.Filter)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// GroupMappingDelete Executes Confluent CLI command to delete a group mapping from Confluent Cloud
+func (c *Client) GroupMappingDelete(ctx context.Context, id string) error {
+	cmd := commands.NewGroupMappingDeleteCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,44 @@
+package groupmapping
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for group mapping client
+type IClient interface {
+	GroupMappingCreate(ctx context.Context, name string, description string, filter string) (GroupMapping, error)
+	GroupMappingDescribe(ctx context.Context, id string) (GroupMapping, error)
+	GroupMappingUpdate(ctx context.Context, id string, u Update) error
+	GroupMappingDelete(ctx context.Context, id string) error
+}
+
+// Config is a configuration element for the group mapping client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for group mapping client
+type Client struct {
+	Config Config
+}
+
+// GroupMapping is a struct used for deserialising the response of GroupMappingCreate & GroupMappingDescribe
+type GroupMapping struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Filter      string `json:"filter"`
+}
+
+// Principal Returns the principal of the group mapping in role bindings
+func (gm GroupMapping) Principal() string {
+	return "User:" + gm.ID
+}
+
+// Update describes the changes GroupMappingUpdate applies to a group mapping. Empty fields are left unchanged, a nil Description too
+type Update struct {
+	Name        string
+	Description *string
+	Filter      string
+}
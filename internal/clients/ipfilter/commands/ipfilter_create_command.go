@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/apis/ipfilter/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPFilterCreateCommand is a factory method for IPFilter create command
+func NewIPFilterCreateCommand(fp v1alpha1.IPFilterParameters) exec.Cmd {
+	args := []string{"iam", "ip-filter", "create", fp.FilterName, "--ip-groups", strings.Join(fp.IPGroups, ",")}
+
+	if fp.ResourceGroup != "" {
+		args = append(args, "--resource-group", fp.ResourceGroup)
+	}
+	if len(fp.OperationGroups) > 0 {
+		args = append(args, "--operations", strings.Join(fp.OperationGroups, ","))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
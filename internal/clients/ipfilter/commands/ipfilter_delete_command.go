@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPFilterDeleteCommand is a factory method for IPFilter delete command
+func NewIPFilterDeleteCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "ip-filter", "delete", id, "--force"},
+	}
+
+	return command
+}
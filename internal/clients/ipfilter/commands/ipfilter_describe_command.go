@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPFilterDescribeCommand is a factory method for IPFilter describe command
+func NewIPFilterDescribeCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "ip-filter", "describe", id, "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPFilterUpdateCommand is a factory method for IPFilter update command
+func NewIPFilterUpdateCommand(id string, name string, resourceGroup string, addIPGroups []string, removeIPGroups []string, addOperationGroups []string, removeOperationGroups []string) exec.Cmd {
+	args := []string{"iam", "ip-filter", "update", id}
+
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if resourceGroup != "" {
+		args = append(args, "--resource-group", resourceGroup)
+	}
+	if len(addIPGroups) > 0 {
+		args = append(args, "--add-ip-groups", strings.Join(addIPGroups, ","))
+	}
+	if len(removeIPGroups) > 0 {
+		args = append(args, "--remove-ip-groups", strings.Join(removeIPGroups, ","))
+	}
+	if len(addOperationGroups) > 0 {
+		args = append(args, "--add-operation-groups", strings.Join(addOperationGroups, ","))
+	}
+	if len(removeOperationGroups) > 0 {
+		args = append(args, "--remove-operation-groups", strings.Join(removeOperationGroups, ","))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
@@ -0,0 +1,105 @@
+package ipfilter
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/ipfilter/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/ipfilter/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "ip filter does not exist"
+	ErrInvalidInput = "input given may be invalid like an unknown ip group or operation group"
+)
+
+// ErrNotFound is returned when an ip filter does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for ip filter client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// IPFilterCreate Executes Confluent CLI command to create an ip filter in Confluent Cloud & return the created IPFilter
+func (c *Client) IPFilterCreate(ctx context.Context, fp v1alpha1.IPFilterParameters) (IPFilter, error) {
+	var resp IPFilter
+
+	cmd := commands.NewIPFilterCreateCommand(fp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// IPFilterDescribe Executes Confluent CLI command to retrieve an ip filter by id from Confluent Cloud
+func (c *Client) IPFilterDescribe(ctx context.Context, id string) (IPFilter, error) {
+	var resp IPFilter
+
+	cmd := commands.NewIPFilterDescribeCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// IPFilterUpdate Executes Confluent CLI command to change an ip filter in Confluent Cloud
+func (c *Client) IPFilterUpdate(ctx context.Context, id string, u Update) error {
+	cmd := commands.NewIPFilterUpdateCommand(id, u.Name, u.ResourceGroup, u.AddIPGroups, u.RemoveIPGroups, u.AddOperationGroups, u.RemoveOperationGroups)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// IPFilterDelete Executes Confluent CLI command to delete an ip filter from Confluent Cloud
+func (c *Client) IPFilterDelete(ctx context.Context, id string) error {
+	cmd := commands.NewIPFilterDeleteCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,44 @@
+package ipfilter
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/apis/ipfilter/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for ip filter client
+type IClient interface {
+	IPFilterCreate(ctx context.Context, fp v1alpha1.IPFilterParameters) (IPFilter, error)
+	IPFilterDescribe(ctx context.Context, id string) (IPFilter, error)
+	IPFilterUpdate(ctx context.Context, id string, u Update) error
+	IPFilterDelete(ctx context.Context, id string) error
+}
+
+// Config is a configuration element for the ip filter client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for ip filter client
+type Client struct {
+	Config Config
+}
+
+// IPFilter is a struct used for deserialising the response of IPFilterCreate & IPFilterDescribe
+type IPFilter struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name"`
+	ResourceGroup   string   `json:"resource_group"`
+	IPGroups        []string `json:"ip_groups"`
+	OperationGroups []string `json:"operation_groups"`
+}
+
+// Update describes the changes IPFilterUpdate applies to an ip filter. Empty fields are left unchanged
+type Update struct {
+	Name                  string
+	ResourceGroup         string
+	AddIPGroups           []string
+	RemoveIPGroups        []string
+	AddOperationGroups    []string
+	RemoveOperationGroups []string
+}
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPGroupCreateCommand is a factory method for IPGroup create command
+func NewIPGroupCreateCommand(name string, cidrBlocks []string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "ip-group", "create", name, "--cidr-blocks", strings.Join(cidrBlocks, ","), "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPGroupDeleteCommand is a factory method for IPGroup delete command
+func NewIPGroupDeleteCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "ip-group", "delete", id, "--force"},
+	}
+
+	return command
+}
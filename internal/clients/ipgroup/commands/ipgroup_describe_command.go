@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPGroupDescribeCommand is a factory method for IPGroup describe command
+func NewIPGroupDescribeCommand(id string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"iam", "ip-group", "describe", id, "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewIPGroupUpdateCommand is a factory method for IPGroup update command
+func NewIPGroupUpdateCommand(id string, name string, addCIDRBlocks []string, removeCIDRBlocks []string) exec.Cmd {
+	args := []string{"iam", "ip-group", "update", id}
+
+	if name != "" {
+		args = append(args, "--name", name)
+	}
+	if len(addCIDRBlocks) > 0 {
+		args = append(args, "--add-cidr-blocks", strings.Join(addCIDRBlocks, ","))
+	}
+	if len(removeCIDRBlocks) > 0 {
+		args = append(args, "--remove-cidr-blocks", strings.Join(removeCIDRBlocks, ","))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
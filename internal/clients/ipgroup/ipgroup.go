@@ -0,0 +1,104 @@
+package ipgroup
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/ipgroup/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "ip group does not exist"
+	ErrInvalidInput = "input given may be invalid like a malformed cidr block"
+)
+
+// ErrNotFound is returned when an ip group does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for ip group client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// IPGroupCreate Executes Confluent CLI command to create an ip group in Confluent Cloud & return the created IPGroup
+func (c *Client) IPGroupCreate(ctx context.Context, name string, cidrBlocks []string) (IPGroup, error) {
+	var resp IPGroup
+
+	cmd := commands.NewIPGroupCreateCommand(name, cidrBlocks)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// IPGroupDescribe Executes Confluent CLI command to retrieve an ip group by id from Confluent Cloud
+func (c *Client) IPGroupDescribe(ctx context.Context, id string) (IPGroup, error) {
+	var resp IPGroup
+
+	cmd := commands.NewIPGroupDescribeCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// IPGroupUpdate Executes Confluent CLI command to rename an ip group or change its cidr blocks in Confluent Cloud
+func (c *Client) IPGroupUpdate(ctx context.Context, id string, u Update) error {
+	cmd := commands.NewIPGroupUpdateCommand(id, u.Name, u.AddCIDRBlocks, u.RemoveCIDRBlocks)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// IPGroupDelete Executes Confluent CLI command to delete an ip group from Confluent Cloud
+func (c *Client) IPGroupDelete(ctx context.Context, id string) error {
+	cmd := commands.NewIPGroupDeleteCommand(id)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
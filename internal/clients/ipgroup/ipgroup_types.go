@@ -0,0 +1,38 @@
+package ipgroup
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for ip group client
+type IClient interface {
+	IPGroupCreate(ctx context.Context, name string, cidrBlocks []string) (IPGroup, error)
+	IPGroupDescribe(ctx context.Context, id string) (IPGroup, error)
+	IPGroupUpdate(ctx context.Context, id string, u Update) error
+	IPGroupDelete(ctx context.Context, id string) error
+}
+
+// Config is a configuration element for the ip group client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for ip group client
+type Client struct {
+	Config Config
+}
+
+// IPGroup is a struct used for deserialising the response of IPGroupCreate & IPGroupDescribe
+type IPGroup struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	CIDRBlocks []string `json:"cidr_blocks"`
+}
+
+// Update describes the changes IPGroupUpdate applies to an ip group. Empty fields are left unchanged
+type Update struct {
+	Name             string
+	AddCIDRBlocks    []string
+	RemoveCIDRBlocks []string
+}
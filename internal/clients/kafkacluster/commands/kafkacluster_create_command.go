@@ -0,0 +1,30 @@
+package commands
+
+import (
+	"os/exec"
+	"strconv"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewClusterCreateCommand is a factory method for kafka cluster create command
+func NewClusterCreateCommand(name string, environment string, cloud string, region string, availability string, clusterType string, cku int) exec.Cmd {
+	args := []string{"kafka", "cluster", "create", name, "--environment", environment, "--cloud", cloud, "--region", region}
+
+	if availability != "" {
+		args = append(args, "--availability", availability)
+	}
+	if clusterType != "" {
+		args = append(args, "--type", clusterType)
+	}
+	if cku > 0 {
+		args = append(args, "--cku", strconv.Itoa(cku))
+	}
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
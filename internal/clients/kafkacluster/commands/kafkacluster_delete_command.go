@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewClusterDeleteCommand is a factory method for kafka cluster delete command
+func NewClusterDeleteCommand(id string, environment string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "cluster", "delete", id, "--environment", environment, "--force"},
+	}
+
+	return command
+}
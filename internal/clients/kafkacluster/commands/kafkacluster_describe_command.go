@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewClusterDescribeCommand is a factory method for kafka cluster describe command
+func NewClusterDescribeCommand(id string, environment string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "cluster", "describe", id, "--environment", environment, "-o", "json"},
+	}
+
+	return command
+}
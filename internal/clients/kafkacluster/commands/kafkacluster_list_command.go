@@ -0,0 +1,17 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewClusterListCommand is a factory method for kafka cluster list command
+func NewClusterListCommand(environment string) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "cluster", "list", "--environment", environment, "-o", "json"},
+	}
+
+	return command
+}
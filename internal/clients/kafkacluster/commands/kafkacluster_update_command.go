@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+	"strconv"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewClusterUpdateCommand is a factory method for kafka cluster update command
+func NewClusterUpdateCommand(id string, environment string, cku int) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "cluster", "update", id, "--environment", environment, "--cku", strconv.Itoa(cku)},
+	}
+
+	return command
+}
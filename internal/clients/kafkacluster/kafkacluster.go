@@ -0,0 +1,136 @@
+package kafkacluster
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster/commands"
+)
+
+// Errors
+const (
+	errUnknown       = "unknown error"
+	errAmbiguousName = "environment %s has %d kafka clusters named %s, set the ID of one of them as external name to adopt it"
+	ErrNotExists     = "kafka cluster does not exist"
+)
+
+// ErrNotFound is returned when a kafka cluster does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for kafka cluster client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// ClusterCreate Executes Confluent CLI command to create a kafka cluster in an environment of Confluent Cloud & return the created Cluster.
+// An empty availability or type & a cku of 0 leave the default of Confluent
+func (c *Client) ClusterCreate(ctx context.Context, name string, environment string, cloud string, region string, availability string, clusterType string, cku int) (Cluster, error) {
+	var resp Cluster
+
+	cmd := commands.NewClusterCreateCommand(name, environment, cloud, region, availability, clusterType, cku)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// ClusterDescribe Executes Confluent CLI command to retrieve a kafka cluster by id from Confluent Cloud
+func (c *Client) ClusterDescribe(ctx context.Context, id string, environment string) (Cluster, error) {
+	var resp Cluster
+
+	cmd := commands.NewClusterDescribeCommand(id, environment)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// ClusterByName Executes Confluent CLI command to list the kafka clusters of an environment & return the cluster of name. Names are not unique
+// in Confluent Cloud, so several clusters of name are refused rather than picking one of them
+func (c *Client) ClusterByName(ctx context.Context, name string, environment string) (Cluster, error) {
+	cmd := commands.NewClusterListCommand(environment)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return Cluster{}, errorParser(out)
+	}
+
+	var resp []Cluster
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return Cluster{}, err
+	}
+
+	var found []Cluster
+	for _, v := range resp {
+		if v.Name == name {
+			found = append(found, v)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Cluster{}, ErrNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return Cluster{}, errors.Errorf(errAmbiguousName, environment, len(found), name)
+	}
+}
+
+// ClusterUpdate Executes Confluent CLI command to expand or shrink a dedicated kafka cluster to cku Confluent units
+func (c *Client) ClusterUpdate(ctx context.Context, id string, environment string, cku int) error {
+	cmd := commands.NewClusterUpdateCommand(id, environment, cku)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// ClusterDelete Executes Confluent CLI command to delete a kafka cluster from Confluent Cloud
+func (c *Client) ClusterDelete(ctx context.Context, id string, environment string) error {
+	cmd := commands.NewClusterDeleteCommand(id, environment)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,48 @@
+package kafkacluster
+
+import (
+	"context"
+	"strings"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for kafka cluster client
+type IClient interface {
+	ClusterCreate(ctx context.Context, name string, environment string, cloud string, region string, availability string, clusterType string, cku int) (Cluster, error)
+	ClusterDescribe(ctx context.Context, id string, environment string) (Cluster, error)
+	ClusterByName(ctx context.Context, name string, environment string) (Cluster, error)
+	ClusterUpdate(ctx context.Context, id string, environment string, cku int) error
+	ClusterDelete(ctx context.Context, id string, environment string) error
+}
+
+// Config is a configuration element for the kafka cluster client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for kafka cluster client
+type Client struct {
+	Config Config
+}
+
+// Cluster is a struct used for deserialising the response of ClusterCreate, ClusterDescribe & ClusterByName
+type Cluster struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Type         string `json:"type"`
+	ClusterSize  int    `json:"cluster_size"`
+	Availability string `json:"availability"`
+	Provider     string `json:"provider"`
+	Region       string `json:"region"`
+	Status       string `json:"status"`
+	Endpoint     string `json:"endpoint"`
+}
+
+// Bootstrap Returns the bootstrap endpoint of the cluster without the protocol Confluent prefixes it with, e.g. SASL_SSL://
+func (c Cluster) Bootstrap() string {
+	if i := strings.Index(c.Endpoint, "://"); i >= 0 {
+		return c.Endpoint[i+3:]
+	}
+	return c.Endpoint
+}
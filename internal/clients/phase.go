@@ -0,0 +1,55 @@
+package clients
+
+import (
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Phase is the lifecycle phase of an asynchronously provisioned Confluent resource, normalized across resource types
+type Phase string
+
+// Normalized phases
+const (
+	PhaseProvisioning Phase = "PROVISIONING"
+	PhaseReady        Phase = "READY"
+	PhaseFailed       Phase = "FAILED"
+)
+
+// phases maps the phases reported by Confluent Cloud to their normalized phase. Phases not listed are still provisioning
+var phases = map[string]Phase{
+	"PROVISIONING": PhaseProvisioning,
+	"PENDING":      PhaseProvisioning,
+	"READY":        PhaseReady,
+	"PROVISIONED":  PhaseReady,
+	"RUNNING":      PhaseReady,
+	"COMPLETED":    PhaseReady,
+	"UP":           PhaseReady,
+	"FAILED":       PhaseFailed,
+	"STOPPED":      PhaseFailed,
+	"SUSPENDED":    PhaseFailed,
+}
+
+// NormalizePhase Returns the normalized phase of a phase reported by Confluent Cloud, regardless of case & surrounding whitespace
+func NormalizePhase(phase string) Phase {
+	if p, ok := phases[strings.ToUpper(strings.TrimSpace(phase))]; ok {
+		return p
+	}
+	return PhaseProvisioning
+}
+
+// PhaseCondition Returns the Ready condition matching a phase reported by Confluent Cloud. A failed resource is reported as "<subject> is <phase>", followed by detail when known
+func PhaseCondition(phase string, subject string, detail string) xpv1.Condition {
+	switch NormalizePhase(phase) {
+	case PhaseReady:
+		return xpv1.Available()
+	case PhaseFailed:
+		msg := subject + " is " + strings.ToLower(strings.TrimSpace(phase))
+		if detail != "" {
+			msg += ": " + detail
+		}
+		return xpv1.Unavailable().WithMessage(msg)
+	default:
+		return xpv1.Creating()
+	}
+}
@@ -0,0 +1,47 @@
+package clients
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePhase(t *testing.T) {
+	assert := assert.New(t)
+
+	for phase, expected := range map[string]Phase{
+		"PROVISIONING": PhaseProvisioning,
+		"PENDING":      PhaseProvisioning,
+		"READY":        PhaseReady,
+		"PROVISIONED":  PhaseReady,
+		"RUNNING":      PhaseReady,
+		"COMPLETED":    PhaseReady,
+		"UP":           PhaseReady,
+		"FAILED":       PhaseFailed,
+		"STOPPED":      PhaseFailed,
+		"SUSPENDED":    PhaseFailed,
+		"running":      PhaseReady,
+		" Failed\n":    PhaseFailed,
+		"STOPPING":     PhaseProvisioning,
+		"":             PhaseProvisioning,
+	} {
+		assert.Equal(expected, NormalizePhase(phase), phase)
+	}
+}
+
+func TestPhaseCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(PhaseCondition("PROVISIONING", "cluster", "").Equal(xpv1.Creating()))
+	assert.True(PhaseCondition("", "cluster", "").Equal(xpv1.Creating()))
+	assert.True(PhaseCondition("PROVISIONED", "cluster", "").Equal(xpv1.Available()))
+	assert.True(PhaseCondition("ready", "cluster", "").Equal(xpv1.Available()))
+
+	failed := PhaseCondition("FAILED", "cluster", "quota exceeded")
+	assert.Equal(xpv1.TypeReady, failed.Type)
+	assert.Equal(xpv1.ReasonUnavailable, failed.Reason)
+	assert.Equal("cluster is failed: quota exceeded", failed.Message)
+
+	assert.Equal("cluster is suspended", PhaseCondition("SUSPENDED", "cluster", "").Message)
+}
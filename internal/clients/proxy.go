@@ -0,0 +1,87 @@
+package clients
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const errInvalidProxy = "proxy must be an absolute http, https or socks5 URL"
+
+// proxyEnvKeys are the environment variables the Confluent CLI takes its proxy from, like http.ProxyFromEnvironment. NO_PROXY is left as is,
+// so hosts excluded from the proxy of the provider stay excluded from a configured proxy
+var proxyEnvKeys = []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"}
+
+var (
+	proxyMu  sync.RWMutex
+	proxyURL string
+)
+
+// ParseProxy Parses & validates the URL of a proxy
+func ParseProxy(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, errors.Wrap(err, errInvalidProxy)
+	}
+
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.New(errInvalidProxy)
+	}
+	if u.Host == "" {
+		return nil, errors.New(errInvalidProxy)
+	}
+
+	return u, nil
+}
+
+// useProxy Makes the Confluent CLI connect through proxy, overriding the proxy environment variables of the provider. An empty proxy restores
+// them
+func useProxy(proxy string) error {
+	if proxy != "" {
+		if _, err := ParseProxy(proxy); err != nil {
+			return err
+		}
+	}
+
+	proxyMu.Lock()
+	defer proxyMu.Unlock()
+
+	proxyURL = proxy
+	return nil
+}
+
+// withProxy Returns env with the proxy variables replaced by the proxy in use. env is returned unchanged when no proxy is in use
+func withProxy(env []string) []string {
+	proxyMu.RLock()
+	proxy := proxyURL
+	proxyMu.RUnlock()
+
+	if proxy == "" {
+		return env
+	}
+
+	out := make([]string, 0, len(env)+len(proxyEnvKeys))
+	for _, kv := range env {
+		if !isProxyEnv(kv) {
+			out = append(out, kv)
+		}
+	}
+	for _, k := range proxyEnvKeys {
+		out = append(out, k+"="+proxy)
+	}
+
+	return out
+}
+
+func isProxyEnv(kv string) bool {
+	for _, k := range proxyEnvKeys {
+		if strings.HasPrefix(kv, k+"=") {
+			return true
+		}
+	}
+	return false
+}
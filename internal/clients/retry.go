@@ -0,0 +1,82 @@
+package clients
+
+import (
+	"context"
+	"math/rand"
+	"os/exec"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxRetryDelay caps the delay between two attempts, however many retries are allowed
+const maxRetryDelay = 10 * time.Second
+
+var (
+	retrySleep  = sleep
+	retryJitter = rand.Int63n
+)
+
+// RetryPolicy retries failed Confluent CLI commands with exponential backoff & full jitter: retry n waits a random time below
+// BaseDelay * 2^n. Only timeouts & transient failures, as classified by CommandError, are retried. A zero MaxRetries runs a command once, a
+// zero BaseDelay retries without waiting
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// ExecuteCommandContext Executes cmd like ExecuteCommandContext, retrying it as allowed by the policy
+func (p RetryPolicy) ExecuteCommandContext(ctx context.Context, cmd exec.Cmd) ([]byte, error) {
+	return p.Do(ctx, func() ([]byte, error) {
+		return ExecuteCommandContext(ctx, cmd)
+	})
+}
+
+// Do Calls run until it succeeds, fails in a way that is not retryable, the retries are used up or ctx is done. The output & error of the
+// last call are returned
+func (p RetryPolicy) Do(ctx context.Context, run func() ([]byte, error)) ([]byte, error) {
+	out, err := run()
+	for retry := 0; err != nil && retry < p.MaxRetries && ctx.Err() == nil && retryable(out, err); retry++ {
+		if !retrySleep(ctx, p.delay(retry)) {
+			break
+		}
+		out, err = run()
+	}
+
+	return out, err
+}
+
+// sleep Waits for d, returning false when ctx is done first
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// delay Returns a random delay below BaseDelay * 2^retry, capped by maxRetryDelay
+func (p RetryPolicy) delay(retry int) time.Duration {
+	if p.BaseDelay <= 0 {
+		return 0
+	}
+
+	backoff := p.BaseDelay
+	for i := 0; i < retry && backoff < maxRetryDelay; i++ {
+		backoff *= 2
+	}
+	if backoff > maxRetryDelay {
+		backoff = maxRetryDelay
+	}
+
+	return time.Duration(retryJitter(int64(backoff)))
+}
+
+// retryable Reports whether a command that failed with out & err timed out or failed transiently, as classified by CommandError
+func retryable(out []byte, err error) bool {
+	return errors.Is(err, context.DeadlineExceeded) || IsTransient(CommandError(out))
+}
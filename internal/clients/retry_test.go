@@ -0,0 +1,129 @@
+package clients
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryable(t *testing.T) {
+	errFailed := errors.New("exit status 1")
+
+	tests := []struct {
+		name      string
+		out       string
+		err       error
+		retryable bool
+	}{
+		{name: "429", out: `{"errors":[{"status":"429","detail":"Too Many Requests"}]}`, err: errFailed, retryable: true},
+		{name: "500", out: `{"errors":[{"status":"500","detail":"Internal Server Error"}]}`, err: errFailed, retryable: true},
+		{name: "502", out: `{"errors":[{"status":"502","detail":"Bad Gateway"}]}`, err: errFailed, retryable: true},
+		{name: "503", out: `{"error_code":50301,"message":"Service unavailable"}`, err: errFailed, retryable: true},
+		{name: "504", out: `{"errors":[{"status":"504","detail":"Gateway Timeout"}]}`, err: errFailed, retryable: true},
+		{name: "501", out: `{"errors":[{"status":"501","detail":"Not Implemented"}]}`, err: errFailed, retryable: true},
+		{name: "connection reset without status", out: "Error: read tcp: connection reset by peer", err: errFailed, retryable: true},
+		{name: "400 mentioning a timeout", out: `{"errors":[{"status":"400","detail":"Invalid value for request.timeout.ms"}]}`, err: errFailed},
+		{name: "404", out: `{"errors":[{"status":"404","detail":"Not Found"}]}`, err: errFailed},
+		{name: "timeout without status", out: "Error: net/http: request canceled (Client.Timeout exceeded)", err: errFailed, retryable: true},
+		{name: "killed by the command timeout", err: errors.Wrap(context.DeadlineExceeded, "confluent cli command did not finish within 1m0s"), retryable: true},
+		{name: "plain error", out: "Error: unknown flag: --ulla", err: errFailed},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.retryable, retryable([]byte(tc.out), tc.err))
+		})
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	assert := assert.New(t)
+
+	var delays []time.Duration
+	retrySleep = func(_ context.Context, d time.Duration) bool {
+		delays = append(delays, d)
+		return true
+	}
+	retryJitter = func(n int64) int64 { return n - 1 }
+	defer func() {
+		retrySleep = sleep
+		retryJitter = rand.Int63n
+	}()
+
+	unavailable := []byte(`{"errors":[{"status":"503","detail":"Service Unavailable"}]}`)
+	failing := func(failures int, out []byte) (func() ([]byte, error), *int) {
+		calls := 0
+		return func() ([]byte, error) {
+			calls++
+			if calls <= failures {
+				return out, errors.New("exit status 1")
+			}
+			return []byte("ok"), nil
+		}, &calls
+	}
+
+	// Retryable failures are retried with a doubling delay until the command succeeds
+	p := RetryPolicy{MaxRetries: 3, BaseDelay: 100 * time.Millisecond}
+	run, calls := failing(2, unavailable)
+	out, err := p.Do(context.Background(), run)
+	assert.NoError(err)
+	assert.Equal("ok", string(out))
+	assert.Equal(3, *calls)
+	assert.Equal([]time.Duration{100*time.Millisecond - 1, 200*time.Millisecond - 1}, delays)
+
+	// The last failure is returned once the retries are used up
+	delays = nil
+	run, calls = failing(10, unavailable)
+	out, err = p.Do(context.Background(), run)
+	assert.Error(err)
+	assert.Equal(unavailable, out)
+	assert.Equal(4, *calls)
+	assert.Len(delays, 3)
+
+	// Failures that are not retryable are returned right away
+	delays = nil
+	run, calls = failing(1, []byte(`{"errors":[{"status":"404","detail":"Not Found"}]}`))
+	_, err = p.Do(context.Background(), run)
+	assert.Error(err)
+	assert.Equal(1, *calls)
+	assert.Empty(delays)
+
+	// A zero policy runs the command once
+	run, calls = failing(1, unavailable)
+	_, err = RetryPolicy{}.Do(context.Background(), run)
+	assert.Error(err)
+	assert.Equal(1, *calls)
+
+	// A zero base delay retries without waiting
+	delays = nil
+	run, calls = failing(2, unavailable)
+	_, err = RetryPolicy{MaxRetries: 2}.Do(context.Background(), run)
+	assert.NoError(err)
+	assert.Equal(3, *calls)
+	assert.Equal([]time.Duration{0, 0}, delays)
+
+	// Nothing is retried once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	run, calls = failing(2, unavailable)
+	_, err = p.Do(ctx, run)
+	assert.Error(err)
+	assert.Equal(1, *calls)
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	assert := assert.New(t)
+
+	retryJitter = func(n int64) int64 { return n }
+	defer func() { retryJitter = rand.Int63n }()
+
+	p := RetryPolicy{BaseDelay: time.Second}
+	assert.Equal(time.Second, p.delay(0))
+	assert.Equal(8*time.Second, p.delay(3))
+	assert.Equal(maxRetryDelay, p.delay(4))
+	assert.Equal(maxRetryDelay, p.delay(100))
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewRoleBindingCreateCommand is a factory method for RoleBinding create command
+func NewRoleBindingCreateCommand(principal string, role string, environment string, cluster string) exec.Cmd {
+	args := append([]string{"iam", "rbac", "role-binding", "create", "--principal", principal, "--role", role}, scopeArgs(environment, cluster)...)
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
@@ -0,0 +1,19 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewRoleBindingDeleteCommand is a factory method for RoleBinding delete command
+func NewRoleBindingDeleteCommand(principal string, role string, environment string, cluster string) exec.Cmd {
+	args := append([]string{"iam", "rbac", "role-binding", "delete", "--principal", principal, "--role", role}, scopeArgs(environment, cluster)...)
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "--force"),
+	}
+
+	return command
+}
@@ -0,0 +1,20 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewRoleBindingListCommand is a factory method for RoleBinding list command. Only the role bindings of principal at exactly the given scope
+// are listed
+func NewRoleBindingListCommand(principal string, environment string, cluster string) exec.Cmd {
+	args := append([]string{"iam", "rbac", "role-binding", "list", "--principal", principal}, scopeArgs(environment, cluster)...)
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append(args, "-o", "json"),
+	}
+
+	return command
+}
@@ -0,0 +1,13 @@
+package commands
+
+// scopeArgs Returns the flags scoping a role binding command to an environment or cluster. No flags scope it to the organization
+func scopeArgs(environment string, cluster string) []string {
+	var args []string
+	if environment != "" {
+		args = append(args, "--environment", environment)
+	}
+	if cluster != "" {
+		args = append(args, "--cloud-cluster", cluster)
+	}
+	return args
+}
@@ -0,0 +1,115 @@
+package rolebinding
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/rolebinding/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "role binding does not exist"
+	ErrInvalidInput = "input given may be invalid like a role that cannot be bound in the given scope"
+)
+
+// ErrNotFound is returned when a role binding does not exist in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// Roles are the predefined roles of Confluent Cloud
+var Roles = []string{
+	"OrganizationAdmin",
+	"AccountAdmin",
+	"BillingAdmin",
+	"MetricsViewer",
+	"EnvironmentAdmin",
+	"DataDiscovery",
+	"DataSteward",
+	"FlinkAdmin",
+	"FlinkDeveloper",
+	"CloudClusterAdmin",
+	"Operator",
+	"ResourceKeyAdmin",
+	"DeveloperRead",
+	"DeveloperWrite",
+	"DeveloperManage",
+	"ResourceOwner",
+}
+
+// IsKnownRole reports whether role is one of Roles
+func IsKnownRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// NewClient is a factory method for role binding client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// RoleBindingList Executes Confluent CLI command to list the role bindings of a principal bound at exactly scope s in Confluent Cloud
+func (c *Client) RoleBindingList(ctx context.Context, principal string, s Scope) ([]RoleBinding, error) {
+	var resp []RoleBinding
+
+	cmd := commands.NewRoleBindingListCommand(principal, s.Environment, s.Cluster)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// RoleBindingCreate Executes Confluent CLI command to bind a role to a principal in Confluent Cloud
+func (c *Client) RoleBindingCreate(ctx context.Context, rb RoleBinding) error {
+	cmd := commands.NewRoleBindingCreateCommand(rb.Principal, rb.Role, rb.Environment, rb.Cluster)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// RoleBindingDelete Executes Confluent CLI command to remove a role binding from Confluent Cloud
+func (c *Client) RoleBindingDelete(ctx context.Context, rb RoleBinding) error {
+	cmd := commands.NewRoleBindingDeleteCommand(rb.Principal, rb.Role, rb.Environment, rb.Cluster)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := strings.ToLower(string(cmdout))
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "invalid") {
+		return errors.Wrap(clients.CommandError(cmdout), ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,42 @@
+package rolebinding
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for role binding client
+type IClient interface {
+	RoleBindingList(ctx context.Context, principal string, s Scope) ([]RoleBinding, error)
+	RoleBindingCreate(ctx context.Context, rb RoleBinding) error
+	RoleBindingDelete(ctx context.Context, rb RoleBinding) error
+}
+
+// Config is a configuration element for the role binding client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for role binding client
+type Client struct {
+	Config Config
+}
+
+// Scope is the organization, an environment or a cluster of an environment that roles are bound in. The zero Scope is the organization
+type Scope struct {
+	Environment string
+	Cluster     string
+}
+
+// RoleBinding is a struct used for deserialising the response of RoleBindingList
+type RoleBinding struct {
+	Principal   string `json:"principal"`
+	Role        string `json:"role"`
+	Environment string `json:"environment"`
+	Cluster     string `json:"cloud_cluster"`
+}
+
+// Scope Returns the scope rb is bound in
+func (rb RoleBinding) Scope() Scope {
+	return Scope{Environment: rb.Environment, Cluster: rb.Cluster}
+}
@@ -1,6 +1,7 @@
 package schemaregistry
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os/exec"
@@ -29,7 +30,7 @@ func NewClient(c Config) IClient {
 }
 
 // SchemaCreate creates a schema in the schemaregistry
-func (c *Client) SchemaCreate(subject string, schema string, schemaType string, environment string) (string, error) {
+func (c *Client) SchemaCreate(ctx context.Context, subject string, schema string, schemaType string, environment string) (string, error) {
 	schemaGUID := uuid.New().String()
 
 	path, err := CreateFile([]byte(schema), schemaGUID, c.Config.SchemaPath)
@@ -39,7 +40,7 @@ func (c *Client) SchemaCreate(subject string, schema string, schemaType string,
 	}
 
 	var cmd = commands.NewSchemaCreateCommand(subject, path, schemaType, environment, c.Config.APICredentials.Key, c.Config.APICredentials.Secret)
-	var cmdOutput, cmdErr = clients.ExecuteCommand(exec.Cmd(cmd))
+	var cmdOutput, cmdErr = clients.ExecuteCommandContext(ctx, exec.Cmd(cmd))
 
 	err = RemoveFile(path) // TODO: consider implementing with defer
 
@@ -56,17 +57,17 @@ func (c *Client) SchemaCreate(subject string, schema string, schemaType string,
 }
 
 // SchemaDelete deletes a schema in the schemaregistry
-func (c *Client) SchemaDelete(subject string, version string, permanent bool, environment string) (string, error) {
+func (c *Client) SchemaDelete(ctx context.Context, subject string, version string, permanent bool, environment string) (string, error) {
 	var cmd = commands.NewSchemaDeleteCommand(subject, version, permanent, environment, c.Config.APICredentials.Key, c.Config.APICredentials.Secret)
-	var cmdOutput, cmdErr = clients.ExecuteCommand(exec.Cmd(cmd))
+	var cmdOutput, cmdErr = clients.ExecuteCommandContext(ctx, exec.Cmd(cmd))
 
 	return string(cmdOutput), cmdErr
 }
 
 // SchemaDescribe gets a schema in the schemaregistry
-func (c *Client) SchemaDescribe(subject string, version string, environment string) (SchemaDescribeResponse, error) {
+func (c *Client) SchemaDescribe(ctx context.Context, subject string, version string, environment string) (SchemaDescribeResponse, error) {
 	var cmd = commands.NewSchemaDescribeCommand(subject, version, environment, c.Config.APICredentials.Key, c.Config.APICredentials.Secret)
-	var cmdOutput, err = clients.ExecuteCommand(exec.Cmd(cmd))
+	var cmdOutput, err = clients.ExecuteCommandContext(ctx, exec.Cmd(cmd))
 	var schema SchemaDescribeResponse
 
 	if err != nil {
@@ -86,9 +87,9 @@ func (c *Client) SchemaDescribe(subject string, version string, environment stri
 }
 
 // SchemaSubjectUpdateCommand Executes Confluent CLI command to update a Schema in Confluent Cloud
-func (c *Client) SchemaSubjectUpdateCommand(subject string, compatibility string, environment string) (string, error) {
+func (c *Client) SchemaSubjectUpdateCommand(ctx context.Context, subject string, compatibility string, environment string) (string, error) {
 	var cmd = commands.NewSchemaSubjectUpdateCommand(subject, compatibility, environment, c.Config.APICredentials.Key, c.Config.APICredentials.Secret)
-	cmdOutput, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	cmdOutput, err := clients.ExecuteCommandContext(ctx, exec.Cmd(cmd))
 
 	if err != nil {
 		return string(cmdOutput), errorParser(cmdOutput)
@@ -113,9 +114,7 @@ func errorParser(cmdout []byte) error {
 
 	err = json.Unmarshal([]byte(split[1]), &schema)
 	if err != nil {
-		message := fmt.Sprintf("%s%v", errGeneral, split)
-		err = errors.Wrap(err, message)
-		return err
+		return errors.Wrap(clients.CommandError(cmdout), strings.TrimSuffix(errGeneral, ":"))
 	}
 
 	switch schema.ErrorCode {
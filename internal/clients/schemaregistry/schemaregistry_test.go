@@ -2,6 +2,7 @@
 package schemaregistry
 
 import (
+	"context"
 	"log"
 	"testing"
 
@@ -114,7 +115,7 @@ func TestClientSchemaCreate(t *testing.T) {
 	clients.SkipCI(t)
 	client := NewClient(testConfig)
 
-	resp, err := client.SchemaCreate("provider-confluent-testclientcreate", testSchema, "AVRO", "env-zvzz7")
+	resp, err := client.SchemaCreate(context.Background(), "provider-confluent-testclientcreate", testSchema, "AVRO", "env-zvzz7")
 
 	if err != nil {
 		log.Println(resp)
@@ -122,7 +123,7 @@ func TestClientSchemaCreate(t *testing.T) {
 	}
 
 	// Teardown
-	_, err = client.SchemaDelete("provider-confluent-testclientcreate", "all", false, "env-zvzz7")
+	_, err = client.SchemaDelete(context.Background(), "provider-confluent-testclientcreate", "all", false, "env-zvzz7")
 
 	if err != nil {
 		t.Errorf(err.Error())
@@ -135,13 +136,13 @@ func TestClientSchemaDelete(t *testing.T) {
 	clients.SkipCI(t)
 	client := NewClient(testConfig)
 
-	respCreate, err := client.SchemaCreate("provider-confluent-testclientdelete", testSchema, "AVRO", "env-zvzz7")
+	respCreate, err := client.SchemaCreate(context.Background(), "provider-confluent-testclientdelete", testSchema, "AVRO", "env-zvzz7")
 	if err != nil {
 		log.Println(respCreate)
 		t.Errorf(err.Error())
 	}
 
-	resp, err := client.SchemaDelete("provider-confluent-testclientdelete", "all", false, "env-zvzz7")
+	resp, err := client.SchemaDelete(context.Background(), "provider-confluent-testclientdelete", "all", false, "env-zvzz7")
 	if err != nil {
 		log.Println(resp)
 		t.Errorf(err.Error())
@@ -154,20 +155,20 @@ func TestClientSchemaDescribe(t *testing.T) {
 	clients.SkipCI(t)
 	client := NewClient(testConfig)
 
-	respCreate, err := client.SchemaCreate("provider-confluent-testclientdescribe", testSchema, "AVRO", "env-zvzz7")
+	respCreate, err := client.SchemaCreate(context.Background(), "provider-confluent-testclientdescribe", testSchema, "AVRO", "env-zvzz7")
 	if err != nil {
 		log.Println(respCreate)
 		t.Errorf(err.Error())
 	}
 
-	resp, err := client.SchemaDescribe("provider-confluent-testclientdescribe", "latest", "env-zvzz7")
+	resp, err := client.SchemaDescribe(context.Background(), "provider-confluent-testclientdescribe", "latest", "env-zvzz7")
 	if err != nil {
 		log.Println(resp)
 		t.Errorf(err.Error())
 	}
 
 	// Teardown
-	_, err = client.SchemaDelete("provider-confluent-testclientdescribe", "all", false, "env-zvzz7")
+	_, err = client.SchemaDelete(context.Background(), "provider-confluent-testclientdescribe", "all", false, "env-zvzz7")
 	if err != nil {
 		t.Errorf(err.Error())
 	}
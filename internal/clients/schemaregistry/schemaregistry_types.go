@@ -1,13 +1,16 @@
 package schemaregistry
 
-import "github.com/dfds/provider-confluent/internal/clients"
+import (
+	"context"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
 
 // IClient interface for schemaregistry client
 type IClient interface {
-	SchemaCreate(subject string, schema string, schemaType string, environment string) (string, error)
-	SchemaDelete(subject string, version string, permanent bool, environment string) (string, error)
-	SchemaDescribe(subject string, version string, environment string) (SchemaDescribeResponse, error)
-	SchemaSubjectUpdateCommand(subject string, compatibility string, environment string) (string, error)
+	SchemaCreate(ctx context.Context, subject string, schema string, schemaType string, environment string) (string, error)
+	SchemaDelete(ctx context.Context, subject string, version string, permanent bool, environment string) (string, error)
+	SchemaDescribe(ctx context.Context, subject string, version string, environment string) (SchemaDescribeResponse, error)
+	SchemaSubjectUpdateCommand(ctx context.Context, subject string, compatibility string, environment string) (string, error)
 }
 
 // Config is a configuration element for the schema registry client
@@ -1,9 +1,11 @@
 package serviceaccount
 
 import (
+	"context"
 	"encoding/json"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -16,9 +18,25 @@ const (
 	ErrAlreadyInUse       = "service account already in use"
 	ErrDescriptionTooLong = "service account description exceed 128 characters"
 	ErrNameTooLong        = "service account name exceed 64 characters"
+	ErrNotAuthorized      = "not authorized to manage service accounts"
 	ErrNotExists          = "service account does not exists"
 )
 
+var (
+	// ErrNotFound is returned when a service account does not exist in Confluent Cloud
+	ErrNotFound = errors.New(ErrNotExists)
+	// ErrAlreadyExists is returned when a service account of the same name exists in Confluent Cloud already
+	ErrAlreadyExists = errors.New(ErrAlreadyInUse)
+	// ErrUnauthorized is returned when Confluent Cloud rejects the credentials, like a 401 response
+	ErrUnauthorized = errors.New(ErrNotAuthorized)
+)
+
+// Defaults of the retry policy of the controllers
+const (
+	DefaultMaxRetries = 3
+	DefaultBaseDelay  = 250 * time.Millisecond
+)
+
 const (
 	nameMaxLength        = 64
 	descriptionMaxLength = 128
@@ -29,8 +47,14 @@ func NewClient(c Config) IClient {
 	return &Client{Config: c}
 }
 
+// execute Executes a Confluent CLI command, retrying it as configured until ctx is done
+func (c *Client) execute(ctx context.Context, cmd exec.Cmd) ([]byte, error) {
+	policy := clients.RetryPolicy{MaxRetries: c.Config.MaxRetries, BaseDelay: c.Config.BaseDelay}
+	return policy.ExecuteCommandContext(ctx, cmd)
+}
+
 // ServiceAccountCreate Executes Confluent CLI command to create ServiceAccount in Confluent Cloud & return a ServiceAccount object
-func (c *Client) ServiceAccountCreate(name string, description string) (ServiceAccount, error) {
+func (c *Client) ServiceAccountCreate(ctx context.Context, name string, description string) (ServiceAccount, error) {
 	var resp ServiceAccount
 
 	// TODO: consider hitting the API and then handling the error
@@ -43,13 +67,10 @@ func (c *Client) ServiceAccountCreate(name string, description string) (ServiceA
 	}
 
 	var cmd = commands.NewServiceAccountCreateCommand(name, description)
-	out, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	out, err := c.execute(ctx, exec.Cmd(cmd))
 
 	if err != nil {
-		if strings.Contains(string(out), "Service name is already in use") {
-			return resp, errors.New(ErrAlreadyInUse)
-		}
-		return resp, errors.Wrap(err, string(out))
+		return resp, commandError(out, err)
 	}
 
 	err = json.Unmarshal(out, &resp)
@@ -61,12 +82,12 @@ func (c *Client) ServiceAccountCreate(name string, description string) (ServiceA
 }
 
 // ServiceAccountList Executes Confluent CLI command to list all ServiceAccounts in Confluent Cloud & return a slice of ServiceAccount objects
-func (c *Client) ServiceAccountList() ([]ServiceAccount, error) {
+func (c *Client) ServiceAccountList(ctx context.Context) ([]ServiceAccount, error) {
 	var cmd = commands.NewServiceAccountListCommand()
-	out, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	out, err := c.execute(ctx, exec.Cmd(cmd))
 
 	if err != nil {
-		return []ServiceAccount{}, errors.Wrap(err, string(out))
+		return []ServiceAccount{}, commandError(out, err)
 	}
 
 	var resp List
@@ -75,16 +96,16 @@ func (c *Client) ServiceAccountList() ([]ServiceAccount, error) {
 		return []ServiceAccount{}, err
 	}
 
-	return resp, errors.New(ErrNotExists)
+	return resp, nil
 }
 
 // ServiceAccountByID Executes Confluent CLI command to list all ServiceAccounts in Confluent Cloud, filter by id & return a non-empty ServiceAccount object if found
-func (c *Client) ServiceAccountByID(id string) (ServiceAccount, error) {
+func (c *Client) ServiceAccountByID(ctx context.Context, id string) (ServiceAccount, error) {
 	var cmd = commands.NewServiceAccountListCommand()
-	out, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	out, err := c.execute(ctx, exec.Cmd(cmd))
 
 	if err != nil {
-		return ServiceAccount{}, errors.Wrap(err, string(out))
+		return ServiceAccount{}, commandError(out, err)
 	}
 
 	var resp List
@@ -99,16 +120,16 @@ func (c *Client) ServiceAccountByID(id string) (ServiceAccount, error) {
 		}
 	}
 
-	return ServiceAccount{}, errors.New(ErrNotExists)
+	return ServiceAccount{}, ErrNotFound
 }
 
-// ServiceAccountByName Executes Confluent CLI command to list all ServiceAccounts in Confluent Cloud, filter by name & return a non-empty ServiceAccount object if found
-func (c *Client) ServiceAccountByName(name string) (ServiceAccount, error) {
+// ServiceAccountByName Executes Confluent CLI command to list all ServiceAccounts in Confluent Cloud, filter by name & return a non-empty ServiceAccount object if found. Names are matched case-sensitively
+func (c *Client) ServiceAccountByName(ctx context.Context, name string) (ServiceAccount, error) {
 	var cmd = commands.NewServiceAccountListCommand()
-	out, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	out, err := c.execute(ctx, exec.Cmd(cmd))
 
 	if err != nil {
-		return ServiceAccount{}, errors.Wrap(err, string(out))
+		return ServiceAccount{}, commandError(out, err)
 	}
 
 	var resp List
@@ -117,49 +138,114 @@ func (c *Client) ServiceAccountByName(name string) (ServiceAccount, error) {
 		return ServiceAccount{}, err
 	}
 
-	for _, v := range resp {
-		if strings.EqualFold(v.Name, name) {
+	return findByName(resp, name)
+}
+
+// findByName Returns the service account of list named exactly name. Confluent keeps display names as given & compares them case-sensitively, so MyAccount never matches myaccount
+func findByName(list List, name string) (ServiceAccount, error) {
+	for _, v := range list {
+		if v.Name == name {
 			return v, nil
 		}
 	}
 
-	return ServiceAccount{}, errors.New(ErrNotExists)
+	return ServiceAccount{}, ErrNotFound
 }
 
 // ServiceAccountUpdate Executes Confluent CLI command to update the description of a ServiceAccount in Confluent Cloud
-func (c *Client) ServiceAccountUpdate(id string, description string) error {
+func (c *Client) ServiceAccountUpdate(ctx context.Context, id string, description string) error {
 	// TODO: consider hitting the API and then handling the error
 	if isDescriptionValid(description) {
 		return errors.New(ErrDescriptionTooLong)
 	}
 
 	var cmd = commands.NewServiceAccountUpdateCommand(id, description)
-	out, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	out, err := c.execute(ctx, exec.Cmd(cmd))
 
 	if err != nil {
-		if strings.Contains(string(out), "Service Account Not Found") {
-			return errors.New(ErrNotExists)
-		}
-		return errors.Wrap(err, string(out))
+		return commandError(out, err)
 	}
 
 	return nil
 }
 
 // ServiceAccountDelete Executes Confluent CLI command to delete a ServiceAccount in Confluent Cloud
-func (c *Client) ServiceAccountDelete(id string) error {
+func (c *Client) ServiceAccountDelete(ctx context.Context, id string) error {
 	var cmd = commands.NewServiceAccountDeleteCommand(id)
-	out, err := clients.ExecuteCommand(exec.Cmd(cmd))
+	out, err := c.execute(ctx, exec.Cmd(cmd))
 
 	if err != nil {
-		if strings.Contains(string(out), "error deleting service account: Forbidden") {
-			return errors.New(ErrNotExists)
+		cmdErr := commandError(out, err)
+
+		// The CLI may report deleting an unknown service account as forbidden, so a denied delete is only taken as not found once the service
+		// account is confirmed gone. Otherwise the permission is missing & the service account is left in place
+		if clients.IsForbidden(cmdErr) {
+			if _, lookupErr := c.ServiceAccountByID(ctx, id); IsNotFound(lookupErr) {
+				return &sentinelError{sentinel: ErrNotFound, err: cmdErr}
+			}
 		}
-		return errors.Wrap(err, string(out))
+		return cmdErr
 	}
 	return nil
 }
 
+// IsNotFound reports whether err indicates that a service account does not exist
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsAlreadyExists reports whether err indicates that a service account of the same name exists already
+func IsAlreadyExists(err error) bool {
+	return errors.Is(err, ErrAlreadyExists)
+}
+
+// IsUnauthorized reports whether err indicates that Confluent Cloud rejected the credentials
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// commandError Returns the error of a failed Confluent CLI command. Statuses & output with a known meaning are returned as one of ErrNotFound,
+// ErrAlreadyExists or ErrUnauthorized, still wrapping the clients.Error of the command
+func commandError(out []byte, err error) error {
+	cmdErr := errors.Wrap(clients.CommandError(out), err.Error())
+
+	var e *clients.Error
+	if !errors.As(cmdErr, &e) {
+		return cmdErr
+	}
+
+	output := strings.ToLower(e.Output)
+	switch {
+	case e.Status == "404" || strings.Contains(output, "not found"):
+		return &sentinelError{sentinel: ErrNotFound, err: cmdErr}
+	case e.Status == "409" || strings.Contains(output, "already in use"):
+		return &sentinelError{sentinel: ErrAlreadyExists, err: cmdErr}
+	case e.Status == "401" || strings.Contains(output, "unauthorized"):
+		return &sentinelError{sentinel: ErrUnauthorized, err: cmdErr}
+	}
+	return cmdErr
+}
+
+// sentinelError is a failed Confluent CLI command that matches one of the sentinel errors of this package with errors.Is
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+func (e *sentinelError) Error() string {
+	return e.sentinel.Error() + ": " + e.err.Error()
+}
+
+// Unwrap Returns the error of the command
+func (e *sentinelError) Unwrap() error {
+	return e.err
+}
+
+// Is Reports whether target is the sentinel error matched by the command
+func (e *sentinelError) Is(target error) bool {
+	return target == e.sentinel
+}
+
 func isDescriptionValid(description string) bool {
 	return len(description) > descriptionMaxLength
 }
@@ -1,10 +1,16 @@
 package serviceaccount
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
-	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/internal/clients"
 )
 
 var (
@@ -19,36 +25,127 @@ var (
 func TestServiceAccountLifecycle(t *testing.T) {
 	clients.SkipCI(t)
 	assert := assert.New(t)
+	ctx := context.Background()
 
-	_, err := client.ServiceAccountByName("")
+	_, err := client.ServiceAccountByName(ctx, "")
 	if err != nil {
-		assert.Equal(err.Error(), ErrNotExists)
+		assert.True(IsNotFound(err))
 	} else {
 		t.Errorf("getting an empty service account should produce error")
 	}
 
-	resp, err := client.ServiceAccountCreate(serviceAccount, description)
+	resp, err := client.ServiceAccountCreate(ctx, serviceAccount, description)
 	if err != nil {
 		t.Errorf("service account creation not working with error: %s", err.Error())
 	}
 
-	_, err = client.ServiceAccountByName(resp.Name)
+	_, err = client.ServiceAccountByName(ctx, resp.Name)
 	if err != nil {
 		t.Errorf("could not get already created service account by name")
 	}
 
-	_, err = client.ServiceAccountByID(resp.ID)
+	_, err = client.ServiceAccountByID(ctx, resp.ID)
 	if err != nil {
 		t.Errorf("could not get already created service account by id")
 	}
 
-	err = client.ServiceAccountUpdate(resp.ID, "crossplane-test-update")
+	err = client.ServiceAccountUpdate(ctx, resp.ID, "crossplane-test-update")
 	if err != nil {
 		t.Errorf("update does not work as indented")
 	}
 
-	err = client.ServiceAccountDelete(resp.ID)
+	err = client.ServiceAccountDelete(ctx, resp.ID)
 	if err != nil {
 		t.Errorf("delete does not work as indented")
 	}
 }
+
+func TestFindByNameIsCaseSensitive(t *testing.T) {
+	assert := assert.New(t)
+
+	list := List{
+		{ID: "sa-11111", Name: "myaccount"},
+		{ID: "sa-22222", Name: "MyAccount"},
+	}
+
+	sa, err := findByName(list, "MyAccount")
+	assert.NoError(err)
+	assert.Equal("sa-22222", sa.ID)
+
+	sa, err = findByName(list, "myaccount")
+	assert.NoError(err)
+	assert.Equal("sa-11111", sa.ID)
+
+	_, err = findByName(list[:1], "MyAccount")
+	assert.True(IsNotFound(err), "an account differing only in case must not be adopted")
+}
+
+func TestCommandError(t *testing.T) {
+	errFailed := errors.New("exit status 1")
+
+	tests := []struct {
+		name     string
+		out      string
+		sentinel error
+	}{
+		{name: "404", out: `{"errors":[{"status":"404","detail":"Not Found"}]}`, sentinel: ErrNotFound},
+		{name: "not found without status", out: "Error: Service Account Not Found", sentinel: ErrNotFound},
+		{name: "409", out: `{"errors":[{"status":"409","detail":"Conflict"}]}`, sentinel: ErrAlreadyExists},
+		{name: "already in use without status", out: "Error: Service name is already in use", sentinel: ErrAlreadyExists},
+		{name: "401", out: `{"errors":[{"status":"401","detail":"Unauthorized"}]}`, sentinel: ErrUnauthorized},
+		{name: "unauthorized without status", out: "Error: 401 Unauthorized", sentinel: ErrUnauthorized},
+		{name: "other failure", out: `{"errors":[{"status":"400","detail":"Bad Request"}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			err := commandError([]byte(tc.out), errFailed)
+			for _, sentinel := range []error{ErrNotFound, ErrAlreadyExists, ErrUnauthorized} {
+				assert.Equal(sentinel == tc.sentinel, errors.Is(err, sentinel), sentinel.Error())
+			}
+
+			// The failed command is still reported
+			var e *clients.Error
+			assert.True(errors.As(err, &e))
+		})
+	}
+}
+
+// fakeCLI Puts a stand-in for the Confluent CLI on the PATH, which answers the list command with accounts & the delete command with a
+// Forbidden error. It returns a func restoring the PATH
+func fakeCLI(t *testing.T, accounts string) func() {
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"case \"$3\" in\n" +
+		"list) echo '" + accounts + "' ;;\n" +
+		"delete) echo 'Error: error deleting service account: Forbidden'; exit 1 ;;\n" +
+		"esac\n"
+	if err := ioutil.WriteFile(filepath.Join(dir, clients.CliName), []byte(script), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	path := os.Getenv("PATH")
+	os.Setenv("PATH", dir+string(os.PathListSeparator)+path) //nolint:errcheck
+	return func() { os.Setenv("PATH", path) }                //nolint:errcheck
+}
+
+func TestDeleteForbidden(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// A service account that is gone is reported as not found
+	restore := fakeCLI(t, `[{"id":"sa-22222","name":"other","description":""}]`)
+	err := client.ServiceAccountDelete(ctx, "sa-11111")
+	restore()
+	assert.True(IsNotFound(err), err)
+
+	// A service account that still exists was not deleted for lack of permission
+	restore = fakeCLI(t, `[{"id":"sa-11111","name":"existing","description":""}]`)
+	err = client.ServiceAccountDelete(ctx, "sa-11111")
+	restore()
+	assert.Error(err)
+	assert.False(IsNotFound(err))
+	assert.True(clients.IsForbidden(err))
+}
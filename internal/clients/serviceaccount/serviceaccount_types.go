@@ -1,20 +1,30 @@
 package serviceaccount
 
-import "github.com/dfds/provider-confluent/internal/clients"
+import (
+	"context"
+	"time"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
 
 // IClient interface for service account client
 type IClient interface {
-	ServiceAccountCreate(name string, description string) (ServiceAccount, error)
-	ServiceAccountDelete(id string) error
-	ServiceAccountList() ([]ServiceAccount, error)
-	ServiceAccountByName(name string) (ServiceAccount, error)
-	ServiceAccountByID(id string) (ServiceAccount, error)
-	ServiceAccountUpdate(id string, description string) error
+	ServiceAccountCreate(ctx context.Context, name string, description string) (ServiceAccount, error)
+	ServiceAccountDelete(ctx context.Context, id string) error
+	ServiceAccountList(ctx context.Context) ([]ServiceAccount, error)
+	ServiceAccountByName(ctx context.Context, name string) (ServiceAccount, error)
+	ServiceAccountByID(ctx context.Context, id string) (ServiceAccount, error)
+	ServiceAccountUpdate(ctx context.Context, id string, description string) error
 }
 
 // Config is a configuration element for the service account client
 type Config struct {
 	APICredentials clients.APICredentials
+
+	// MaxRetries is how often a command that timed out or was answered with a 429, 500, 502, 503 or 504 is retried. Zero disables retries
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, which doubles for every further retry & is jittered. Zero retries without waiting
+	BaseDelay time.Duration
 }
 
 // Client is a struct for service account client
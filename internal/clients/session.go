@@ -0,0 +1,94 @@
+package clients
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SessionTTL is how long a login of the Confluent CLI is reused by Authenticate before logging in again. It is kept well below the lifetime
+// of a Confluent Cloud session, so a reused login is never close to expiring. Zero logs in on every Authenticate
+var SessionTTL = 30 * time.Minute
+
+// expiredOutput are fragments of CLI output that indicate the login is no longer valid
+var expiredOutput = []string{
+	"you must log in",
+	"not logged in",
+	"token is expired",
+}
+
+var sessionNow = time.Now
+
+// session is the login the Confluent CLI currently holds. The CLI holds a single login, so only the credentials logged in last are reused
+type session struct {
+	key     string
+	expires time.Time
+}
+
+type sessionCache struct {
+	mu      sync.Mutex
+	current session
+}
+
+var sessions = &sessionCache{}
+
+// login Calls login unless the CLI still holds a login for key that did not expire. The lock is held while logging in, so concurrent
+// Connects with the same credentials log in only once
+func (s *sessionCache) login(key string, login func() error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if SessionTTL > 0 && s.current.key == key && sessionNow().Before(s.current.expires) {
+		return nil
+	}
+
+	s.current = session{}
+	if err := login(); err != nil {
+		return err
+	}
+	s.current = session{key: key, expires: sessionNow().Add(SessionTTL)}
+
+	return nil
+}
+
+func (s *sessionCache) forget() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.current = session{}
+}
+
+// ForgetSession makes the next Authenticate log in again, whatever credentials it is called with
+func ForgetSession() {
+	sessions.forget()
+}
+
+// forgetExpiredSession Forgets the login when out of a failed command shows it is no longer valid
+func forgetExpiredSession(out []byte) {
+	str := strings.ToLower(string(out))
+	for _, fragment := range expiredOutput {
+		if strings.Contains(str, fragment) {
+			ForgetSession()
+			return
+		}
+	}
+}
+
+func sessionKey(email string, password string, cfg Config) string {
+	h := sha256.New()
+	h.Write([]byte(email))
+	h.Write([]byte{0})
+	h.Write([]byte(password))
+	h.Write([]byte{0})
+	h.Write(cfg.CABundle)
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.OrganizationID))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.Endpoint))
+	h.Write([]byte{0})
+	h.Write([]byte(cfg.Proxy))
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,78 @@
+package clients
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSessionCache(t *testing.T) {
+	assert := assert.New(t)
+
+	current := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	sessionNow = func() time.Time { return current }
+	defer func() { sessionNow = time.Now }()
+
+	logins := 0
+	login := func() error {
+		logins++
+		return nil
+	}
+
+	s := &sessionCache{}
+	alice := sessionKey("alice@example.com", "password", Config{})
+	bob := sessionKey("bob@example.com", "password", Config{})
+
+	// The login is reused until it expires
+	assert.NoError(s.login(alice, login))
+	assert.NoError(s.login(alice, login))
+	assert.Equal(1, logins)
+
+	current = current.Add(SessionTTL)
+	assert.NoError(s.login(alice, login))
+	assert.Equal(2, logins)
+
+	// The CLI holds a single login, so other credentials log in again
+	assert.NoError(s.login(bob, login))
+	assert.NoError(s.login(alice, login))
+	assert.Equal(4, logins)
+
+	// A failed login is not reused
+	assert.Error(s.login(bob, func() error { return errors.New("boom") }))
+	assert.NoError(s.login(bob, login))
+	assert.Equal(5, logins)
+
+	// A login the CLI reports as expired is forgotten
+	sessions = s
+	defer func() { sessions = &sessionCache{} }()
+	forgetExpiredSession([]byte("Error: token is expired"))
+	assert.NoError(s.login(bob, login))
+	assert.Equal(6, logins)
+}
+
+func TestSessionCacheConcurrentLogins(t *testing.T) {
+	s := &sessionCache{}
+	key := sessionKey("alice@example.com", "password", Config{OrganizationID: "org-1"})
+
+	var mu sync.Mutex
+	logins := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = s.login(key, func() error {
+				mu.Lock()
+				logins++
+				mu.Unlock()
+				return nil
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, logins)
+}
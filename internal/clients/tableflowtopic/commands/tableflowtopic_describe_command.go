@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewTableflowTopicDescribeCommand is a factory method for TableflowTopic describe command
+func NewTableflowTopicDescribeCommand(to v1alpha1.TableflowTopicObservation) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"tableflow", "topic", "describe", to.Topic, "--cluster", to.Cluster, "--environment", to.Environment, "-o", "json"},
+	}
+
+	return command
+}
@@ -0,0 +1,47 @@
+package commands
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewTableflowTopicEnableCommand is a factory method for TableflowTopic enable command
+func NewTableflowTopicEnableCommand(tp v1alpha1.TableflowTopicParameters) exec.Cmd {
+	args := []string{"tableflow", "topic", "enable", tp.Topic, "--cluster", tp.Cluster, "--environment", tp.Environment}
+
+	if tp.Storage.Type != "" {
+		args = append(args, "--storage-type", tp.Storage.Type)
+	}
+	if tp.Storage.ProviderIntegration != "" {
+		args = append(args, "--provider-integration", tp.Storage.ProviderIntegration)
+	}
+	if tp.Storage.BucketName != "" {
+		args = append(args, "--bucket-name", tp.Storage.BucketName)
+	}
+	args = append(args, configArgs(tp)...)
+
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: args,
+	}
+
+	return command
+}
+
+// configArgs Returns the flags for the updatable Tableflow configuration of tp
+func configArgs(tp v1alpha1.TableflowTopicParameters) []string {
+	var args []string
+
+	if len(tp.TableFormats) > 0 {
+		args = append(args, "--table-formats", strings.Join(tp.TableFormats, ","))
+	}
+	if tp.RetentionMs > 0 {
+		args = append(args, "--retention-ms", fmt.Sprintf("%d", tp.RetentionMs))
+	}
+
+	return args
+}
@@ -0,0 +1,18 @@
+package commands
+
+import (
+	"os/exec"
+
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// NewTableflowTopicUpdateCommand is a factory method for TableflowTopic update command
+func NewTableflowTopicUpdateCommand(tp v1alpha1.TableflowTopicParameters) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: append([]string{"tableflow", "topic", "update", tp.Topic, "--cluster", tp.Cluster, "--environment", tp.Environment}, configArgs(tp)...),
+	}
+
+	return command
+}
@@ -0,0 +1,98 @@
+package tableflowtopic
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/tableflowtopic/commands"
+)
+
+// Errors
+const (
+	errUnknown      = "unknown error"
+	ErrNotExists    = "tableflow is not enabled for topic"
+	ErrInvalidInput = "input given may be invalid like empty topic name or so"
+)
+
+// ErrNotFound is returned when Tableflow is not enabled for a topic in Confluent Cloud
+var ErrNotFound = errors.New(ErrNotExists)
+
+// IsNotFound reports whether err is, or wraps, ErrNotFound
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// NewClient is a factory method for tableflow topic client
+func NewClient(c Config) IClient {
+	return &Client{Config: c}
+}
+
+// TableflowTopicEnable Executes Confluent CLI command to enable Tableflow for a Topic in Confluent Cloud
+func (c *Client) TableflowTopicEnable(ctx context.Context, tp v1alpha1.TableflowTopicParameters) error {
+	cmd := commands.NewTableflowTopicEnableCommand(tp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// TableflowTopicDescribe Executes Confluent CLI command to retrieve the Tableflow configuration and materialization state of a Topic from Confluent Cloud
+func (c *Client) TableflowTopicDescribe(ctx context.Context, to v1alpha1.TableflowTopicObservation) (DescribeResponse, error) {
+	var resp DescribeResponse
+
+	cmd := commands.NewTableflowTopicDescribeCommand(to)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return resp, errorParser(out)
+	}
+
+	err = json.Unmarshal(out, &resp)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// TableflowTopicUpdate Executes Confluent CLI command, and with its given TableflowTopicParameters, attempts to update the Tableflow configuration of a Topic in Confluent Cloud
+func (c *Client) TableflowTopicUpdate(ctx context.Context, tp v1alpha1.TableflowTopicParameters) error {
+	cmd := commands.NewTableflowTopicUpdateCommand(tp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// TableflowTopicDisable Executes Confluent CLI command to disable Tableflow for a Topic in Confluent Cloud
+func (c *Client) TableflowTopicDisable(ctx context.Context, to v1alpha1.TableflowTopicObservation) error {
+	cmd := commands.NewTableflowTopicDisableCommand(to)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+func errorParser(cmdout []byte) error {
+	str := string(cmdout)
+	if strings.Contains(str, "not found") {
+		return ErrNotFound
+	} else if strings.Contains(str, "Error: REST request failed") {
+		return errors.New(ErrInvalidInput)
+	}
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
+}
@@ -0,0 +1,40 @@
+package tableflowtopic
+
+import (
+	"context"
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// IClient interface for tableflow topic client
+type IClient interface {
+	TableflowTopicEnable(ctx context.Context, tp v1alpha1.TableflowTopicParameters) error
+	TableflowTopicDescribe(ctx context.Context, to v1alpha1.TableflowTopicObservation) (DescribeResponse, error)
+	TableflowTopicUpdate(ctx context.Context, tp v1alpha1.TableflowTopicParameters) error
+	TableflowTopicDisable(ctx context.Context, to v1alpha1.TableflowTopicObservation) error
+}
+
+// Config is a configuration element for the tableflow topic client
+type Config struct {
+	APICredentials clients.APICredentials
+}
+
+// Client is a struct for tableflow topic client
+type Client struct {
+	Config Config
+}
+
+// DescribeResponse is a struct used for deserialising the response of TableflowTopicDescribe
+type DescribeResponse struct {
+	TopicName             string   `json:"topic_name"`
+	Environment           string   `json:"environment"`
+	Cluster               string   `json:"kafka_cluster"`
+	StorageType           string   `json:"storage_type"`
+	ProviderIntegrationID string   `json:"provider_integration_id"`
+	BucketName            string   `json:"bucket_name"`
+	TableFormats          []string `json:"table_formats"`
+	RetentionMs           string   `json:"retention_ms"`
+	Suspended             bool     `json:"suspended"`
+	Phase                 string   `json:"phase"`
+	ErrorMessage          string   `json:"error_message"`
+}
@@ -3,6 +3,9 @@ package commands
 import (
 	"fmt"
 	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
 	"github.com/dfds/provider-confluent/internal/clients"
@@ -12,8 +15,36 @@ import (
 func NewTopicCreateCommand(tp v1alpha1.TopicParameters) exec.Cmd {
 	var command = exec.Cmd{
 		Path: clients.CliName,
-		Args: []string{"kafka", "topic", "create", tp.Topic.Name, "--cluster", tp.Cluster, "--environment", tp.Environment, "--partitions", fmt.Sprintf("%d", tp.Topic.Partitions), "--config", fmt.Sprintf("\"retention.ms=%d\"", tp.Topic.Config.Retention)},
+		Args: []string{"kafka", "topic", "create", tp.Topic.Name, "--cluster", tp.Cluster, "--environment", tp.Environment, "--partitions", fmt.Sprintf("%d", tp.Topic.Partitions)},
 	}
+	command.Args = append(command.Args, configArgs(tp.Topic)...)
 
 	return command
 }
+
+// configArgs Returns the --config flag setting the config keys of tc that the provider manages, or nothing when it manages none of them.
+// Each key is quoted, as the CLI reads the flag as comma separated values
+func configArgs(tc v1alpha1.TopicConfig) []string {
+	var pairs []string
+	if tc.ManagesConfigKey(v1alpha1.ConfigKeyRetention) {
+		pairs = append(pairs, configPair(v1alpha1.ConfigKeyRetention, strconv.FormatInt(tc.Config.Retention, 10)))
+	}
+
+	keys := make([]string, 0, len(tc.Config.Settings))
+	for k := range tc.Config.Settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		pairs = append(pairs, configPair(k, tc.Config.Settings[k]))
+	}
+
+	if len(pairs) == 0 {
+		return nil
+	}
+	return []string{"--config", strings.Join(pairs, ",")}
+}
+
+func configPair(key string, value string) string {
+	return fmt.Sprintf("\"%s=%s\"", key, strings.ReplaceAll(value, "\"", "\"\""))
+}
@@ -8,11 +8,21 @@ import (
 	"github.com/dfds/provider-confluent/internal/clients"
 )
 
-// NewTopicUpdateCommand is a factory method for Topic Update command
+// NewTopicUpdateCommand is a factory method for Topic Update command. Only the config keys the provider manages are updated
 func NewTopicUpdateCommand(tp v1alpha1.TopicParameters) exec.Cmd {
 	var command = exec.Cmd{
 		Path: clients.CliName,
-		Args: []string{"kafka", "topic", "update", tp.Topic.Name, "--cluster", tp.Cluster, "--environment", tp.Environment, "--config", fmt.Sprintf("\"retention.ms=%d\"", tp.Topic.Config.Retention)},
+		Args: []string{"kafka", "topic", "update", tp.Topic.Name, "--cluster", tp.Cluster, "--environment", tp.Environment},
+	}
+	command.Args = append(command.Args, configArgs(tp.Topic)...)
+	return command
+}
+
+// NewTopicUpdatePartitionsCommand is a factory method for Topic Update command increasing the partitions of the topic
+func NewTopicUpdatePartitionsCommand(tp v1alpha1.TopicParameters) exec.Cmd {
+	var command = exec.Cmd{
+		Path: clients.CliName,
+		Args: []string{"kafka", "topic", "update", tp.Topic.Name, "--cluster", tp.Cluster, "--environment", tp.Environment, "--partitions-count", fmt.Sprintf("%d", tp.Topic.Partitions)},
 	}
 	return command
 }
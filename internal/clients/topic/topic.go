@@ -1,6 +1,7 @@
 package topic
 
 import (
+	"context"
 	"encoding/json"
 	"strings"
 
@@ -24,10 +25,10 @@ func NewClient(c Config) IClient {
 }
 
 // TopicCreate Executes Confluent CLI command to create a Topic in Confluent Cloud
-func (c *Client) TopicCreate(tp v1alpha1.TopicParameters) error {
+func (c *Client) TopicCreate(ctx context.Context, tp v1alpha1.TopicParameters) error {
 
 	var cmd = commands.NewTopicCreateCommand(tp)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return errorParser(out)
@@ -37,11 +38,11 @@ func (c *Client) TopicCreate(tp v1alpha1.TopicParameters) error {
 }
 
 // TopicDescribe Executes Confluent CLI command to retrieve metadata about a Topic from Confluent Cloud
-func (c *Client) TopicDescribe(to v1alpha1.TopicObservation) (DescribeResponse, error) {
+func (c *Client) TopicDescribe(ctx context.Context, to v1alpha1.TopicObservation) (DescribeResponse, error) {
 	var resp DescribeResponse
 
 	cmd := commands.NewTopicDescribeCommand(to)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return resp, errorParser(out)
@@ -56,10 +57,23 @@ func (c *Client) TopicDescribe(to v1alpha1.TopicObservation) (DescribeResponse,
 }
 
 // TopicUpdate Executes Confluent CLI command, and with its given TopicParameters, attempts to update a Topic in Confluent Cloud
-func (c *Client) TopicUpdate(tp v1alpha1.TopicParameters) error {
+func (c *Client) TopicUpdate(ctx context.Context, tp v1alpha1.TopicParameters) error {
 
 	cmd := commands.NewTopicUpdateCommand(tp)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
+
+	if err != nil {
+		return errorParser(out)
+	}
+
+	return nil
+}
+
+// TopicUpdatePartitions Executes Confluent CLI command to increase the partitions of a Topic in Confluent Cloud to the partitions of its
+// given TopicParameters
+func (c *Client) TopicUpdatePartitions(ctx context.Context, tp v1alpha1.TopicParameters) error {
+	cmd := commands.NewTopicUpdatePartitionsCommand(tp)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return errorParser(out)
@@ -69,9 +83,9 @@ func (c *Client) TopicUpdate(tp v1alpha1.TopicParameters) error {
 }
 
 // TopicDelete Executes Confluent CLI command, and with its given TopicParameters, attempts to delete a Topic in Confluent Cloud
-func (c *Client) TopicDelete(tp v1alpha1.TopicParameters) error {
+func (c *Client) TopicDelete(ctx context.Context, tp v1alpha1.TopicParameters) error {
 	cmd := commands.NewTopicDeleteCommand(tp)
-	out, err := clients.ExecuteCommand(cmd)
+	out, err := clients.ExecuteCommandContext(ctx, cmd)
 
 	if err != nil {
 		return errorParser(out)
@@ -87,5 +101,5 @@ func errorParser(cmdout []byte) error {
 	} else if strings.Contains(str, "Error: REST request failed") {
 		return errors.New(ErrInvalidInput)
 	}
-	return errors.Wrap(errors.New(errUnknown), str)
+	return errors.Wrap(clients.CommandError(cmdout), errUnknown)
 }
@@ -1,6 +1,7 @@
 package topic
 
 import (
+	"context"
 	"testing"
 
 	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
@@ -27,37 +28,37 @@ func TestTopicLifecycle(t *testing.T) {
 	clients.SkipCI(t)
 	assert := assert.New(t)
 
-	_, err := client.TopicDescribe(v1alpha1.TopicObservation{Cluster: cluster, Environment: environment, Name: name})
+	_, err := client.TopicDescribe(context.Background(), v1alpha1.TopicObservation{Cluster: cluster, Environment: environment, Name: name})
 	if err != nil {
 		assert.Equal(err.Error(), ErrUnknownTopic)
 	} else {
 		t.Errorf("expected topic unknow error but got: %s", err.Error())
 	}
 
-	_, err = client.TopicDescribe(v1alpha1.TopicObservation{Cluster: cluster, Environment: environment, Name: ""})
+	_, err = client.TopicDescribe(context.Background(), v1alpha1.TopicObservation{Cluster: cluster, Environment: environment, Name: ""})
 	if err != nil {
 		assert.Equal(err.Error(), ErrInvalidInput)
 	} else {
 		t.Errorf("expected invalid input error but got: %s", err.Error())
 	}
 
-	err = client.TopicCreate(topic)
+	err = client.TopicCreate(context.Background(), topic)
 	if err != nil {
 		t.Errorf("topic creation failed with unknow error: %s", err.Error())
 	}
 
-	_, err = client.TopicDescribe(v1alpha1.TopicObservation{Cluster: cluster, Environment: environment, Name: name})
+	_, err = client.TopicDescribe(context.Background(), v1alpha1.TopicObservation{Cluster: cluster, Environment: environment, Name: name})
 	if err != nil {
 		t.Errorf("cannot find previously created topic: %s", err.Error())
 	}
 
 	topic.Topic.Config.Retention = 604800000
-	err = client.TopicUpdate(topic)
+	err = client.TopicUpdate(context.Background(), topic)
 	if err != nil {
 		t.Errorf("cannot update topic retion: %s", err.Error())
 	}
 
-	err = client.TopicDelete(topic)
+	err = client.TopicDelete(context.Background(), topic)
 	if err != nil {
 		t.Errorf("cannot delete topic (manual clean up may be required): %s", err.Error())
 	}
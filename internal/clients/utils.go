@@ -1,18 +1,34 @@
 package clients
 
 import (
-	"os"
+	"context"
 	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	errCommandDeadline = "confluent cli command did not finish before the deadline of the reconcile"
+	errCommandCanceled = "confluent cli command was canceled"
 )
 
-// ExecuteCommand Execute command helper method
-func ExecuteCommand(cmd exec.Cmd) ([]byte, error) {
-	execCmd := exec.Command(cmd.Path, cmd.Args...) //nolint:gosec
-	execCmd.Env = os.Environ()
+// ExecuteCommandContext Executes cmd & returns its combined output. The command is killed once ctx is done, so all commands of a reconcile
+// share the deadline of its context
+func ExecuteCommandContext(ctx context.Context, cmd exec.Cmd) ([]byte, error) {
+	execCmd := exec.CommandContext(ctx, cmd.Path, cmd.Args...) //nolint:gosec
+	execCmd.Env = commandEnv()
 
 	out, err := execCmd.CombinedOutput()
 
+	switch ctx.Err() {
+	case context.DeadlineExceeded:
+		return out, errors.Wrap(ctx.Err(), errCommandDeadline)
+	case context.Canceled:
+		return out, errors.Wrap(ctx.Err(), errCommandCanceled)
+	}
+
 	if err != nil {
+		forgetExpiredSession(out)
 		return out, err
 	}
 
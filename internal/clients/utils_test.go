@@ -1,8 +1,12 @@
 package clients
 
 import (
+	"context"
 	"os/exec"
 	"testing"
+	"time"
+
+	"github.com/pkg/errors"
 )
 
 func TestExecuteCommand(t *testing.T) {
@@ -12,7 +16,7 @@ func TestExecuteCommand(t *testing.T) {
 	}
 
 	// Expect no error
-	_, err := ExecuteCommand(command)
+	_, err := ExecuteCommandContext(context.Background(), command)
 	if err != nil {
 		t.Error(err)
 	}
@@ -20,8 +24,41 @@ func TestExecuteCommand(t *testing.T) {
 	command.Args = append(command.Args, "-ulla")
 
 	// Expect an error "unknown shorthand flag: 'u' in -ulla"
-	_, err = ExecuteCommand(command)
+	_, err = ExecuteCommandContext(context.Background(), command)
 	if err == nil {
 		t.Error(err)
 	}
 }
+
+func TestExecuteCommandContext(t *testing.T) {
+	var command = exec.Cmd{
+		Path: "sleep",
+		Args: []string{"5"},
+	}
+
+	// The deadline of the context bounds the command
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := ExecuteCommandContext(ctx, command)
+	if err == nil || err.Error() != "confluent cli command did not finish before the deadline of the reconcile: context deadline exceeded" {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Error("command should be killed at the deadline of the context")
+	}
+
+	// A canceled context kills the command
+	ctx, cancel = context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start = time.Now()
+	_, err = ExecuteCommandContext(ctx, command)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected a canceled error, got %v", err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Error("command should be killed once the context is canceled")
+	}
+}
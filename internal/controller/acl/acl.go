@@ -18,7 +18,7 @@ package acl
 
 import (
 	"context"
-	"reflect"
+	"fmt"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -27,7 +27,6 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -41,6 +40,16 @@ import (
 	confluentClient "github.com/dfds/provider-confluent/internal/clients"
 	"github.com/dfds/provider-confluent/internal/clients/acl"
 	"github.com/dfds/provider-confluent/internal/clients/acl/commands"
+	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
 )
 
 const (
@@ -48,31 +57,51 @@ const (
 	errTrackPCUsage                   = "cannot track ProviderConfig usage"
 	errGetPC                          = "cannot get ProviderConfig"
 	errGetCreds                       = "cannot get credentials"
+	errGetCABundle                    = "cannot get CA bundle"
 	errNewClient                      = "cannot create new Service"
 	errAuthCredentials                = "invalid client credentials"
 	errACLRuleInputDoesNotMatchOutput = "A single rule was not returned after creation. As only one rule is supposed to be created, this ain't right son."
+	errRolledBack                     = "created bindings were rolled back"
+	errRollbackFailed                 = "rolling back created bindings failed, bindings left behind for operations %s"
+	errListACLs                       = "cannot list the ACLs declaring bindings"
+	errConvertLegacy                  = "cannot update the ACL converted from the legacy rule shape"
 )
 
+// Reason of the events recorded for deleted unmanaged bindings
+const (
+	reasonDeleteUnmanaged event.Reason = "DeleteUnmanagedBinding"
+	reasonBindingsDrifted event.Reason = "BindingsDrifted"
+)
+
+// CheckPrincipals enables checking that the principal of an ACL still exists when observing it. It costs an extra API call per observe, so it is disabled by default
+var CheckPrincipals = false
+
 var (
-	createAndConvertClientFunc = func(clientCreds []byte, apiCreds clients.APICredentials) (interface{}, error) { //nolint
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, interface{}, error) { //nolint
 		credParts := strings.Split(string(clientCreds), ":")
 
 		if len(credParts) != 2 {
-			return nil, errors.New(errAuthCredentials)
+			return nil, nil, errors.New(errAuthCredentials)
 		}
 
-		cClient := confluentClient.NewClient()
-		authErr := cClient.Authenticate(credParts[0], credParts[1])
+		cClient := confluentClient.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
 
 		if authErr != nil {
-			return nil, authErr
+			return nil, nil, authErr
 		}
 
 		srConfig := acl.Config{
 			APICredentials: apiCreds,
 		}
 
-		return acl.NewClient(srConfig).(interface{}), nil
+		saConfig := serviceaccount.Config{
+			APICredentials: apiCreds,
+			MaxRetries:     serviceaccount.DefaultMaxRetries,
+			BaseDelay:      serviceaccount.DefaultBaseDelay,
+		}
+
+		return acl.NewClient(srConfig).(interface{}), serviceaccount.NewClient(saConfig).(interface{}), nil
 	}
 )
 
@@ -84,20 +113,49 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
 	}
 
+	failures := retry.NewTracker()
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ACLGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithReferenceResolver(dependency.ResolveReferences(managed.NewAPISimpleReferenceResolver(mgr.GetClient()))),
+		managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), &legacyConverter{kube: mgr.GetClient()}, providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.ACL).Spec.ForProvider.Environment })),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: createAndConvertClientFunc}),
+			recorder:     recorder,
+			newServiceFn: createAndConvertClientFunc})),
 		managed.WithLogger(l.WithValues("controller", name)),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithRecorder(recorder))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o).
 		For(&v1alpha1.ACL{}).
-		Complete(r)
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.ACLList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.ACL{} }, failures.Reconciler(r))))
+}
+
+// legacyConverter converts the rules of ACLs declared in the legacy shape into the current one, so existing manifests keep working
+type legacyConverter struct {
+	kube client.Client
+}
+
+// Initialize Converts the legacy fields of the rule of mg & persists the result before anything is reconciled
+func (l *legacyConverter) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.ACL)
+	if !ok {
+		return errors.New(errNotMyType)
+	}
+	if !cr.Spec.ForProvider.ACLRule.IsLegacy() {
+		return nil
+	}
+
+	if err := cr.Spec.ForProvider.ConvertLegacy(); err != nil {
+		return err
+	}
+	return errors.Wrap(l.kube.Update(ctx, cr), errConvertLegacy)
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -105,7 +163,8 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(creds []byte, apiCreds confluentClient.APICredentials) (interface{}, error)
+	recorder     event.Recorder
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds confluentClient.APICredentials, cfg confluentClient.Config) (interface{}, interface{}, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -123,12 +182,22 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
+	// Malformed principals are rejected when the ACL commands are built
+	serviceAccount, _ := commands.ParsePrincipal(cr.Spec.ForProvider.ACLRule.Principal)
+	deps, err := dependency.ServiceAccounts(ctx, c.kube, cr.Spec.ForProvider.ACLRule.PrincipalRef, serviceAccount)
+	if err != nil {
+		return nil, err
+	}
+	if err := dependency.Gate(cr, deps...); err != nil {
+		return nil, err
+	}
+
 	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	clientCredentialData, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -143,12 +212,23 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		}
 	}
 
-	svc, err := c.newServiceFn(clientCredentialData, apiCredentials)
+	caBundle, err := confluentClient.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := confluentClient.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, saSvc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, saService: saSvc, kube: c.kube, recorder: c.recorder}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -156,8 +236,10 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
-	service interface{}
-	kube    client.Client
+	service   interface{}
+	saService interface{}
+	kube      client.Client
+	recorder  event.Recorder
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -166,15 +248,19 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{}, errors.New(errNotMyType)
 	}
 
+	// Confluent
+	var client = c.service.(acl.IClient)
+
 	if cr.Status.AtProvider.ACLP.ACLRule.Principal == "" {
-		return managed.ExternalObservation{
-			ResourceExists:    false,
-			ConnectionDetails: managed.ConnectionDetails{},
-		}, nil
+		adopted, err := adoptRules(ctx, client, cr)
+		if err != nil || !adopted {
+			return managed.ExternalObservation{
+				ResourceExists:    false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, err
+		}
 	}
 
-	// Confluent
-	var client = c.service.(acl.IClient)
 	serviceAccount, err := commands.ParsePrincipal(cr.Status.AtProvider.ACLP.ACLRule.Principal)
 	if err != nil {
 		return managed.ExternalObservation{
@@ -182,10 +268,11 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 			ConnectionDetails: managed.ConnectionDetails{},
 		}, err
 	}
-	aclResp, err := client.ACLList(serviceAccount, cr.Status.AtProvider.ACLP.Environment, cr.Status.AtProvider.ACLP.Cluster)
+	aclResp, err := client.ACLList(ctx, serviceAccount, cr.Status.AtProvider.ACLP.Environment, cr.Status.AtProvider.ACLP.Cluster)
 
 	if err != nil {
 		if err.Error() == acl.ErrACLNotExistsOrInvalidServiceAccount {
+			countBindings(cr, nil)
 			return managed.ExternalObservation{
 				ResourceExists:    false,
 				ConnectionDetails: managed.ConnectionDetails{},
@@ -198,25 +285,29 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	}
 
-	// Diff
-	ruleStatusMatched := false
-	ruleSpecMatched := false
-	for _, rule := range aclResp {
-		if ruleStatusMatched && ruleSpecMatched {
-			break
-		}
-
-		if reflect.DeepEqual(rule, cr.Status.AtProvider.ACLP.ACLRule) {
-			ruleStatusMatched = true
-		}
+	// The bindings of another principal or scope are none of the declared bindings
+	counted := aclResp
+	if principalChanged(cr) || scopeChanged(cr) {
+		counted = nil
+	}
+	countBindings(cr, counted)
 
-		if reflect.DeepEqual(rule, cr.Spec.ForProvider.ACLRule) {
-			ruleSpecMatched = true
-		}
+	// Bindings are principal scoped & immutable. When the principal changed, the bindings of the old principal have to be deleted & recreated for the new one
+	if principalChanged(cr) {
+		c.reportDrift(cr, driftOf(cr, aclResp, nil))
+		return managed.ExternalObservation{
+			ResourceExists:    containsAnyRule(aclResp, expandRule(cr.Status.AtProvider.ACLP.ACLRule)),
+			ResourceUpToDate:  false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
 	}
 
+	// Diff
+	ruleStatusMatched, ruleSpecMatched := observeRuleMatches(aclResp, cr.Status.AtProvider.ACLP.ACLRule, cr.Spec.ForProvider.ACLRule)
+
 	// Rule stored in Status matched, but rule in Spec doesn't. Delete rule specified in Status & create a new rule based from Spec. Update Status with rule from Spec.
 	if ruleStatusMatched && !ruleSpecMatched {
+		c.reportDrift(cr, driftOf(cr, aclResp, nil))
 		return managed.ExternalObservation{
 			ResourceExists:    true,
 			ResourceUpToDate:  false,
@@ -226,6 +317,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	// Rule stored in Status & Spec didn't match. Create rule from Spec, update Status with rule from Spec.
 	if !ruleStatusMatched && !ruleSpecMatched {
+		c.reportDrift(cr, driftOf(cr, aclResp, nil))
 		return managed.ExternalObservation{
 			ResourceExists:    false,
 			ResourceUpToDate:  false,
@@ -233,11 +325,34 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
-	cr.Status.SetConditions(xpv1.Available())
-	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalObservation{}, err
+	// Bindings of the principal that no ACL declares are deleted by an update when the ACLs of the principal are authoritative
+	if cr.Spec.ForProvider.UnmanagedBindings == UnmanagedBindingsDelete {
+		unmanaged, err := c.unmanagedRules(ctx, cr, aclResp)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if len(unmanaged) > 0 {
+			c.reportDrift(cr, driftOf(cr, aclResp, unmanaged))
+			return managed.ExternalObservation{
+				ResourceExists:    true,
+				ResourceUpToDate:  false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, nil
+		}
+	}
+
+	// Bindings of a service account deleted out-of-band are dangling, which is reported rather than silently considered healthy
+	conditions := []xpv1.Condition{xpv1.Available(), NoDrift()}
+	if CheckPrincipals {
+		cond, err := c.principalCondition(ctx, serviceAccount)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		conditions = append(conditions, cond)
 	}
 
+	cr.Status.SetConditions(conditions...)
+
 	return managed.ExternalObservation{
 		ResourceExists:    true,
 		ResourceUpToDate:  true,
@@ -251,23 +366,26 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotMyType)
 	}
 
+	if err := validateScope(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := validateOperations(cr.Spec.ForProvider.ACLRule); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	cr.Status.SetConditions(xpv1.Creating())
 	if err := c.kube.Status().Update(ctx, cr); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
 	var client = c.service.(acl.IClient)
-	out, err := client.ACLCreate(cr.Spec.ForProvider)
-
+	created, err := createRules(ctx, client, cr.Spec.ForProvider, nil)
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
-	if len(out) != 1 {
-		return managed.ExternalCreation{}, errors.New(errACLRuleInputDoesNotMatchOutput)
-	}
-
-	cr.Status.AtProvider.ACLP.ACLRule = out[0]
+	cr.Status.AtProvider.ACLP.ACLRule = collapseRules(cr.Spec.ForProvider.ACLRule, created)
 	cr.Status.AtProvider.ACLP.Cluster = cr.Spec.ForProvider.Cluster
 	cr.Status.AtProvider.ACLP.Environment = cr.Spec.ForProvider.Environment
 
@@ -277,6 +395,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	conn := managed.ConnectionDetails{}
 
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -290,24 +412,64 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotMyType)
 	}
 
-	var client = c.service.(acl.IClient)
-
-	// Update description
-	err := client.ACLDelete(cr.Status.AtProvider.ACLP)
-	if err != nil {
+	if err := validateScope(cr.Spec.ForProvider); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	out, err := client.ACLCreate(cr.Spec.ForProvider)
-	if err != nil {
+	if err := validateOperations(cr.Spec.ForProvider.ACLRule); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if len(out) != 1 {
-		return managed.ExternalUpdate{}, errors.New(errACLRuleInputDoesNotMatchOutput)
+	var client = c.service.(acl.IClient)
+
+	if cr.Spec.ForProvider.UnmanagedBindings == UnmanagedBindingsDelete {
+		observed, err := c.deleteUnmanagedRules(ctx, client, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		// Only unmanaged bindings drifted, the bindings of the ACL are left in place
+		if _, ruleSpecMatched := observeRuleMatches(observed, cr.Status.AtProvider.ACLP.ACLRule, cr.Spec.ForProvider.ACLRule); ruleSpecMatched && !principalChanged(cr) {
+			if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+			return managed.ExternalUpdate{ConnectionDetails: managed.ConnectionDetails{}}, nil
+		}
 	}
 
-	cr.Status.AtProvider.ACLP.ACLRule = out[0]
+	var created []v1alpha1.ACLRule
+	if principalChanged(cr) || scopeChanged(cr) {
+		// Bindings are immutable & scoped to their principal, environment & cluster, so all of them are replaced
+		if err := deleteRules(ctx, client, cr.Status.AtProvider.ACLP); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		rules, err := createRules(ctx, client, cr.Spec.ForProvider, nil)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		created = rules
+	} else {
+		// Only the bindings that changed are touched, whatever the order of the operations
+		observed, err := existingRules(ctx, client, cr.Spec.ForProvider)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		rules, err := createRules(ctx, client, cr.Spec.ForProvider, observed)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		created = rules
+
+		owned := ownedRules(observed, cr.Status.AtProvider.ACLP.ACLRule, cr.Spec.ForProvider.ACLRule)
+		_, removed := diffRules(expandRule(cr.Spec.ForProvider.ACLRule), owned)
+		if err := deleteRemovedRules(ctx, client, cr.Spec.ForProvider, removed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	cr.Status.AtProvider.ACLP.ACLRule = collapseRules(cr.Spec.ForProvider.ACLRule, created)
 	cr.Status.AtProvider.ACLP.Cluster = cr.Spec.ForProvider.Cluster
 	cr.Status.AtProvider.ACLP.Environment = cr.Spec.ForProvider.Environment
 
@@ -315,6 +477,10 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, err
 	}
 
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -335,10 +501,219 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	var client = c.service.(acl.IClient)
 
-	err := client.ACLDelete(cr.Spec.ForProvider)
+	// Observe finds the applied bindings through Status, so they are deleted even when Spec was changed since
+	applied := cr.Status.AtProvider.ACLP
+	if applied.ACLRule.Principal != "" {
+		if err := deleteRules(ctx, client, applied); err != nil {
+			return err
+		}
+	}
+
+	// Bindings of Spec that were not applied yet may exist as well, e.g. after an update that failed halfway
+	if applied.ACLRule.Principal == "" || principalChanged(cr) || scopeChanged(cr) {
+		return deleteRules(ctx, client, cr.Spec.ForProvider)
+	}
+	unapplied, _ := diffRules(expandRule(cr.Spec.ForProvider.ACLRule), expandRule(applied.ACLRule))
+	return deleteRemovedRules(ctx, client, cr.Spec.ForProvider, unapplied)
+}
+
+// createRules Creates the bindings of the operations of aclP that observed lacks & returns the bindings of all operations. If aclP is atomic, the bindings created before a failure are rolled back
+func createRules(ctx context.Context, client acl.IClient, aclP v1alpha1.ACLParameters, observed []v1alpha1.ACLRule) ([]v1alpha1.ACLRule, error) {
+	var created []v1alpha1.ACLRule
+	var rollback []v1alpha1.ACLParameters
+
+	existing := observed
+	if aclP.Atomic && existing == nil {
+		var err error
+		existing, err = existingRules(ctx, client, aclP)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	add, _ := diffRules(expandRule(aclP.ACLRule), observed)
+	for _, p := range expandParameters(aclP) {
+		if !containsRule(add, p.ACLRule) {
+			rule, _ := findRule(observed, p.ACLRule)
+			created = append(created, rule)
+			continue
+		}
+
+		out, err := client.ACLCreate(ctx, p)
+		if err == nil && len(out) != 1 {
+			err = errors.New(errACLRuleInputDoesNotMatchOutput)
+		}
+		if err != nil {
+			if aclP.Atomic {
+				return nil, rollbackRules(ctx, client, rollback, err)
+			}
+			return nil, err
+		}
+
+		created = append(created, out[0])
+		if !containsRule(existing, p.ACLRule) {
+			rollback = append(rollback, p)
+		}
+	}
+
+	return created, nil
+}
+
+// adoptRules Stores the bindings of the spec of cr in its status when all of them exist, so an ACL declared for existing bindings adopts
+// them instead of creating them. The status of an ACL is empty until its bindings were created or adopted
+func adoptRules(ctx context.Context, client acl.IClient, cr *v1alpha1.ACL) (bool, error) {
+	aclP := cr.Spec.ForProvider
+	if aclP.ACLRule.Principal == "" || validateScope(aclP) != nil || validateOperations(aclP.ACLRule) != nil {
+		return false, nil
+	}
+
+	observed, err := existingRules(ctx, client, aclP)
 	if err != nil {
-		return err
+		return false, err
+	}
+
+	var found []v1alpha1.ACLRule
+	for _, rule := range expandRule(aclP.ACLRule) {
+		r, ok := findRule(observed, rule)
+		if !ok {
+			return false, nil
+		}
+		found = append(found, r)
+	}
+
+	cr.Status.AtProvider.ACLP.ACLRule = collapseRules(aclP.ACLRule, found)
+	cr.Status.AtProvider.ACLP.Environment = aclP.Environment
+	cr.Status.AtProvider.ACLP.Cluster = aclP.Cluster
+	return true, nil
+}
+
+// existingRules Returns the bindings of the principal of aclP that exist before creating it, so a rollback leaves them alone
+func existingRules(ctx context.Context, client acl.IClient, aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, error) {
+	serviceAccount, err := commands.ParsePrincipal(aclP.ACLRule.Principal)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := client.ACLList(ctx, serviceAccount, aclP.Environment, aclP.Cluster)
+	if err != nil {
+		if err.Error() == acl.ErrACLNotExistsOrInvalidServiceAccount {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// rollbackRules Deletes the bindings created before cause, on a best effort basis. Bindings that could not be deleted are reported along with cause
+func rollbackRules(ctx context.Context, client acl.IClient, created []v1alpha1.ACLParameters, cause error) error {
+	var remaining []string
+	for _, p := range created {
+		if err := client.ACLDelete(ctx, p); err != nil && !acl.IsBindingNotFound(err) {
+			remaining = append(remaining, p.ACLRule.Operation)
+		}
+	}
+
+	if len(remaining) > 0 {
+		return errors.Wrapf(cause, errRollbackFailed, strings.Join(remaining, ", "))
+	}
+
+	return errors.Wrap(cause, errRolledBack)
+}
+
+// deleteRules Deletes the binding of every operation of aclP. Bindings that are already gone, e.g. removed by another actor or a prior partial delete, are skipped
+func deleteRules(ctx context.Context, client acl.IClient, aclP v1alpha1.ACLParameters) error {
+	for _, p := range expandParameters(aclP) {
+		err := client.ACLDelete(ctx, p)
+		if err != nil && !acl.IsBindingNotFound(err) {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// reportDrift Summarizes the drift of the bindings in the Drift condition & records the drifted bindings as events, a page of bindings per
+// event. The events are only recorded when the drift changed, so an ACL that stays drifted does not flood its events
+func (c *external) reportDrift(cr *v1alpha1.ACL, d drift) {
+	if d.empty() {
+		return
+	}
+
+	cond := BindingsDrifted(d)
+	if prev := cr.Status.GetCondition(TypeDrift); prev.Reason != cond.Reason || prev.Message != cond.Message {
+		for _, page := range d.pages() {
+			c.recorder.Event(cr, event.Warning(reasonBindingsDrifted, errors.New(page)))
+		}
+	}
+	cr.Status.SetConditions(cond)
+}
+
+// unmanagedRules Returns the observed bindings of the principal of cr that are not declared by the Spec of any ACL of the same principal,
+// environment & cluster
+func (c *external) unmanagedRules(ctx context.Context, cr *v1alpha1.ACL, observed []v1alpha1.ACLRule) ([]v1alpha1.ACLRule, error) {
+	acls := &v1alpha1.ACLList{}
+	if err := c.kube.List(ctx, acls); err != nil {
+		return nil, errors.Wrap(err, errListACLs)
+	}
+
+	return undeclaredRules(observed, cr.Spec.ForProvider, append(acls.Items, *cr)), nil
+}
+
+// deleteUnmanagedRules Deletes the bindings of the principal of cr that no ACL declares & returns the bindings that are left. An event naming
+// each binding is recorded before it is deleted, so the deletion is on record even when the provider stops halfway
+func (c *external) deleteUnmanagedRules(ctx context.Context, client acl.IClient, cr *v1alpha1.ACL) ([]v1alpha1.ACLRule, error) {
+	observed, err := existingRules(ctx, client, cr.Spec.ForProvider)
+	if err != nil {
+		return nil, err
+	}
+
+	unmanaged, err := c.unmanagedRules(ctx, cr, observed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range unmanaged {
+		p := v1alpha1.ACLParameters{ACLRule: rule, Environment: cr.Spec.ForProvider.Environment, Cluster: cr.Spec.ForProvider.Cluster}
+		c.recorder.Event(cr, event.Normal(reasonDeleteUnmanaged, fmt.Sprintf("Deleting unmanaged binding %s", externalname.EncodeACL(bindingName(p)))))
+
+		if err := client.ACLDelete(ctx, p); err != nil && !acl.IsBindingNotFound(err) {
+			return nil, err
+		}
+	}
+
+	var left []v1alpha1.ACLRule
+	for _, rule := range observed {
+		if !containsRule(unmanaged, rule) {
+			left = append(left, rule)
+		}
+	}
+
+	return left, nil
+}
+
+// deleteRemovedRules Deletes the removed bindings in the environment & cluster of aclP. Bindings that are already gone are skipped
+func deleteRemovedRules(ctx context.Context, client acl.IClient, aclP v1alpha1.ACLParameters, removed []v1alpha1.ACLRule) error {
+	for _, rule := range removed {
+		p := v1alpha1.ACLParameters{ACLRule: rule, Environment: aclP.Environment, Cluster: aclP.Cluster}
+		if err := client.ACLDelete(ctx, p); err != nil && !acl.IsBindingNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// principalCondition Returns the Degraded condition of an ACL, depending on whether the service account of its principal still exists
+func (c *external) principalCondition(ctx context.Context, serviceAccount string) (xpv1.Condition, error) {
+	var saClient = c.saService.(serviceaccount.IClient)
+	_, err := saClient.ServiceAccountByID(ctx, serviceAccount)
+	if err != nil {
+		if serviceaccount.IsNotFound(err) {
+			return PrincipalNotFound(serviceAccount), nil
+		}
+		return xpv1.Condition{}, err
+	}
+
+	return PrincipalFound(), nil
+}
@@ -0,0 +1,405 @@
+package acl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/acl/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/acl/commands"
+	"github.com/dfds/provider-confluent/internal/externalname"
+)
+
+const (
+	errOperationsInvalid = "exactly one of operation or operations must be set"
+	errOperationUnknown  = "operation %s must be one of %s"
+	errScopeMissing      = "acl %s must be set, bindings are scoped to an environment & cluster"
+)
+
+// Condition type & reasons of the principal check
+const (
+	TypeDegraded xpv1.ConditionType = "Degraded"
+
+	ReasonPrincipalNotFound xpv1.ConditionReason = "PrincipalNotFound"
+	ReasonPrincipalFound    xpv1.ConditionReason = "PrincipalFound"
+)
+
+// What happens to the bindings of a principal that no ACL declares
+const (
+	UnmanagedBindingsKeep   = "Keep"
+	UnmanagedBindingsDelete = "DeleteUnmanaged"
+)
+
+// operations are the operations a binding can have, as listed by the enum of ACLRule.Operation
+var operations = []string{"ALTER", "ALTER_CONFIGS", "CLUSTER_ACTION", "CREATE", "DELETE", "DESCRIBE", "DESCRIBE_CONFIGS", "IDEMPOTENT_WRITE", "READ", "WRITE"}
+
+const msgPrincipalNotFound = "service account %s of the principal no longer exists, the ACL bindings are dangling and should be cleaned up"
+
+// PrincipalNotFound indicates that the service account of an ACL principal was deleted, leaving its bindings dangling
+func PrincipalNotFound(serviceAccount string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPrincipalNotFound,
+		Message:            fmt.Sprintf(msgPrincipalNotFound, serviceAccount),
+	}
+}
+
+// PrincipalFound indicates that the service account of an ACL principal exists
+func PrincipalFound() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPrincipalFound,
+	}
+}
+
+// aclRuleMatches Checks if two ACL rules describe the same binding. PatternType is part of the binding identity, so a PREFIXED binding never matches a LITERAL binding on the same resource name
+func aclRuleMatches(a v1alpha1.ACLRule, b v1alpha1.ACLRule) bool {
+	return a.Operation == b.Operation &&
+		a.PatternType == b.PatternType &&
+		a.Permission == b.Permission &&
+		a.Principal == b.Principal &&
+		resourceName(a) == resourceName(b) &&
+		a.ResourceType == b.ResourceType
+}
+
+// resourceName Returns the resource name of a rule as Confluent reports it. Cluster-scoped bindings have no resource of their own and are always listed under kafka-cluster, whatever name the rule was created with
+func resourceName(rule v1alpha1.ACLRule) string {
+	if rule.ResourceType == "CLUSTER" {
+		return commands.ClusterResourceName
+	}
+	return rule.ResourceName
+}
+
+// observeRuleMatches Checks if the bindings of the rules stored in Status & Spec are among the observed rules
+func observeRuleMatches(observed []v1alpha1.ACLRule, status v1alpha1.ACLRule, spec v1alpha1.ACLRule) (statusMatched bool, specMatched bool) {
+	return containsAllRules(observed, expandRule(status)), containsAllRules(observed, expandRule(spec))
+}
+
+// containsRule Checks if rule is among the observed rules
+func containsRule(observed []v1alpha1.ACLRule, rule v1alpha1.ACLRule) bool {
+	for _, r := range observed {
+		if aclRuleMatches(r, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// findRule Returns the observed rule describing the same binding as rule
+func findRule(observed []v1alpha1.ACLRule, rule v1alpha1.ACLRule) (v1alpha1.ACLRule, bool) {
+	for _, r := range observed {
+		if aclRuleMatches(r, rule) {
+			return r, true
+		}
+	}
+	return v1alpha1.ACLRule{}, false
+}
+
+// containsAllRules Checks if all rules are among the observed rules
+func containsAllRules(observed []v1alpha1.ACLRule, rules []v1alpha1.ACLRule) bool {
+	for _, rule := range rules {
+		if !containsRule(observed, rule) {
+			return false
+		}
+	}
+	return true
+}
+
+// containsAnyRule Checks if at least one of rules is among the observed rules
+func containsAnyRule(observed []v1alpha1.ACLRule, rules []v1alpha1.ACLRule) bool {
+	for _, rule := range rules {
+		if containsRule(observed, rule) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffRules Returns the bindings of desired that current lacks & the bindings of current that desired no longer has. Bindings are compared by
+// aclRuleMatches, so the order of the operations never causes a binding to be recreated
+func diffRules(desired []v1alpha1.ACLRule, current []v1alpha1.ACLRule) (add []v1alpha1.ACLRule, remove []v1alpha1.ACLRule) {
+	for _, rule := range desired {
+		if !containsRule(current, rule) {
+			add = append(add, rule)
+		}
+	}
+	for _, rule := range current {
+		if !containsRule(desired, rule) {
+			remove = append(remove, rule)
+		}
+	}
+	return add, remove
+}
+
+// ownedRules Returns the observed bindings that status or spec declares, leaving out the bindings of other ACLs of the same principal
+func ownedRules(observed []v1alpha1.ACLRule, status v1alpha1.ACLRule, spec v1alpha1.ACLRule) []v1alpha1.ACLRule {
+	declared := append(expandRule(status), expandRule(spec)...)
+
+	var owned []v1alpha1.ACLRule
+	for _, rule := range observed {
+		if containsRule(declared, rule) {
+			owned = append(owned, rule)
+		}
+	}
+	return owned
+}
+
+// validateOperations Checks that exactly one of Operation & Operations is set & that Operations only lists known operations. The API server
+// cannot check Operations, as legacy rules list them in lower case until they are converted
+func validateOperations(rule v1alpha1.ACLRule) error {
+	if (rule.Operation == "") == (len(rule.Operations) == 0) {
+		return errors.New(errOperationsInvalid)
+	}
+	for _, op := range rule.Operations {
+		if !knownOperation(op) {
+			return errors.Errorf(errOperationUnknown, op, strings.Join(operations, ", "))
+		}
+	}
+	return nil
+}
+
+func knownOperation(op string) bool {
+	for _, o := range operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// validateScope Checks that the environment & cluster of the bindings are set, so no call is made against an empty scope
+func validateScope(aclP v1alpha1.ACLParameters) error {
+	switch {
+	case aclP.Environment == "":
+		return errors.Errorf(errScopeMissing, "environment")
+	case aclP.Cluster == "":
+		return errors.Errorf(errScopeMissing, "cluster")
+	}
+	return nil
+}
+
+// expandRule Expands a rule into one binding per operation
+func expandRule(rule v1alpha1.ACLRule) []v1alpha1.ACLRule {
+	if len(rule.Operations) == 0 {
+		return []v1alpha1.ACLRule{rule}
+	}
+
+	rules := make([]v1alpha1.ACLRule, 0, len(rule.Operations))
+	for _, operation := range rule.Operations {
+		r := rule
+		r.Operation = operation
+		r.Operations = nil
+		rules = append(rules, r)
+	}
+	return rules
+}
+
+// expandParameters Expands ACLParameters into one set of parameters per operation
+func expandParameters(aclP v1alpha1.ACLParameters) []v1alpha1.ACLParameters {
+	rules := expandRule(aclP.ACLRule)
+	params := make([]v1alpha1.ACLParameters, 0, len(rules))
+	for _, rule := range rules {
+		params = append(params, v1alpha1.ACLParameters{ACLRule: rule, Environment: aclP.Environment, Cluster: aclP.Cluster})
+	}
+	return params
+}
+
+// collapseRules Folds the created bindings back into a single rule in the shape of spec
+func collapseRules(spec v1alpha1.ACLRule, created []v1alpha1.ACLRule) v1alpha1.ACLRule {
+	rule := created[0]
+	if len(spec.Operations) == 0 {
+		return rule
+	}
+
+	rule.Operation = ""
+	rule.Operations = make([]string, 0, len(created))
+	for _, r := range created {
+		rule.Operations = append(rule.Operations, r.Operation)
+	}
+	return rule
+}
+
+// undeclaredRules Returns the observed bindings of the principal of aclP that the Spec of none of acls declares. Only ACLs of the same
+// principal, environment & cluster as aclP can declare its bindings
+func undeclaredRules(observed []v1alpha1.ACLRule, aclP v1alpha1.ACLParameters, acls []v1alpha1.ACL) []v1alpha1.ACLRule {
+	var declared []v1alpha1.ACLRule
+	for _, a := range acls {
+		p := a.Spec.ForProvider
+		if p.Environment == aclP.Environment && p.Cluster == aclP.Cluster && p.ACLRule.Principal == aclP.ACLRule.Principal {
+			declared = append(declared, expandRule(p.ACLRule)...)
+		}
+	}
+
+	var undeclared []v1alpha1.ACLRule
+	for _, rule := range observed {
+		if !containsRule(declared, rule) {
+			undeclared = append(undeclared, rule)
+		}
+	}
+	return undeclared
+}
+
+// bindingName Returns the external name identifying the binding of aclP
+func bindingName(aclP v1alpha1.ACLParameters) externalname.ACL {
+	return externalname.ACL{
+		Environment:  aclP.Environment,
+		Cluster:      aclP.Cluster,
+		Principal:    aclP.ACLRule.Principal,
+		Permission:   aclP.ACLRule.Permission,
+		Operation:    aclP.ACLRule.Operation,
+		ResourceType: aclP.ACLRule.ResourceType,
+		PatternType:  aclP.ACLRule.PatternType,
+		ResourceName: aclP.ACLRule.ResourceName,
+	}
+}
+
+// scopeChanged Checks if the environment or cluster in Spec differs from the ones of the bindings stored in Status
+func scopeChanged(cr *v1alpha1.ACL) bool {
+	return cr.Spec.ForProvider.Environment != cr.Status.AtProvider.ACLP.Environment || cr.Spec.ForProvider.Cluster != cr.Status.AtProvider.ACLP.Cluster
+}
+
+// principalChanged Checks if the principal in Spec differs from the principal of the binding stored in Status
+func principalChanged(cr *v1alpha1.ACL) bool {
+	return cr.Status.AtProvider.ACLP.ACLRule.Principal != "" && cr.Spec.ForProvider.ACLRule.Principal != cr.Status.AtProvider.ACLP.ACLRule.Principal
+}
+
+// countBindings Sets the number of bindings an ACL declares & how many of them are among the observed rules
+func countBindings(cr *v1alpha1.ACL, observed []v1alpha1.ACLRule) {
+	desired := expandRule(cr.Spec.ForProvider.ACLRule)
+	count := 0
+	for _, rule := range desired {
+		if containsRule(observed, rule) {
+			count++
+		}
+	}
+
+	cr.Status.AtProvider.DesiredCount = len(desired)
+	cr.Status.AtProvider.ObservedCount = count
+}
+
+// Condition type & reasons of binding drift
+const (
+	TypeDrift xpv1.ConditionType = "Drift"
+
+	ReasonBindingsDrifted xpv1.ConditionReason = "BindingsDrifted"
+	ReasonNoDrift         xpv1.ConditionReason = "NoDrift"
+)
+
+const (
+	// maxDriftMessage caps the length of the drift condition message, the full drift is recorded as events
+	maxDriftMessage = 512
+	// driftPageSize is the number of bindings per drift event
+	driftPageSize = 20
+)
+
+// drift are the bindings that are missing from a cluster, & the bindings in the cluster that are not declared
+type drift struct {
+	missing []v1alpha1.ACLRule
+	extra   []v1alpha1.ACLRule
+}
+
+// driftOf Returns the drift of the observed rules from an ACL. Bindings the ACL created before & no longer declares are extra, as are the
+// unmanaged bindings to delete
+func driftOf(cr *v1alpha1.ACL, observed []v1alpha1.ACLRule, unmanaged []v1alpha1.ACLRule) drift {
+	var d drift
+
+	desired := expandRule(cr.Spec.ForProvider.ACLRule)
+	for _, rule := range desired {
+		if !containsRule(observed, rule) {
+			d.missing = append(d.missing, rule)
+		}
+	}
+	for _, rule := range expandRule(cr.Status.AtProvider.ACLP.ACLRule) {
+		if containsRule(observed, rule) && (!containsRule(desired, rule) || principalChanged(cr) || scopeChanged(cr)) {
+			d.extra = append(d.extra, rule)
+		}
+	}
+	d.extra = append(d.extra, unmanaged...)
+
+	return d
+}
+
+func (d drift) empty() bool {
+	return len(d.missing) == 0 && len(d.extra) == 0
+}
+
+// lines Returns one line per drifted binding, missing bindings first
+func (d drift) lines() []string {
+	lines := make([]string, 0, len(d.missing)+len(d.extra))
+	for _, r := range d.missing {
+		lines = append(lines, "missing "+describeRule(r))
+	}
+	for _, r := range d.extra {
+		lines = append(lines, "extra "+describeRule(r))
+	}
+	return lines
+}
+
+// summary Returns the number of missing & extra bindings followed by as many of the bindings as fit maxDriftMessage
+func (d drift) summary() string {
+	s := fmt.Sprintf("%d missing, %d extra bindings", len(d.missing), len(d.extra))
+
+	lines := d.lines()
+	sep := ": "
+	for i, l := range lines {
+		next := s + sep + l
+		if left := len(lines) - i - 1; left > 0 && len(next)+len(moreBindings(left)) > maxDriftMessage || len(next) > maxDriftMessage {
+			return s + moreBindings(len(lines)-i)
+		}
+		s, sep = next, "; "
+	}
+	return s
+}
+
+func moreBindings(n int) string {
+	return fmt.Sprintf("; and %d more", n)
+}
+
+// pages Returns the drifted bindings in pages of driftPageSize
+func (d drift) pages() []string {
+	lines := d.lines()
+
+	var pages []string
+	for start := 0; start < len(lines); start += driftPageSize {
+		end := start + driftPageSize
+		if end > len(lines) {
+			end = len(lines)
+		}
+		pages = append(pages, fmt.Sprintf("Drifted bindings %d-%d of %d: %s", start+1, end, len(lines), strings.Join(lines[start:end], "; ")))
+	}
+	return pages
+}
+
+// describeRule Returns a binding in a readable form, e.g. ALLOW READ on TOPIC orders (LITERAL) for User:sa-12345
+func describeRule(r v1alpha1.ACLRule) string {
+	return fmt.Sprintf("%s %s on %s %s (%s) for %s", r.Permission, r.Operation, r.ResourceType, resourceName(r), r.PatternType, r.Principal)
+}
+
+// BindingsDrifted indicates that the bindings of an ACL in the cluster differ from the declared bindings
+func BindingsDrifted(d drift) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDrift,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonBindingsDrifted,
+		Message:            d.summary(),
+	}
+}
+
+// NoDrift indicates that the bindings of an ACL in the cluster are the declared bindings
+func NoDrift() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDrift,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNoDrift,
+	}
+}
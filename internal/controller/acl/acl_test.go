@@ -0,0 +1,1053 @@
+package acl
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/dfds/provider-confluent/apis/acl/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/acl"
+	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/managementpolicy"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestACLRuleMatchesPatternType(t *testing.T) {
+	assert := assert.New(t)
+
+	literal := v1alpha1.ACLRule{
+		Operation:    "READ",
+		PatternType:  "LITERAL",
+		Permission:   "ALLOW",
+		Principal:    "User:sa-55555",
+		ResourceName: "orders-",
+		ResourceType: "TOPIC",
+	}
+	prefixed := literal
+	prefixed.PatternType = "PREFIXED"
+
+	assert.True(aclRuleMatches(literal, literal))
+	assert.True(aclRuleMatches(prefixed, prefixed))
+	assert.False(aclRuleMatches(literal, prefixed), "literal binding must not match prefixed binding with the same resource name")
+	assert.False(aclRuleMatches(prefixed, literal), "prefixed binding must not match literal binding with the same resource name")
+}
+
+func TestObserveRuleMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	literal := v1alpha1.ACLRule{
+		Operation:    "READ",
+		PatternType:  "LITERAL",
+		Permission:   "ALLOW",
+		Principal:    "User:sa-55555",
+		ResourceName: "orders-",
+		ResourceType: "TOPIC",
+	}
+	prefixed := literal
+	prefixed.PatternType = "PREFIXED"
+
+	// Only the literal binding exists in Confluent, spec asks for prefixed
+	statusMatched, specMatched := observeRuleMatches([]v1alpha1.ACLRule{literal}, literal, prefixed)
+	assert.True(statusMatched)
+	assert.False(specMatched, "prefixed spec must not be satisfied by a literal binding")
+
+	// Both exist
+	statusMatched, specMatched = observeRuleMatches([]v1alpha1.ACLRule{literal, prefixed}, literal, prefixed)
+	assert.True(statusMatched)
+	assert.True(specMatched)
+
+	// Only the prefixed binding exists, status holds the literal binding
+	statusMatched, specMatched = observeRuleMatches([]v1alpha1.ACLRule{prefixed}, literal, prefixed)
+	assert.False(statusMatched)
+	assert.True(specMatched)
+}
+
+type fakeACLClient struct {
+	bindings    []v1alpha1.ACLParameters
+	deleteErr   error
+	createErrOn string
+	// API calls made
+	creates int
+	deletes int
+}
+
+func (f *fakeACLClient) ACLCreate(_ context.Context, aclP v1alpha1.ACLParameters) ([]v1alpha1.ACLRule, error) {
+	f.creates++
+	if f.createErrOn != "" && aclP.ACLRule.Operation == f.createErrOn {
+		return nil, errors.New("boom")
+	}
+	// Like Confluent, cluster-scoped bindings are stored under kafka-cluster
+	if aclP.ACLRule.ResourceType == "CLUSTER" {
+		aclP.ACLRule.ResourceName = "kafka-cluster"
+	}
+	// Like Kafka, creating a binding that already exists is a no-op
+	for _, b := range f.bindings {
+		if aclRuleMatches(b.ACLRule, aclP.ACLRule) && b.Cluster == aclP.Cluster && b.Environment == aclP.Environment {
+			return []v1alpha1.ACLRule{aclP.ACLRule}, nil
+		}
+	}
+	f.bindings = append(f.bindings, aclP)
+	return []v1alpha1.ACLRule{aclP.ACLRule}, nil
+}
+
+func (f *fakeACLClient) ACLDelete(_ context.Context, aclP v1alpha1.ACLParameters) error {
+	f.deletes++
+	if f.deleteErr != nil {
+		return f.deleteErr
+	}
+	for i, b := range f.bindings {
+		if aclRuleMatches(b.ACLRule, aclP.ACLRule) && b.Cluster == aclP.Cluster && b.Environment == aclP.Environment {
+			f.bindings = append(f.bindings[:i], f.bindings[i+1:]...)
+			return nil
+		}
+	}
+	return acl.ErrBindingNotFound
+}
+
+func (f *fakeACLClient) ACLList(_ context.Context, serviceAccount string, environment string, cluster string) ([]v1alpha1.ACLRule, error) {
+	var rules []v1alpha1.ACLRule
+	for _, b := range f.bindings {
+		if b.ACLRule.Principal == "User:"+serviceAccount && b.Environment == environment && b.Cluster == cluster {
+			rules = append(rules, b.ACLRule)
+		}
+	}
+	if len(rules) == 0 {
+		return rules, errors.New(acl.ErrACLNotExistsOrInvalidServiceAccount)
+	}
+	return rules, nil
+}
+
+func TestPrincipalChange(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operation:    "READ",
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceName: "orders",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+
+	// First reconcile creates the binding for the first principal
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+	_, err = e.Create(ctx, cr)
+	assert.NoError(err)
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// Principal re-resolves to another service account
+	cr.Spec.ForProvider.ACLRule.Principal = "User:sa-22222"
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.False(obs.ResourceUpToDate, "principal change must require an update")
+
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+
+	// Old bindings are removed & new ones exist
+	_, err = fake.ACLList(ctx, "sa-11111", "env-12345", "lkc-12345")
+	assert.Error(err, "bindings for the old principal should be deleted")
+	rules, err := fake.ACLList(ctx, "sa-22222", "env-12345", "lkc-12345")
+	assert.NoError(err)
+	assert.Len(rules, 1)
+	assert.Equal("User:sa-22222", cr.Status.AtProvider.ACLP.ACLRule.Principal)
+
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+}
+
+func TestValidateOperations(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateOperations(v1alpha1.ACLRule{Operation: "READ"}))
+	assert.NoError(validateOperations(v1alpha1.ACLRule{Operations: []string{"READ", "WRITE"}}))
+	assert.Error(validateOperations(v1alpha1.ACLRule{}))
+	assert.Error(validateOperations(v1alpha1.ACLRule{Operation: "READ", Operations: []string{"WRITE"}}))
+	assert.EqualError(validateOperations(v1alpha1.ACLRule{Operations: []string{"READ", "describe-configs"}}),
+		"operation describe-configs must be one of ALTER, ALTER_CONFIGS, CLUSTER_ACTION, CREATE, DELETE, DESCRIBE, DESCRIBE_CONFIGS, IDEMPOTENT_WRITE, READ, WRITE")
+}
+
+func TestMultipleOperations(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operations:   []string{"READ", "WRITE", "DESCRIBE"},
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceName: "orders",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+
+	// Each operation is created as a distinct binding
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+	rules, err := fake.ACLList(ctx, "sa-11111", "env-12345", "lkc-12345")
+	assert.NoError(err)
+	assert.Len(rules, 3)
+	assert.Equal([]string{"READ", "WRITE", "DESCRIBE"}, cr.Status.AtProvider.ACLP.ACLRule.Operations)
+	assert.Empty(cr.Status.AtProvider.ACLP.ACLRule.Operation)
+
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// A missing binding is detected
+	assert.NoError(fake.ACLDelete(ctx, expandParameters(cr.Spec.ForProvider)[1]))
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+
+	// Dropping an operation replaces the bindings
+	cr.Spec.ForProvider.ACLRule.Operations = []string{"READ"}
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	rules, err = fake.ACLList(ctx, "sa-11111", "env-12345", "lkc-12345")
+	assert.NoError(err)
+	assert.Len(rules, 1)
+
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// All bindings are deleted
+	assert.NoError(e.Delete(ctx, cr))
+	_, err = fake.ACLList(ctx, "sa-11111", "env-12345", "lkc-12345")
+	assert.Error(err)
+}
+
+type fakeServiceAccountClient struct {
+	serviceaccount.IClient
+	ids []string
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountByID(_ context.Context, id string) (serviceaccount.ServiceAccount, error) {
+	for _, i := range f.ids {
+		if i == id {
+			return serviceaccount.ServiceAccount{ID: id}, nil
+		}
+	}
+	return serviceaccount.ServiceAccount{}, serviceaccount.ErrNotFound
+}
+
+func TestDeletedPrincipal(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	defer func(enabled bool) { CheckPrincipals = enabled }(CheckPrincipals)
+
+	fake := &fakeACLClient{}
+	saFake := &fakeServiceAccountClient{ids: []string{"sa-11111"}}
+	e := &external{service: fake, saService: saFake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operation:    "READ",
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceName: "orders",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+
+	// Check disabled, no condition is set
+	CheckPrincipals = false
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.NotEqual(corev1.ConditionTrue, cr.GetCondition(TypeDegraded).Status)
+
+	// Principal exists
+	CheckPrincipals = true
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(corev1.ConditionFalse, cr.GetCondition(TypeDegraded).Status)
+
+	// Service account deleted out-of-band
+	saFake.ids = nil
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	degraded := cr.GetCondition(TypeDegraded)
+	assert.Equal(corev1.ConditionTrue, degraded.Status)
+	assert.Equal(ReasonPrincipalNotFound, degraded.Reason)
+	assert.Contains(degraded.Message, "sa-11111")
+}
+
+func TestDeleteToleratesRemovedBindings(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operations:   []string{"READ", "WRITE", "DESCRIBE"},
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceName: "orders",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+
+	// One of three bindings was already removed by another actor
+	assert.NoError(fake.ACLDelete(ctx, expandParameters(cr.Spec.ForProvider)[1]))
+
+	assert.NoError(e.Delete(ctx, cr))
+	_, err = fake.ACLList(ctx, "sa-11111", "env-12345", "lkc-12345")
+	assert.Error(err, "remaining bindings should be deleted")
+
+	// Deleting again succeeds
+	assert.NoError(e.Delete(ctx, cr))
+
+	// Genuine failures are returned
+	fake.deleteErr = errors.New("forbidden")
+	assert.EqualError(e.Delete(ctx, cr), "forbidden")
+}
+
+func TestDeleteAppliedBindings(t *testing.T) {
+	rule := v1alpha1.ACLRule{
+		Operations:   []string{"READ", "WRITE"},
+		PatternType:  "LITERAL",
+		Permission:   "ALLOW",
+		Principal:    "User:sa-11111",
+		ResourceName: "orders",
+		ResourceType: "TOPIC",
+	}
+
+	cases := map[string]func(p *v1alpha1.ACLParameters){
+		"PrincipalChanged":  func(p *v1alpha1.ACLParameters) { p.ACLRule.Principal = "User:sa-22222" },
+		"ScopeChanged":      func(p *v1alpha1.ACLParameters) { p.Cluster = "lkc-67890" },
+		"OperationsChanged": func(p *v1alpha1.ACLParameters) { p.ACLRule.Operations = []string{"READ", "DESCRIBE"} },
+	}
+
+	for name, edit := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := context.Background()
+
+			fake := &fakeACLClient{}
+			e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+			cr := &v1alpha1.ACL{}
+			cr.Spec.ForProvider = v1alpha1.ACLParameters{ACLRule: rule, Environment: "env-12345", Cluster: "lkc-12345"}
+			_, err := e.Create(ctx, cr)
+			assert.NoError(err)
+
+			// Spec was edited, & the change was only partially applied before the ACL was deleted
+			edit(&cr.Spec.ForProvider)
+			_, err = fake.ACLCreate(ctx, expandParameters(cr.Spec.ForProvider)[1])
+			assert.NoError(err)
+
+			assert.NoError(e.Delete(ctx, cr))
+			assert.Empty(fake.bindings, "applied & partially applied bindings should be deleted")
+
+			obs, err := e.Observe(ctx, cr)
+			assert.NoError(err)
+			assert.False(obs.ResourceExists)
+		})
+	}
+}
+
+func TestClusterScope(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operation:    "ALTER",
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceType: "CLUSTER",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+	assert.Equal("kafka-cluster", cr.Status.AtProvider.ACLP.ACLRule.ResourceName)
+
+	// The binding listed under kafka-cluster satisfies a spec without resource name
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.True(obs.ResourceUpToDate)
+
+	// As does a spec naming kafka-cluster explicitly
+	cr.Spec.ForProvider.ACLRule.ResourceName = "kafka-cluster"
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// A topic binding of the same name is a different binding
+	topic := cr.Spec.ForProvider.ACLRule
+	topic.ResourceType = "TOPIC"
+	assert.False(aclRuleMatches(cr.Spec.ForProvider.ACLRule, topic))
+
+	assert.NoError(e.Delete(ctx, cr))
+	assert.Empty(fake.bindings)
+}
+
+func TestValidateScope(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operation:    "READ",
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceName: "orders",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+	}
+
+	// A binding without cluster is rejected before any call to Confluent
+	_, err := e.Create(ctx, cr)
+	assert.EqualError(err, "acl cluster must be set, bindings are scoped to an environment & cluster")
+	assert.Empty(fake.bindings)
+
+	cr.Spec.ForProvider.Cluster = "lkc-12345"
+	cr.Spec.ForProvider.Environment = ""
+	_, err = e.Update(ctx, cr)
+	assert.EqualError(err, "acl environment must be set, bindings are scoped to an environment & cluster")
+
+	assert.NoError(validateScope(v1alpha1.ACLParameters{Environment: "env-12345", Cluster: "lkc-12345"}))
+}
+
+func TestAtomicRollback(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	newACL := func(atomic bool) *v1alpha1.ACL {
+		cr := &v1alpha1.ACL{}
+		cr.Spec.ForProvider = v1alpha1.ACLParameters{
+			ACLRule: v1alpha1.ACLRule{
+				Operations:   []string{"READ", "WRITE", "DESCRIBE"},
+				PatternType:  "LITERAL",
+				Permission:   "ALLOW",
+				Principal:    "User:sa-11111",
+				ResourceName: "orders",
+				ResourceType: "TOPIC",
+			},
+			Environment: "env-12345",
+			Cluster:     "lkc-12345",
+			Atomic:      atomic,
+		}
+		return cr
+	}
+
+	// Without atomic, the bindings created before the failure are left behind
+	fake := &fakeACLClient{createErrOn: "DESCRIBE"}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	_, err := e.Create(ctx, newACL(false))
+	assert.EqualError(err, "boom")
+	assert.Len(fake.bindings, 2)
+
+	// The third binding fails, the first two are rolled back
+	fake = &fakeACLClient{createErrOn: "DESCRIBE"}
+	e = &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	_, err = e.Create(ctx, newACL(true))
+	assert.EqualError(err, "created bindings were rolled back: boom")
+	assert.Empty(fake.bindings)
+
+	// Bindings that existed before the reconcile are not rolled back
+	fake = &fakeACLClient{createErrOn: "DESCRIBE"}
+	e = &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	_, err = fake.ACLCreate(ctx, expandParameters(newACL(true).Spec.ForProvider)[0])
+	assert.NoError(err)
+	_, err = e.Create(ctx, newACL(true))
+	assert.Error(err)
+	assert.Len(fake.bindings, 1)
+	assert.Equal("READ", fake.bindings[0].ACLRule.Operation)
+
+	// A failed rollback is surfaced along with the bindings left behind
+	fake = &fakeACLClient{createErrOn: "DESCRIBE", deleteErr: errors.New("unavailable")}
+	e = &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	_, err = e.Create(ctx, newACL(true))
+	assert.EqualError(err, "rolling back created bindings failed, bindings left behind for operations READ, WRITE: boom")
+	assert.Len(fake.bindings, 2)
+}
+
+type fakeRecorder struct {
+	events []event.Event
+	// bindings at the time of each event
+	bindings []int
+	fake     *fakeACLClient
+}
+
+func (r *fakeRecorder) Event(_ runtime.Object, e event.Event) {
+	r.events = append(r.events, e)
+	r.bindings = append(r.bindings, len(r.fake.bindings))
+}
+
+func (r *fakeRecorder) WithAnnotations(...string) event.Recorder { return r }
+
+func TestDeleteUnmanaged(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	binding := func(operation string) v1alpha1.ACLParameters {
+		return v1alpha1.ACLParameters{
+			ACLRule:     v1alpha1.ACLRule{Operation: operation, PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders", ResourceType: "TOPIC"},
+			Environment: "env-12345",
+			Cluster:     "lkc-12345",
+		}
+	}
+
+	// WRITE was created by another tool, DESCRIBE is declared by another ACL of the principal
+	fake := &fakeACLClient{bindings: []v1alpha1.ACLParameters{binding("WRITE"), binding("DESCRIBE")}}
+	other := v1alpha1.ACL{}
+	other.Spec.ForProvider = binding("DESCRIBE")
+	kube := test.NewMockClient()
+	kube.MockList = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		list.(*v1alpha1.ACLList).Items = []v1alpha1.ACL{other}
+		return nil
+	}
+	recorder := &fakeRecorder{fake: fake}
+	e := &external{service: fake, kube: kube, recorder: recorder}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = binding("READ")
+	cr.Spec.ForProvider.UnmanagedBindings = UnmanagedBindingsDelete
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate, "an unmanaged binding must require an update")
+
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	if assert.Len(fake.bindings, 2) {
+		assert.Equal("DESCRIBE", fake.bindings[0].ACLRule.Operation)
+		assert.Equal("READ", fake.bindings[1].ACLRule.Operation)
+	}
+
+	// The unmanaged binding is reported as drift by the observe & the event of its deletion is recorded before the binding is deleted
+	if assert.Len(recorder.events, 2) {
+		assert.Equal(reasonBindingsDrifted, recorder.events[0].Reason)
+		assert.Equal(event.TypeNormal, recorder.events[1].Type)
+		assert.Equal("Deleting unmanaged binding env-12345/lkc-12345/User:sa-11111/ALLOW/WRITE/TOPIC/LITERAL/orders", recorder.events[1].Message)
+		assert.Equal(3, recorder.bindings[1])
+	}
+
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// Unmanaged bindings are kept by default
+	cr.Spec.ForProvider.UnmanagedBindings = ""
+	_, err = fake.ACLCreate(ctx, binding("WRITE"))
+	assert.NoError(err)
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+}
+
+var allOperations = []string{"ALTER", "ALTER_CONFIGS", "CREATE", "DELETE", "DESCRIBE", "DESCRIBE_CONFIGS", "IDEMPOTENT_WRITE", "READ", "WRITE"}
+
+func newManyOperationsACL() *v1alpha1.ACL {
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider = v1alpha1.ACLParameters{
+		ACLRule: v1alpha1.ACLRule{
+			Operations:   append([]string(nil), allOperations[:8]...),
+			PatternType:  "LITERAL",
+			Permission:   "ALLOW",
+			Principal:    "User:sa-11111",
+			ResourceName: "orders",
+			ResourceType: "TOPIC",
+		},
+		Environment: "env-12345",
+		Cluster:     "lkc-12345",
+	}
+	return cr
+}
+
+func TestUpdateOnlyChangedBindings(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	cr := newManyOperationsACL()
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(8, fake.creates)
+
+	// Reordering the operations changes nothing
+	fake.creates = 0
+	ops := cr.Spec.ForProvider.ACLRule.Operations
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// Swapping one operation creates & deletes a single binding
+	ops[0] = allOperations[8]
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(1, fake.creates)
+	assert.Equal(1, fake.deletes)
+	assert.Len(fake.bindings, 8)
+	assert.Equal(ops, cr.Status.AtProvider.ACLP.ACLRule.Operations)
+
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// Moving the bindings to another cluster replaces all of them
+	fake.creates, fake.deletes = 0, 0
+	cr.Spec.ForProvider.Cluster = "lkc-67890"
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(8, fake.creates)
+	assert.Equal(8, fake.deletes)
+}
+
+func TestDiffRules(t *testing.T) {
+	rule := func(operation string) v1alpha1.ACLRule {
+		return v1alpha1.ACLRule{Operation: operation, PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders", ResourceType: "TOPIC"}
+	}
+	prefixed := rule("READ")
+	prefixed.PatternType = "PREFIXED"
+
+	cases := map[string]struct {
+		desired []v1alpha1.ACLRule
+		current []v1alpha1.ACLRule
+		add     []v1alpha1.ACLRule
+		remove  []v1alpha1.ACLRule
+	}{
+		"Unchanged": {
+			desired: []v1alpha1.ACLRule{rule("READ"), rule("WRITE")},
+			current: []v1alpha1.ACLRule{rule("WRITE"), rule("READ")},
+		},
+		"AddOnly": {
+			desired: []v1alpha1.ACLRule{rule("READ"), rule("WRITE"), rule("DESCRIBE")},
+			current: []v1alpha1.ACLRule{rule("READ")},
+			add:     []v1alpha1.ACLRule{rule("WRITE"), rule("DESCRIBE")},
+		},
+		"RemoveOnly": {
+			desired: []v1alpha1.ACLRule{rule("READ")},
+			current: []v1alpha1.ACLRule{rule("READ"), rule("WRITE"), rule("DESCRIBE")},
+			remove:  []v1alpha1.ACLRule{rule("WRITE"), rule("DESCRIBE")},
+		},
+		"Mixed": {
+			desired: []v1alpha1.ACLRule{rule("READ"), rule("ALTER")},
+			current: []v1alpha1.ACLRule{rule("READ"), rule("WRITE")},
+			add:     []v1alpha1.ACLRule{rule("ALTER")},
+			remove:  []v1alpha1.ACLRule{rule("WRITE")},
+		},
+		"PatternTypeChanged": {
+			desired: []v1alpha1.ACLRule{prefixed},
+			current: []v1alpha1.ACLRule{rule("READ")},
+			add:     []v1alpha1.ACLRule{prefixed},
+			remove:  []v1alpha1.ACLRule{rule("READ")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			add, remove := diffRules(tc.desired, tc.current)
+			assert.Equal(t, tc.add, add)
+			assert.Equal(t, tc.remove, remove)
+		})
+	}
+}
+
+func TestUpdateDiff(t *testing.T) {
+	cases := map[string]struct {
+		operations []string
+		creates    int
+		deletes    int
+	}{
+		"AddOnly": {
+			operations: []string{"READ", "WRITE", "DESCRIBE", "ALTER"},
+			creates:    1,
+		},
+		"RemoveOnly": {
+			operations: []string{"READ", "DESCRIBE"},
+			deletes:    1,
+		},
+		"Mixed": {
+			operations: []string{"READ", "ALTER", "DELETE"},
+			creates:    2,
+			deletes:    2,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := context.Background()
+
+			fake := &fakeACLClient{}
+			e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+			cr := newManyOperationsACL()
+			cr.Spec.ForProvider.ACLRule.Operations = []string{"READ", "WRITE", "DESCRIBE"}
+			_, err := e.Create(ctx, cr)
+			assert.NoError(err)
+
+			// A binding of another ACL of the same principal is left alone
+			other := newManyOperationsACL().Spec.ForProvider
+			other.ACLRule.Operations = nil
+			other.ACLRule.Operation = "READ"
+			other.ACLRule.ResourceName = "payments"
+			fake.bindings = append(fake.bindings, other)
+
+			fake.creates, fake.deletes = 0, 0
+			cr.Spec.ForProvider.ACLRule.Operations = tc.operations
+			_, err = e.Update(ctx, cr)
+			assert.NoError(err)
+			assert.Equal(tc.creates, fake.creates)
+			assert.Equal(tc.deletes, fake.deletes)
+			assert.Len(fake.bindings, len(tc.operations)+1)
+			assert.Equal(tc.operations, cr.Status.AtProvider.ACLP.ACLRule.Operations)
+
+			obs, err := e.Observe(ctx, cr)
+			assert.NoError(err)
+			assert.True(obs.ResourceUpToDate)
+		})
+	}
+}
+
+func TestAdoptObserveOnly(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// The bindings exist already, but the ACL never created them so its status is empty
+	fake := &fakeACLClient{}
+	for _, p := range expandParameters(newManyOperationsACL().Spec.ForProvider) {
+		fake.bindings = append(fake.bindings, p)
+	}
+
+	cr := newManyOperationsACL()
+	cr.SetAnnotations(map[string]string{managementpolicy.AnnotationKeyManagementPolicy: string(managementpolicy.ObserveOnly)})
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	ec, err := managementpolicy.Connecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return e, nil
+	})).Connect(ctx, cr)
+	assert.NoError(err)
+
+	obs, err := ec.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(cr.Spec.ForProvider, cr.Status.AtProvider.ACLP)
+	assert.Equal(corev1.ConditionTrue, cr.GetCondition(managementpolicy.TypeUpToDate).Status)
+
+	// Bindings that do not all exist are not adopted, & ObserveOnly refuses to create them
+	cr = newManyOperationsACL()
+	cr.SetAnnotations(map[string]string{managementpolicy.AnnotationKeyManagementPolicy: string(managementpolicy.ObserveOnly)})
+	cr.Spec.ForProvider.ACLRule.Operations = append(cr.Spec.ForProvider.ACLRule.Operations, allOperations[8])
+	obs, err = ec.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+	assert.Empty(cr.Status.AtProvider.ACLP.ACLRule.Principal)
+	_, err = ec.Create(ctx, cr)
+	assert.Error(err)
+
+	assert.Equal(0, fake.creates)
+	assert.Equal(0, fake.deletes)
+}
+
+func BenchmarkUpdateOneOperation(b *testing.B) {
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: event.NewNopRecorder()}
+	cr := newManyOperationsACL()
+	if _, err := e.Create(ctx, cr); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// Alternate the last operation, leaving the other bindings alone
+		cr.Spec.ForProvider.ACLRule.Operations[7] = allOperations[7+i%2]
+		if _, err := e.Update(ctx, cr); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(fake.creates+fake.deletes-8)/float64(b.N), "calls/op")
+}
+
+func TestObserveCounts(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	statusWrites := 0
+	kube := test.NewMockClient()
+	kube.MockStatusUpdate = func(context.Context, client.Object, ...client.UpdateOption) error {
+		statusWrites++
+		return nil
+	}
+
+	fake := &fakeACLClient{}
+	e := &external{service: fake, kube: kube, recorder: event.NewNopRecorder()}
+	cr := newManyOperationsACL()
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+
+	// The counts are only set in memory, the managed reconciler persists them
+	statusWrites = 0
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(8, cr.Status.AtProvider.DesiredCount)
+	assert.Equal(8, cr.Status.AtProvider.ObservedCount)
+	assert.Equal(0, statusWrites)
+
+	// A binding deleted out-of-band shows up in the counts
+	fake.bindings = fake.bindings[1:]
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+	assert.Equal(8, cr.Status.AtProvider.DesiredCount)
+	assert.Equal(7, cr.Status.AtProvider.ObservedCount)
+}
+
+func TestObserveDrift(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeACLClient{}
+	recorder := &fakeRecorder{fake: fake}
+	e := &external{service: fake, kube: test.NewMockClient(), recorder: recorder}
+	cr := newManyOperationsACL()
+	_, err := e.Create(ctx, cr)
+	assert.NoError(err)
+
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(ReasonNoDrift, cr.Status.GetCondition(TypeDrift).Reason)
+
+	// A binding deleted out-of-band is reported as missing
+	fake.bindings = fake.bindings[1:]
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	cond := cr.Status.GetCondition(TypeDrift)
+	assert.Equal(ReasonBindingsDrifted, cond.Reason)
+	assert.Equal("1 missing, 0 extra bindings: missing ALLOW "+allOperations[0]+" on TOPIC orders (LITERAL) for User:sa-11111", cond.Message)
+	if assert.Len(recorder.events, 1) {
+		assert.Equal(reasonBindingsDrifted, recorder.events[0].Reason)
+	}
+
+	// An unchanged drift records no further events
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Len(recorder.events, 1)
+}
+
+func TestDriftSummary(t *testing.T) {
+	assert := assert.New(t)
+
+	rule := v1alpha1.ACLRule{Operation: "READ", PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceType: "TOPIC"}
+	var d drift
+	for i := 0; i < 50; i++ {
+		r := rule
+		r.ResourceName = fmt.Sprintf("topic-%d", i)
+		d.missing = append(d.missing, r)
+	}
+	extra := rule
+	extra.ResourceName = "orders"
+	d.extra = []v1alpha1.ACLRule{extra}
+
+	// The message is capped & the bindings that did not fit are counted
+	s := d.summary()
+	assert.LessOrEqual(len(s), maxDriftMessage)
+	assert.True(strings.HasPrefix(s, "50 missing, 1 extra bindings: missing ALLOW READ on TOPIC topic-0 (LITERAL) for User:sa-11111; "))
+	assert.Regexp(`; and \d+ more$`, s)
+
+	// The detail is paginated
+	pages := d.pages()
+	if assert.Len(pages, 3) {
+		assert.True(strings.HasPrefix(pages[0], "Drifted bindings 1-20 of 51: "))
+		assert.True(strings.HasPrefix(pages[2], "Drifted bindings 41-51 of 51: "))
+		assert.True(strings.HasSuffix(pages[2], "extra ALLOW READ on TOPIC orders (LITERAL) for User:sa-11111"))
+	}
+}
+
+func TestLegacyRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	legacy := []v1alpha1.ACLParameters{
+		{ACLRule: v1alpha1.ACLRule{Operations: []string{"READ", "DESCRIBE"}, LegacyACLRule: v1alpha1.LegacyACLRule{
+			Action: "ALLOW", ServiceAccount: "sa-1", Topic: "orders", Environment: "env-1", Cluster: "lkc-1"}}},
+		{ACLRule: v1alpha1.ACLRule{Operations: []string{"READ"}, LegacyACLRule: v1alpha1.LegacyACLRule{
+			Action: "DENY", ServiceAccount: "sa-1", ConsumerGroup: "orders-", Prefix: "true", Environment: "env-1", Cluster: "lkc-1"}}},
+		{ACLRule: v1alpha1.ACLRule{Operations: []string{"IDEMPOTENT_WRITE"}, LegacyACLRule: v1alpha1.LegacyACLRule{
+			Action: "ALLOW", ServiceAccount: "sa-1", ClusterScope: "true", Environment: "env-1", Cluster: "lkc-1"}}},
+	}
+	current := []v1alpha1.ACLParameters{
+		{Environment: "env-1", Cluster: "lkc-1", ACLRule: v1alpha1.ACLRule{Operations: []string{"READ", "DESCRIBE"},
+			PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-1", ResourceName: "orders", ResourceType: "TOPIC"}},
+		{Environment: "env-1", Cluster: "lkc-1", ACLRule: v1alpha1.ACLRule{Operations: []string{"READ"},
+			PatternType: "PREFIXED", Permission: "DENY", Principal: "User:sa-1", ResourceName: "orders-", ResourceType: "CONSUMER_GROUP"}},
+		{Environment: "env-1", Cluster: "lkc-1", ACLRule: v1alpha1.ACLRule{Operations: []string{"IDEMPOTENT_WRITE"},
+			PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-1", ResourceName: "kafka-cluster", ResourceType: "CLUSTER"}},
+	}
+
+	for i := range legacy {
+		assert.True(legacy[i].ACLRule.IsLegacy())
+
+		converted := legacy[i]
+		assert.NoError(converted.ConvertLegacy())
+		assert.Equal(current[i], converted)
+		assert.False(converted.ACLRule.IsLegacy())
+
+		back, err := converted.ToLegacy()
+		assert.NoError(err)
+		assert.Equal(legacy[i], back)
+
+		again, err := current[i].ToLegacy()
+		assert.NoError(err)
+		assert.NoError(again.ConvertLegacy())
+		assert.Equal(current[i], again)
+	}
+}
+
+func TestConvertLegacy(t *testing.T) {
+	assert := assert.New(t)
+
+	// Values are normalized the way the CLI flags were accepted, & legacy fields take precedence when re-applied over converted ones
+	p := v1alpha1.ACLParameters{Environment: "env-old", ACLRule: v1alpha1.ACLRule{
+		Operation: "WRITE", Operations: []string{"read", "describe-configs"}, PatternType: "PREFIXED", ResourceName: "payments", ResourceType: "TOPIC",
+		LegacyACLRule: v1alpha1.LegacyACLRule{Action: "allow", ServiceAccount: "User:sa-1", Topic: "orders", Prefix: "false", Environment: "env-1", Cluster: "lkc-1"}}}
+	assert.NoError(p.ConvertLegacy())
+	assert.Equal(v1alpha1.ACLParameters{Environment: "env-1", Cluster: "lkc-1", ACLRule: v1alpha1.ACLRule{
+		Operations: []string{"READ", "DESCRIBE_CONFIGS"}, PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-1", ResourceName: "orders", ResourceType: "TOPIC"}}, p)
+
+	// Rules in the current shape are left alone
+	current := v1alpha1.ACLParameters{Environment: "env-1", Cluster: "lkc-1", ACLRule: v1alpha1.ACLRule{Operation: "READ", ResourceType: "TOPIC"}}
+	unchanged := current
+	assert.NoError(unchanged.ConvertLegacy())
+	assert.Equal(current, unchanged)
+
+	both := v1alpha1.ACLParameters{ACLRule: v1alpha1.ACLRule{LegacyACLRule: v1alpha1.LegacyACLRule{Topic: "orders", ConsumerGroup: "orders"}}}
+	assert.Error(both.ConvertLegacy())
+	assert.Error((&v1alpha1.ACLParameters{ACLRule: v1alpha1.ACLRule{LegacyACLRule: v1alpha1.LegacyACLRule{Action: "ALLOW"}}}).ConvertLegacy())
+
+	_, err := v1alpha1.ACLParameters{ACLRule: v1alpha1.ACLRule{ResourceType: "TRANSACTIONAL_ID"}}.ToLegacy()
+	assert.Error(err)
+}
+
+func TestLegacyConverter(t *testing.T) {
+	assert := assert.New(t)
+
+	var updated *v1alpha1.ACL
+	kube := &test.MockClient{MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		updated = obj.(*v1alpha1.ACL)
+		return nil
+	}}
+	l := &legacyConverter{kube: kube}
+
+	cr := &v1alpha1.ACL{Spec: v1alpha1.ACLSpec{ForProvider: v1alpha1.ACLParameters{ACLRule: v1alpha1.ACLRule{Operations: []string{"READ"},
+		LegacyACLRule: v1alpha1.LegacyACLRule{Action: "ALLOW", ServiceAccount: "sa-1", Topic: "orders", Environment: "env-1", Cluster: "lkc-1"}}}}}
+	assert.NoError(l.Initialize(context.Background(), cr))
+	if assert.NotNil(updated) {
+		assert.Equal("User:sa-1", updated.Spec.ForProvider.ACLRule.Principal)
+		assert.Equal("env-1", updated.Spec.ForProvider.Environment)
+		assert.False(updated.Spec.ForProvider.ACLRule.IsLegacy())
+	}
+
+	// ACLs in the current shape are not written to
+	updated = nil
+	assert.NoError(l.Initialize(context.Background(), cr))
+	assert.Nil(updated)
+
+	kube.MockUpdate = test.NewMockUpdateFn(errors.New("boom"))
+	cr.Spec.ForProvider.ACLRule.Topic = "payments"
+	assert.EqualError(l.Initialize(context.Background(), cr), errConvertLegacy+": boom")
+}
+
+func TestResolvePrincipal(t *testing.T) {
+	assert := assert.New(t)
+
+	sa := &saapi.ServiceAccount{}
+	sa.SetName("payments")
+	sa.SetConditions(xpv1.Available())
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			sa.DeepCopyInto(obj.(*saapi.ServiceAccount))
+			return nil
+		}),
+	}
+
+	cr := &v1alpha1.ACL{}
+	cr.Spec.ForProvider.ACLRule.PrincipalRef = &xpv1.Reference{Name: "payments"}
+
+	// A service account without an ID doesn't exist yet
+	assert.Error(cr.ResolveReferences(context.Background(), kube))
+	assert.Empty(cr.Spec.ForProvider.ACLRule.Principal)
+
+	sa.Status.AtProvider.ID = "sa-55555"
+	assert.NoError(cr.ResolveReferences(context.Background(), kube))
+	assert.Equal("User:sa-55555", cr.Spec.ForProvider.ACLRule.Principal)
+}
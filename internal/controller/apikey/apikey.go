@@ -28,6 +28,8 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -41,6 +43,15 @@ import (
 	"github.com/dfds/provider-confluent/internal/clients"
 	"github.com/dfds/provider-confluent/internal/clients/apikey"
 	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
 )
 
 const (
@@ -48,23 +59,25 @@ const (
 	errTrackPCUsage                              = "cannot track ProviderConfig usage"
 	errGetPC                                     = "cannot get ProviderConfig"
 	errGetCreds                                  = "cannot get credentials"
+	errGetCABundle                               = "cannot get CA bundle"
 	errNewClient                                 = "cannot create new Service"
 	errAuthCredentials                           = "invalid client credentials"
 	errBlockingCreationServiceAccountDoNotExists = "creation blocked service-account referenced do not exists"
 	errExternalNameNotPresent                    = "external name is not present"
 	errDestructiveUpdateNotAllowed               = "cannot update resource. DeletionPolicy is set to Orphan, but update is destructive"
+	errDeleteConnectionSecret                    = "cannot delete connection secret"
 )
 
 var (
-	createAndConvertClientFunc = func(clientCreds []byte, apiCreds clients.APICredentials) (interface{}, interface{}, error) { //nolint
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, interface{}, error) { //nolint
 		credParts := strings.Split(string(clientCreds), ":")
 
 		if len(credParts) != 2 {
 			return nil, nil, errors.New(errAuthCredentials)
 		}
 
-		cClient := clients.NewClient()
-		authErr := cClient.Authenticate(credParts[0], credParts[1])
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
 
 		if authErr != nil {
 			return nil, nil, authErr
@@ -74,7 +87,13 @@ var (
 			APICredentials: apiCreds,
 		}
 
-		return apikey.NewClient(srConfig).(interface{}), serviceaccount.NewClient(serviceaccount.Config(srConfig)).(interface{}), nil
+		saConfig := serviceaccount.Config{
+			APICredentials: apiCreds,
+			MaxRetries:     serviceaccount.DefaultMaxRetries,
+			BaseDelay:      serviceaccount.DefaultBaseDelay,
+		}
+
+		return apikey.NewClient(srConfig).(interface{}), serviceaccount.NewClient(saConfig).(interface{}), nil
 	}
 )
 
@@ -86,21 +105,26 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
 	}
 
+	failures := retry.NewTracker()
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.APIKeyGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(failures.Connecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: createAndConvertClientFunc}),
+			newServiceFn: createAndConvertClientFunc})),
 		managed.WithLogger(l.WithValues("controller", name)),
-		managed.WithInitializers(),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithReferenceResolver(dependency.ResolveReferences(managed.NewAPISimpleReferenceResolver(mgr.GetClient()))),
+		managed.WithInitializers(providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.APIKey).Spec.ForProvider.Environment })),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o).
 		For(&v1alpha1.APIKey{}).
-		Complete(r)
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.APIKeyList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.APIKey{} }, failures.Reconciler(r))))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -108,7 +132,7 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(creds []byte, apiCreds clients.APICredentials) (interface{}, interface{}, error)
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, interface{}, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -126,12 +150,27 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errTrackPCUsage)
 	}
 
+	// Only service account owners are managed resources that can be waited for
+	if owner, ownerType, err := resolveOwner(cr); err == nil && ownerType == v1alpha1.OwnerTypeServiceAccount {
+		var ref *xpv1.Reference
+		if cr.Spec.ForProvider.Owner != nil {
+			ref = cr.Spec.ForProvider.Owner.ServiceAccountRef
+		}
+		deps, err := dependency.ServiceAccounts(ctx, c.kube, ref, owner)
+		if err != nil {
+			return nil, err
+		}
+		if err := dependency.Gate(cr, deps...); err != nil {
+			return nil, err
+		}
+	}
+
 	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	clientCredentialData, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -146,7 +185,18 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		}
 	}
 
-	svc, saSvc, err := c.newServiceFn(clientCredentialData, apiCredentials)
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, saSvc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
@@ -171,12 +221,16 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	}
 
+	if _, _, err := resolveOwner(cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	// Support for importing resource using exernal name
-	key, exists := externalNameHelper(cr)
+	key, exists := externalname.APIKey(cr)
 
 	// Confluent cloud
 	var client = c.service.(apikey.IClient)
-	observe, err := client.GetAPIKeyByKey(key)
+	observe, err := client.GetAPIKeyByKey(ctx, key)
 
 	// Check if resource require creation
 	create, err := observeCreateResource(cr, exists, err)
@@ -196,6 +250,10 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
+	// Report the actual owner & scope, so drift is visible in the status
+	observeOwner(cr, observe)
+	observeResource(cr, observe)
+
 	// Check if resource require update
 	if observeUpdateResource(cr, observe) {
 		return managed.ExternalObservation{
@@ -206,9 +264,6 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.SetConditions(xpv1.Available())
-	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalObservation{}, err
-	}
 
 	return managed.ExternalObservation{
 		ResourceExists:    true,
@@ -228,17 +283,24 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, err
 	}
 
-	// Need to check if service account is valid otherwise it will return key pair with God like access (bug stems from confluent cli)
-	var saClient = c.saService.(serviceaccount.IClient)
-	_, err := saClient.ServiceAccountByID(cr.Spec.ForProvider.ServiceAccount)
+	owner, ownerType, err := resolveOwner(cr)
 	if err != nil {
-		if err.Error() == serviceaccount.ErrNotExists {
-			return managed.ExternalCreation{}, errors.New(errBlockingCreationServiceAccountDoNotExists)
-		}
 		return managed.ExternalCreation{}, err
 	}
 
-	key, exists := externalNameHelper(cr)
+	// The secret is only returned once, so the mapping is validated before the key is created
+	if _, _, err := connectionSecretKeys(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := validateScope(cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.checkServiceAccountOwner(ctx, owner, ownerType); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	key, exists := externalname.APIKey(cr)
 
 	var createIsImport bool
 
@@ -247,7 +309,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	var client = c.service.(apikey.IClient)
 
 	if exists {
-		observe, err := client.GetAPIKeyByKey(key)
+		observe, err := client.GetAPIKeyByKey(ctx, key)
 		createIsImport, err = createResourceIsImport(err)
 		if err != nil {
 			return managed.ExternalCreation{}, err
@@ -255,27 +317,29 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		if createIsImport {
 			cr.Status.AtProvider.Key = observe.Key
 			cr.Status.AtProvider.Environment = cr.Spec.ForProvider.Environment
-			cr.Status.AtProvider.Resource = cr.Spec.ForProvider.Resource
-			cr.Status.AtProvider.ServiceAccount = cr.Spec.ForProvider.ServiceAccount
-			conn = managed.ConnectionDetails{xpv1.ResourceCredentialsSecretUserKey: []byte(observe.Key),
-				xpv1.ResourceCredentialsSecretPasswordKey: []byte("YOU NEED TO SUPPLY YOUR OWN SECRET FOR IMPORTED RESOURCES"),
+			setResourceStatus(cr, cr.Spec.ForProvider.Resource)
+			setOwnerStatus(cr, owner, ownerType)
+			observeOwner(cr, observe)
+			conn, err = connectionDetails(cr, observe.Key, "YOU NEED TO SUPPLY YOUR OWN SECRET FOR IMPORTED RESOURCES")
+			if err != nil {
+				return managed.ExternalCreation{}, err
 			}
 		}
 	}
 
 	if !createIsImport {
-		out, err := client.APIKeyCreate(cr.Spec.ForProvider.Resource, cr.Spec.ForProvider.Description, cr.Spec.ForProvider.ServiceAccount, cr.Spec.ForProvider.Environment)
+		out, err := client.APIKeyCreate(ctx, cr.Spec.ForProvider.Resource, cr.Spec.ForProvider.Description, owner, cr.Spec.ForProvider.Environment)
 		if err != nil {
 			return managed.ExternalCreation{}, err
 		}
 		meta.SetExternalName(cr, out.Key)
 		cr.Status.AtProvider.Key = out.Key
 		cr.Status.AtProvider.Environment = cr.Spec.ForProvider.Environment
-		cr.Status.AtProvider.Resource = cr.Spec.ForProvider.Resource
-		cr.Status.AtProvider.ServiceAccount = cr.Spec.ForProvider.ServiceAccount
-		conn = managed.ConnectionDetails{
-			xpv1.ResourceCredentialsSecretUserKey:     []byte(out.Key),
-			xpv1.ResourceCredentialsSecretPasswordKey: []byte(out.Secret),
+		setResourceStatus(cr, cr.Spec.ForProvider.Resource)
+		setOwnerStatus(cr, owner, ownerType)
+		conn, err = connectionDetails(cr, out.Key, out.Secret)
+		if err != nil {
+			return managed.ExternalCreation{}, err
 		}
 	}
 
@@ -283,6 +347,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, err
 	}
 
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -297,14 +365,14 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	// Use external name since we set in create
-	key, exists := externalNameHelper(cr)
+	key, exists := externalname.APIKey(cr)
 	if !exists {
 		return managed.ExternalUpdate{}, errors.New(errExternalNameNotPresent)
 	}
 
 	// Confluent cloud
 	var client = c.service.(apikey.IClient)
-	observed, err := client.GetAPIKeyByKey(key)
+	observed, err := client.GetAPIKeyByKey(ctx, key)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
@@ -314,44 +382,55 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		if !destructiveActionsAllowed(cr.GetDeletionPolicy()) {
 			return managed.ExternalUpdate{}, errors.New(errDestructiveUpdateNotAllowed)
 		}
-		// Need to check if service account is valid otherwise it will return key pair with God like access (bug stems from confluent cli)
-		var saClient = c.saService.(serviceaccount.IClient)
-		_, err := saClient.ServiceAccountByID(cr.Spec.ForProvider.ServiceAccount)
+		owner, ownerType, err := resolveOwner(cr)
 		if err != nil {
-			if err.Error() == serviceaccount.ErrNotExists {
-				return managed.ExternalUpdate{}, errors.New(errBlockingCreationServiceAccountDoNotExists)
-			}
+			return managed.ExternalUpdate{}, err
+		}
+		if _, _, err := connectionSecretKeys(cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if err := validateScope(cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		if err := c.checkServiceAccountOwner(ctx, owner, ownerType); err != nil {
 			return managed.ExternalUpdate{}, err
 		}
 
 		// Continue with desctructive action
-		err = client.APIKeyDelete(key)
+		err = client.APIKeyDelete(ctx, key)
 		if err != nil {
 			return managed.ExternalUpdate{}, err
 		}
 
-		out, err := client.APIKeyCreate(cr.Spec.ForProvider.Resource, cr.Spec.ForProvider.Description, cr.Spec.ForProvider.ServiceAccount, cr.Spec.ForProvider.Environment)
+		out, err := client.APIKeyCreate(ctx, cr.Spec.ForProvider.Resource, cr.Spec.ForProvider.Description, owner, cr.Spec.ForProvider.Environment)
 		if err != nil {
 			return managed.ExternalUpdate{}, err
 		}
 		cr.Status.AtProvider.Key = out.Key
 		cr.Status.AtProvider.Environment = cr.Spec.ForProvider.Environment
-		cr.Status.AtProvider.Resource = cr.Spec.ForProvider.Resource
-		cr.Status.AtProvider.ServiceAccount = cr.Spec.ForProvider.ServiceAccount
-		conn := managed.ConnectionDetails{
-			xpv1.ResourceCredentialsSecretUserKey:     []byte(out.Key),
-			xpv1.ResourceCredentialsSecretPasswordKey: []byte(out.Secret),
+		setResourceStatus(cr, cr.Spec.ForProvider.Resource)
+		setOwnerStatus(cr, owner, ownerType)
+		conn, err := connectionDetails(cr, out.Key, out.Secret)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
 		}
 		if err := c.kube.Status().Update(ctx, cr); err != nil {
 			return managed.ExternalUpdate{}, err
 		}
+		if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
 		return managed.ExternalUpdate{ConnectionDetails: conn}, nil
 	}
 	// Continue with non-destructive action
-	err = client.APIKeyUpdate(key, cr.Spec.ForProvider.Description)
+	err = client.APIKeyUpdate(ctx, key, cr.Spec.ForProvider.Description)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 	return managed.ExternalUpdate{}, nil
 }
 
@@ -368,10 +447,48 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	var client = c.service.(apikey.IClient)
 
-	err := client.APIKeyDelete(cr.Status.AtProvider.Key)
+	err := client.APIKeyDelete(ctx, cr.Status.AtProvider.Key)
 	if err != nil {
 		return err
 	}
 
+	// The connection secret is garbage collected through its owner reference, but only once the APIKey itself is gone. Removing it right
+	// away keeps consumers from using the deleted key while the APIKey is still being deleted
+	return c.deleteConnectionSecret(ctx, cr)
+}
+
+// deleteConnectionSecret Deletes the connection secret of an APIKey. A secret that is gone or not controlled by the APIKey is left alone
+func (c *external) deleteConnectionSecret(ctx context.Context, cr *v1alpha1.APIKey) error {
+	ref := cr.GetWriteConnectionSecretToReference()
+	if ref == nil {
+		return nil
+	}
+
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errDeleteConnectionSecret)
+	}
+	if !metav1.IsControlledBy(s, cr) {
+		return nil
+	}
+
+	return errors.Wrap(resource.IgnoreNotFound(c.kube.Delete(ctx, s)), errDeleteConnectionSecret)
+}
+
+// checkServiceAccountOwner Checks that the service account owning a key exists, otherwise the Confluent CLI returns a key pair with God like access. User owners are not checked
+func (c *external) checkServiceAccountOwner(ctx context.Context, owner string, ownerType string) error {
+	if ownerType != v1alpha1.OwnerTypeServiceAccount {
+		return nil
+	}
+
+	var saClient = c.saService.(serviceaccount.IClient)
+	_, err := saClient.ServiceAccountByID(ctx, owner)
+	if err != nil {
+		if serviceaccount.IsNotFound(err) {
+			return errors.New(errBlockingCreationServiceAccountDoNotExists)
+		}
+		return err
+	}
+
 	return nil
 }
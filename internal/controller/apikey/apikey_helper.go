@@ -1,7 +1,9 @@
 package apikey
 
 import (
-	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
 	"github.com/dfds/provider-confluent/internal/clients/apikey"
 	"github.com/pkg/errors"
@@ -9,8 +11,24 @@ import (
 
 const (
 	errCouldImportResource = "given external name does match any existing keys in this environment and/or cluster"
+	errOwnerMissing        = "owner is missing, set either a service account or a user"
+	errOwnerConflict       = "a service account owner and a user owner are mutually exclusive"
+	errServiceAccountDiff  = "serviceAccount and owner.serviceAccount refer to different service accounts"
+	errKeyMappingCollision = "connectionSecretKeyMapping maps the API key & secret to the same key %q"
+	errScopeMismatch       = "resource %s is not in scope %s, expected an ID starting with %s"
+)
+
+const (
+	userIDPrefix           = "u-"
+	serviceAccountIDPrefix = "sa-"
 )
 
+// scopePrefixes are the ID prefixes of the resources of each scope
+var scopePrefixes = map[string]string{
+	v1alpha1.ScopeKafka:          "lkc-",
+	v1alpha1.ScopeSchemaRegistry: "lsrc-",
+}
+
 func observeCreateResource(ak *v1alpha1.APIKey, exists bool, err error) (bool, error) {
 	if err != nil {
 		if err.Error() == apikey.ErrNotExists {
@@ -42,21 +60,13 @@ func observeUpdateResource(ak *v1alpha1.APIKey, akm apikey.Metadata) bool {
 		return true
 	}
 
-	if !compare.ServiceAccountMatch {
+	if !compare.OwnerMatch {
 		return true
 	}
 
 	return false
 }
 
-func externalNameHelper(ak *v1alpha1.APIKey) (string, bool) {
-	extName := meta.GetExternalName(ak)
-	if extName != "" {
-		return extName, true
-	}
-	return ak.Status.AtProvider.Key, false
-}
-
 func createResourceIsImport(err error) (bool, error) {
 	if err != nil {
 		if err.Error() == apikey.ErrNotExists {
@@ -72,3 +82,116 @@ func updateResourceDestructive(ak *v1alpha1.APIKey, akm apikey.Metadata) bool {
 
 	return compare.isDestructive()
 }
+
+// resolveOwner Returns the ID & type of the desired owner of an APIKey. The deprecated serviceAccount field is treated as a service account owner
+func resolveOwner(ak *v1alpha1.APIKey) (string, string, error) {
+	sa := ak.Spec.ForProvider.ServiceAccount
+	var user string
+
+	if o := ak.Spec.ForProvider.Owner; o != nil {
+		if o.ServiceAccount != "" {
+			if sa != "" && sa != o.ServiceAccount {
+				return "", "", errors.New(errServiceAccountDiff)
+			}
+			sa = o.ServiceAccount
+		}
+		user = o.User
+	}
+
+	switch {
+	case sa != "" && user != "":
+		return "", "", errors.New(errOwnerConflict)
+	case sa != "":
+		return sa, v1alpha1.OwnerTypeServiceAccount, nil
+	case user != "":
+		return user, v1alpha1.OwnerTypeUser, nil
+	default:
+		return "", "", errors.New(errOwnerMissing)
+	}
+}
+
+// ownerType Returns the owner type of a Confluent resource ID, or an empty string when it is neither a user nor a service account
+func ownerType(id string) string {
+	switch {
+	case strings.HasPrefix(id, serviceAccountIDPrefix):
+		return v1alpha1.OwnerTypeServiceAccount
+	case strings.HasPrefix(id, userIDPrefix):
+		return v1alpha1.OwnerTypeUser
+	default:
+		return ""
+	}
+}
+
+// observeOwner Records the owner of an APIKey as reported by Confluent Cloud
+func observeOwner(ak *v1alpha1.APIKey, akm apikey.Metadata) {
+	if akm.OwnerResourceID == "" {
+		return
+	}
+	ak.Status.AtProvider.Owner = akm.OwnerResourceID
+	ak.Status.AtProvider.OwnerType = ownerType(akm.OwnerResourceID)
+}
+
+// observeResource Records the resource an APIKey is scoped to as reported by Confluent Cloud
+func observeResource(ak *v1alpha1.APIKey, akm apikey.Metadata) {
+	if akm.ResourceID == "" {
+		return
+	}
+	setResourceStatus(ak, akm.ResourceID)
+}
+
+// setResourceStatus Records the resource an APIKey is scoped to & the scope of the resource
+func setResourceStatus(ak *v1alpha1.APIKey, resource string) {
+	ak.Status.AtProvider.Resource = resource
+	ak.Status.AtProvider.Scope = resourceScope(resource)
+}
+
+// resourceScope Returns the scope of a resource ID, or an empty string when it is neither a Kafka cluster nor a schema registry
+func resourceScope(resource string) string {
+	for scope, prefix := range scopePrefixes {
+		if strings.HasPrefix(resource, prefix) {
+			return scope
+		}
+	}
+	return ""
+}
+
+// validateScope Checks that the resource of an APIKey is in its scope, so a key meant for a schema registry is never created for a Kafka
+// cluster or the other way around
+func validateScope(ak *v1alpha1.APIKey) error {
+	scope := ak.Spec.ForProvider.Scope
+	if scope == "" || resourceScope(ak.Spec.ForProvider.Resource) == scope {
+		return nil
+	}
+	return errors.Errorf(errScopeMismatch, ak.Spec.ForProvider.Resource, scope, scopePrefixes[scope])
+}
+
+// setOwnerStatus Records the owner an APIKey was created for
+func setOwnerStatus(ak *v1alpha1.APIKey, owner string, ownerType string) {
+	ak.Status.AtProvider.Owner = owner
+	ak.Status.AtProvider.OwnerType = ownerType
+	ak.Status.AtProvider.ServiceAccount = ""
+	if ownerType == v1alpha1.OwnerTypeServiceAccount {
+		ak.Status.AtProvider.ServiceAccount = owner
+	}
+}
+
+// connectionSecretKeys Returns the connection secret keys of the API key & secret, defaulting to username & password
+func connectionSecretKeys(ak *v1alpha1.APIKey) (string, string, error) {
+	key, secret := ak.ConnectionSecretKeys()
+	if key == secret {
+		return "", "", errors.Errorf(errKeyMappingCollision, key)
+	}
+	return key, secret, nil
+}
+
+// connectionDetails Returns the connection details of an API key, written under the keys of the connection secret key mapping
+func connectionDetails(ak *v1alpha1.APIKey, key string, secret string) (managed.ConnectionDetails, error) {
+	keyName, secretName, err := connectionSecretKeys(ak)
+	if err != nil {
+		return nil, err
+	}
+	return managed.ConnectionDetails{
+		keyName:    []byte(key),
+		secretName: []byte(secret),
+	}, nil
+}
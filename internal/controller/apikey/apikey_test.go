@@ -1,35 +1,23 @@
 package apikey
 
 import (
+	"context"
 	"testing"
 
 	v1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
 	"github.com/dfds/provider-confluent/internal/clients/apikey"
+	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func TestExternalNameHelper(t *testing.T) {
-	assert := assert.New(t)
-
-	// No external name
-	ak := v1alpha1.APIKey{}
-	ak.Status.AtProvider.Key = "name"
-	name, exists := externalNameHelper(&ak)
-	assert.Equal(ak.Status.AtProvider.Key, name)
-	assert.False(exists)
-
-	// With external name
-	extName := make(map[string]string)
-	extName["crossplane.io/external-name"] = "extname"
-	ak.SetAnnotations(extName)
-	name, exists = externalNameHelper(&ak)
-	assert.NotEqual(ak.Name, name, "external name not used")
-	assert.Equal("extname", name, "external name not used")
-	assert.True(exists)
-}
-
 func TestObserveCreateResource(t *testing.T) {
 	assert := assert.New(t)
 
@@ -163,3 +151,288 @@ func TestDestructiveIsAllowed(t *testing.T) {
 	dp = "blabla"
 	assert.False(destructiveActionsAllowed(dp))
 }
+
+func TestResolveOwner(t *testing.T) {
+	assert := assert.New(t)
+
+	// Deprecated service account field
+	ak := v1alpha1.APIKey{}
+	ak.Spec.ForProvider.ServiceAccount = "sa-55555"
+	owner, ownerType, err := resolveOwner(&ak)
+	assert.NoError(err)
+	assert.Equal("sa-55555", owner)
+	assert.Equal(v1alpha1.OwnerTypeServiceAccount, ownerType)
+
+	// Same service account in both fields
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55555"}
+	owner, ownerType, err = resolveOwner(&ak)
+	assert.NoError(err)
+	assert.Equal("sa-55555", owner)
+	assert.Equal(v1alpha1.OwnerTypeServiceAccount, ownerType)
+
+	// Different service accounts in both fields
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55556"}
+	_, _, err = resolveOwner(&ak)
+	assert.EqualError(err, errServiceAccountDiff)
+
+	// User owner
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	owner, ownerType, err = resolveOwner(&ak)
+	assert.NoError(err)
+	assert.Equal("u-12345", owner)
+	assert.Equal(v1alpha1.OwnerTypeUser, ownerType)
+
+	// User and service account owners are mutually exclusive
+	ak.Spec.ForProvider.Owner.ServiceAccount = "sa-55555"
+	_, _, err = resolveOwner(&ak)
+	assert.EqualError(err, errOwnerConflict)
+
+	ak.Spec.ForProvider.Owner.ServiceAccount = ""
+	ak.Spec.ForProvider.ServiceAccount = "sa-55555"
+	_, _, err = resolveOwner(&ak)
+	assert.EqualError(err, errOwnerConflict)
+
+	// No owner
+	ak = v1alpha1.APIKey{}
+	_, _, err = resolveOwner(&ak)
+	assert.EqualError(err, errOwnerMissing)
+}
+
+func TestOwnerType(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(v1alpha1.OwnerTypeServiceAccount, ownerType("sa-55555"))
+	assert.Equal(v1alpha1.OwnerTypeUser, ownerType("u-12345"))
+	assert.Equal("", ownerType("lkc-yyyyy"))
+}
+
+func TestObserveOwnerDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	// Service account owner matches the reported owner
+	ak := v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55555"}
+	akm := apikey.Metadata{OwnerResourceID: "sa-55555"}
+	assert.False(observeUpdateResource(&ak, akm), "no update required when owner match")
+
+	// Key reported for a user while a service account is desired
+	akm.OwnerResourceID = "u-12345"
+	assert.True(observeUpdateResource(&ak, akm), "update required when owner do not match")
+	assert.True(updateResourceDestructive(&ak, akm), "updates to owner is destructive")
+
+	observeOwner(&ak, akm)
+	assert.Equal("u-12345", ak.Status.AtProvider.Owner)
+	assert.Equal(v1alpha1.OwnerTypeUser, ak.Status.AtProvider.OwnerType)
+
+	// User owner matches the reported owner
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	assert.False(observeUpdateResource(&ak, akm), "no update required when owner match")
+
+	// Key reported for a service account while a user is desired
+	akm.OwnerResourceID = "sa-55555"
+	assert.True(updateResourceDestructive(&ak, akm), "updates to owner is destructive")
+}
+
+func TestObserveResourceDrift(t *testing.T) {
+	assert := assert.New(t)
+
+	ak := v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55555"}
+	ak.Spec.ForProvider.Resource = "lkc-12345"
+	ak.Status.AtProvider.Resource = "lkc-12345"
+	akm := apikey.Metadata{OwnerResourceID: "sa-55555", ResourceID: "lkc-12345", ResourceType: "kafka"}
+	assert.False(observeUpdateResource(&ak, akm), "no update required when scope match")
+
+	// Key scoped to another cluster than recorded in the status
+	akm.ResourceID = "lkc-67890"
+	assert.True(observeUpdateResource(&ak, akm), "update required when scope do not match")
+	assert.True(updateResourceDestructive(&ak, akm), "updates to scope is destructive")
+
+	observeResource(&ak, akm)
+	assert.Equal("lkc-67890", ak.Status.AtProvider.Resource)
+
+	// Scope not reported falls back to the status
+	observeResource(&ak, apikey.Metadata{})
+	assert.Equal("lkc-67890", ak.Status.AtProvider.Resource)
+}
+
+func TestScope(t *testing.T) {
+	assert := assert.New(t)
+
+	ak := v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Resource = "lsrc-12345"
+	assert.NoError(validateScope(&ak), "no scope is not validated")
+
+	ak.Spec.ForProvider.Scope = v1alpha1.ScopeSchemaRegistry
+	assert.NoError(validateScope(&ak))
+
+	ak.Spec.ForProvider.Resource = "lkc-12345"
+	assert.EqualError(validateScope(&ak), "resource lkc-12345 is not in scope SchemaRegistry, expected an ID starting with lsrc-")
+
+	// The scope is recorded along with the resource
+	observeResource(&ak, apikey.Metadata{ResourceID: "lsrc-12345"})
+	assert.Equal(v1alpha1.ScopeSchemaRegistry, ak.Status.AtProvider.Scope)
+	observeResource(&ak, apikey.Metadata{ResourceID: "lkc-12345"})
+	assert.Equal(v1alpha1.ScopeKafka, ak.Status.AtProvider.Scope)
+	observeResource(&ak, apikey.Metadata{ResourceID: "cloud"})
+	assert.Empty(ak.Status.AtProvider.Scope)
+}
+
+type fakeAPIKeyClient struct {
+	apikey.IClient
+	owner string
+}
+
+func (f *fakeAPIKeyClient) APIKeyCreate(_ context.Context, resource string, description string, owner string, environment string) (apikey.APIKey, error) {
+	f.owner = owner
+	return apikey.APIKey{Key: "KEY", Secret: "SECRET"}, nil
+}
+
+type fakeServiceAccountClient struct {
+	serviceaccount.IClient
+	lookups int
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountByID(_ context.Context, id string) (serviceaccount.ServiceAccount, error) {
+	f.lookups++
+	if id == "sa-55555" {
+		return serviceaccount.ServiceAccount{ID: id}, nil
+	}
+	return serviceaccount.ServiceAccount{}, serviceaccount.ErrNotFound
+}
+
+func TestCreateOwner(t *testing.T) {
+	assert := assert.New(t)
+
+	// Service account owner is checked before creation
+	akClient := &fakeAPIKeyClient{}
+	saClient := &fakeServiceAccountClient{}
+	e := &external{service: akClient, saService: saClient, kube: test.NewMockClient()}
+
+	ak := v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55555"}
+	_, err := e.Create(context.Background(), &ak)
+	assert.NoError(err)
+	assert.Equal("sa-55555", akClient.owner)
+	assert.Equal(1, saClient.lookups)
+	assert.Equal("sa-55555", ak.Status.AtProvider.ServiceAccount)
+	assert.Equal(v1alpha1.OwnerTypeServiceAccount, ak.Status.AtProvider.OwnerType)
+
+	// Missing service account blocks creation
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55556"}
+	_, err = e.Create(context.Background(), &ak)
+	assert.EqualError(err, errBlockingCreationServiceAccountDoNotExists)
+
+	// User owner is passed as is
+	akClient = &fakeAPIKeyClient{}
+	saClient = &fakeServiceAccountClient{}
+	e = &external{service: akClient, saService: saClient, kube: test.NewMockClient()}
+
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	_, err = e.Create(context.Background(), &ak)
+	assert.NoError(err)
+	assert.Equal("u-12345", akClient.owner)
+	assert.Equal(0, saClient.lookups)
+	assert.Equal("", ak.Status.AtProvider.ServiceAccount)
+	assert.Equal("u-12345", ak.Status.AtProvider.Owner)
+	assert.Equal(v1alpha1.OwnerTypeUser, ak.Status.AtProvider.OwnerType)
+
+	// Conflicting owners are rejected
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{ServiceAccount: "sa-55555", User: "u-12345"}
+	_, err = e.Create(context.Background(), &ak)
+	assert.EqualError(err, errOwnerConflict)
+}
+
+func TestConnectionSecretKeyMapping(t *testing.T) {
+	assert := assert.New(t)
+
+	akClient := &fakeAPIKeyClient{}
+	saClient := &fakeServiceAccountClient{}
+	e := &external{service: akClient, saService: saClient, kube: test.NewMockClient()}
+
+	// Default scheme
+	ak := v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	cre, err := e.Create(context.Background(), &ak)
+	assert.NoError(err)
+	assert.Equal([]byte("KEY"), cre.ConnectionDetails[v1.ResourceCredentialsSecretUserKey])
+	assert.Equal([]byte("SECRET"), cre.ConnectionDetails[v1.ResourceCredentialsSecretPasswordKey])
+
+	// Mapped keys, unset keys keep their default
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	ak.Spec.ForProvider.ConnectionSecretKeyMapping = &v1alpha1.ConnectionSecretKeyMapping{Key: "api-key"}
+	cre, err = e.Create(context.Background(), &ak)
+	assert.NoError(err)
+	assert.Len(cre.ConnectionDetails, 2)
+	assert.Equal([]byte("KEY"), cre.ConnectionDetails["api-key"])
+	assert.Equal([]byte("SECRET"), cre.ConnectionDetails[v1.ResourceCredentialsSecretPasswordKey])
+
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	ak.Spec.ForProvider.ConnectionSecretKeyMapping = &v1alpha1.ConnectionSecretKeyMapping{Key: "sasl.username", Secret: "sasl.password"}
+	cre, err = e.Create(context.Background(), &ak)
+	assert.NoError(err)
+	assert.Equal([]byte("KEY"), cre.ConnectionDetails["sasl.username"])
+	assert.Equal([]byte("SECRET"), cre.ConnectionDetails["sasl.password"])
+
+	// Colliding keys are rejected before the key is created
+	akClient.owner = ""
+	ak = v1alpha1.APIKey{}
+	ak.Spec.ForProvider.Owner = &v1alpha1.APIKeyOwner{User: "u-12345"}
+	ak.Spec.ForProvider.ConnectionSecretKeyMapping = &v1alpha1.ConnectionSecretKeyMapping{Key: "password"}
+	_, err = e.Create(context.Background(), &ak)
+	assert.EqualError(err, `connectionSecretKeyMapping maps the API key & secret to the same key "password"`)
+	assert.Empty(akClient.owner)
+}
+
+func (f *fakeAPIKeyClient) APIKeyDelete(_ context.Context, key string) error {
+	return nil
+}
+
+func TestDeleteConnectionSecret(t *testing.T) {
+	assert := assert.New(t)
+
+	ak := v1alpha1.APIKey{}
+	ak.SetUID("1234")
+	ak.Status.AtProvider.Key = "KEY"
+	ak.Spec.WriteConnectionSecretToReference = &v1.SecretReference{Name: "kafka-credentials", Namespace: "crossplane-system"}
+
+	var deleted []string
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name != "kafka-credentials" {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+		}
+		// The publisher makes the APIKey the controller of its connection secret
+		obj.SetName(key.Name)
+		obj.SetNamespace(key.Namespace)
+		obj.SetOwnerReferences([]metav1.OwnerReference{meta.AsController(meta.TypedReferenceTo(&ak, v1alpha1.APIKeyGroupVersionKind))})
+		return nil
+	}
+	kube.MockDelete = func(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+		deleted = append(deleted, obj.GetNamespace()+"/"+obj.GetName())
+		return nil
+	}
+	e := &external{service: &fakeAPIKeyClient{}, kube: kube}
+
+	assert.NoError(e.Delete(context.Background(), &ak))
+	assert.Equal([]string{"crossplane-system/kafka-credentials"}, deleted)
+
+	// A secret that is already gone is not an error
+	deleted = nil
+	ak.Spec.WriteConnectionSecretToReference.Name = "gone"
+	assert.NoError(e.Delete(context.Background(), &ak))
+	assert.Empty(deleted)
+
+	// A secret controlled by something else is left alone
+	other := v1alpha1.APIKey{}
+	other.SetUID("5678")
+	other.Spec.WriteConnectionSecretToReference = &v1.SecretReference{Name: "kafka-credentials", Namespace: "crossplane-system"}
+	assert.NoError(e.Delete(context.Background(), &other))
+	assert.Empty(deleted)
+}
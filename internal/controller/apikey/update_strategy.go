@@ -8,10 +8,10 @@ import (
 
 // Compare comparison helper struct
 type Compare struct {
-	DescriptionMatch    bool
-	EnvironmentMatch    bool
-	ResourceMatch       bool
-	ServiceAccountMatch bool
+	DescriptionMatch bool
+	EnvironmentMatch bool
+	ResourceMatch    bool
+	OwnerMatch       bool
 }
 
 func updateStrategy(ak *v1alpha1.APIKey, akm apikey.Metadata) Compare {
@@ -24,12 +24,27 @@ func updateStrategy(ak *v1alpha1.APIKey, akm apikey.Metadata) Compare {
 		compare.EnvironmentMatch = true
 	}
 
-	if ak.Spec.ForProvider.Resource == ak.Status.AtProvider.Resource {
+	// Compare against the resource the key is scoped to in Confluent Cloud, so keys scoped to another resource are caught
+	observedResource := akm.ResourceID
+	if observedResource == "" {
+		observedResource = ak.Status.AtProvider.Resource
+	}
+	if ak.Spec.ForProvider.Resource == observedResource {
 		compare.ResourceMatch = true
 	}
 
-	if ak.Spec.ForProvider.ServiceAccount == ak.Status.AtProvider.ServiceAccount {
-		compare.ServiceAccountMatch = true
+	// Compare against the owner reported by Confluent Cloud, so keys created for another owner are caught
+	observedOwner := akm.OwnerResourceID
+	if observedOwner == "" {
+		observedOwner = ak.Status.AtProvider.Owner
+	}
+	if observedOwner == "" {
+		observedOwner = ak.Status.AtProvider.ServiceAccount
+	}
+
+	// An invalid owner is rejected by Observe before it is compared
+	if owner, _, _ := resolveOwner(ak); owner == observedOwner {
+		compare.OwnerMatch = true
 	}
 
 	return compare
@@ -46,7 +61,7 @@ func (ac *Compare) isDestructive() bool {
 		destructive = true
 	}
 
-	if !ac.ServiceAccountMatch {
+	if !ac.OwnerMatch {
 		destructive = true
 	}
 
@@ -0,0 +1,117 @@
+package certificateauthority
+
+import (
+	"crypto/sha1" //nolint:gosec
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/certificateauthority/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/certificateauthority"
+)
+
+// observation Returns the CertificateAuthority status matching a certificate authority in Confluent Cloud
+func observation(ca certificateauthority.CertificateAuthority) v1alpha1.CertificateAuthorityObservation {
+	return v1alpha1.CertificateAuthorityObservation{
+		ID:              ca.ID,
+		DisplayName:     ca.Name,
+		Description:     ca.Description,
+		Fingerprints:    ca.Fingerprints,
+		ExpirationDates: ca.ExpirationDates,
+		CRLURL:          ca.CRLURL,
+		State:           ca.State,
+	}
+}
+
+// parseChain Returns the certificates of a PEM encoded certificate chain. Anything but at least one well-formed X.509 certificate is
+// refused, so a broken chain never reaches Confluent
+func parseChain(chain string) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+
+	rest := []byte(chain)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, errors.Errorf(errUnexpectedPEMBlock, block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrapf(err, errParseCertificate, len(certs)+1)
+		}
+		certs = append(certs, cert)
+	}
+
+	if strings.TrimSpace(string(rest)) != "" {
+		return nil, errors.New(errTrailingData)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New(errNoCertificates)
+	}
+
+	return certs, nil
+}
+
+// fingerprints Returns the SHA-1 fingerprints of the certificates of a PEM encoded certificate chain, the way Confluent Cloud reports them
+func fingerprints(chain string) ([]string, error) {
+	certs, err := parseChain(chain)
+	if err != nil {
+		return nil, err
+	}
+
+	fps := make([]string, len(certs))
+	for i, cert := range certs {
+		sum := sha1.Sum(cert.Raw) //nolint:gosec
+		fps[i] = strings.ToUpper(hex.EncodeToString(sum[:]))
+	}
+
+	return fps, nil
+}
+
+// chainUpToDate Checks if the certificate chain of the CertificateAuthority parameters is the one observed in Confluent Cloud. The chain
+// itself is never returned by Confluent, so their fingerprints are compared instead
+func chainUpToDate(chain string, observed []string) bool {
+	fps, err := fingerprints(chain)
+	if err != nil || len(fps) != len(observed) {
+		return false
+	}
+	for i := range fps {
+		if !strings.EqualFold(fps[i], observed[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// upToDate Checks if a certificate authority in Confluent Cloud matches the CertificateAuthority parameters
+func upToDate(cp v1alpha1.CertificateAuthorityParameters, ca certificateauthority.CertificateAuthority) bool {
+	return cp.DisplayName == ca.Name && cp.Description == ca.Description && cp.CRLURL == ca.CRLURL &&
+		chainUpToDate(cp.CertificateChain, ca.Fingerprints)
+}
+
+// changes Returns the update turning the observed certificate authority into the desired one & whether there is anything to update
+func changes(cp v1alpha1.CertificateAuthorityParameters, observed v1alpha1.CertificateAuthorityObservation) (certificateauthority.Update, bool) {
+	var u certificateauthority.Update
+	if cp.DisplayName != observed.DisplayName {
+		u.Name = cp.DisplayName
+	}
+	if cp.Description != observed.Description {
+		description := cp.Description
+		u.Description = &description
+	}
+	if !chainUpToDate(cp.CertificateChain, observed.Fingerprints) {
+		u.CertificateChain = cp.CertificateChain
+	}
+	if cp.CRLURL != observed.CRLURL {
+		crlURL := cp.CRLURL
+		u.CRLURL = &crlURL
+	}
+
+	return u, u.Name != "" || u.Description != nil || u.CertificateChain != "" || u.CRLURL != nil
+}
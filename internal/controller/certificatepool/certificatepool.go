@@ -0,0 +1,352 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package certificatepool
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/certificatepool/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/certificatepool"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
+)
+
+const (
+	errNotMyType       = "managed resource is not a CertificatePool custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetCABundle     = "cannot get CA bundle"
+	errNewClient       = "cannot create new Service"
+	errAuthCredentials = "invalid client credentials"
+
+	errNoCertificateAuthority = "certificate authority must be set, or resolvable from its reference or selector"
+	errMovePool               = "cannot remove certificate pool %s from certificate authority %s"
+)
+
+var (
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
+		credParts := strings.Split(string(clientCreds), ":")
+
+		if len(credParts) != 2 {
+			return nil, errors.New(errAuthCredentials)
+		}
+
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
+
+		if authErr != nil {
+			return nil, authErr
+		}
+
+		cpConfig := certificatepool.Config{
+			APICredentials: apiCreds,
+		}
+
+		return certificatepool.NewClient(cpConfig).(interface{}), nil
+	}
+)
+
+// Setup adds a controller that reconciles CertificatePool managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.CertificatePoolGroupKind)
+
+	o := controller.Options{
+		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+	}
+
+	failures := retry.NewTracker()
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CertificatePoolGroupVersionKind),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: createAndConvertClientFunc})),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithReferenceResolver(dependency.ResolveReferences(managed.NewAPISimpleReferenceResolver(mgr.GetClient()))),
+		managed.WithInitializers(),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.CertificatePool{}).
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.CertificatePoolList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.CertificatePool{} }, failures.Reconciler(r))))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.CertificatePool)
+	if !ok {
+		return nil, errors.New(errNotMyType)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	var apiCredentials clients.APICredentials
+
+	for _, value := range pc.Spec.APICredentials {
+		if value.Identifier == v1alpha1.SchemeGroupVersion.Identifier() {
+			apiCredentials = value
+
+			break
+		}
+	}
+
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// A 'client' used to connect to the external resource API. In practice this
+	// would be something like an AWS SDK client.
+	service interface{}
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CertificatePool)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMyType)
+	}
+
+	// The external name is the ID of the certificate pool, which is only known once it is created or set to import an existing one
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+	if err := externalname.CheckID(cr, cr.Status.AtProvider.ID); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Confluent
+	p := provider(cr)
+	if p == "" {
+		return managed.ExternalObservation{}, errors.New(errNoCertificateAuthority)
+	}
+
+	var client = c.service.(certificatepool.IClient)
+	cp, err := client.CertificatePoolDescribe(ctx, p, id)
+
+	if err != nil {
+		if certificatepool.IsNotFound(err) {
+			return managed.ExternalObservation{
+				ResourceExists:    false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, nil // returning nil because we want create on not found
+		}
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, err
+	}
+
+	cr.Status.AtProvider = observation(p, cp)
+	cr.Status.SetConditions(xpv1.Available())
+
+	// Diff
+	if !upToDate(cr.Spec.ForProvider, p, cp) {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CertificatePool)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMyType)
+	}
+
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.create(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CertificatePool)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMyType)
+	}
+
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	var client = c.service.(certificatepool.IClient)
+	id := meta.GetExternalName(cr)
+	p := provider(cr)
+
+	// A certificate pool belongs to its certificate authority for good, so moving it to another one recreates it there
+	if p != cr.Spec.ForProvider.CertificateAuthority {
+		if err := client.CertificatePoolDelete(ctx, p, id); err != nil && !certificatepool.IsNotFound(err) {
+			return managed.ExternalUpdate{}, errors.Wrapf(err, errMovePool, id, p)
+		}
+		if err := c.create(ctx, cr); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	} else if u, changed := changes(cr.Spec.ForProvider, cr.Status.AtProvider); changed {
+		if err := client.CertificatePoolUpdate(ctx, p, id, u); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CertificatePool)
+	if !ok {
+		return errors.New(errNotMyType)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	var client = c.service.(certificatepool.IClient)
+	if err := client.CertificatePoolDelete(ctx, provider(cr), meta.GetExternalName(cr)); err != nil && !certificatepool.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
+
+// create Creates the certificate pool in its certificate authority & records its ID & observation
+func (c *external) create(ctx context.Context, cr *v1alpha1.CertificatePool) error {
+	var client = c.service.(certificatepool.IClient)
+	pp := cr.Spec.ForProvider
+	cp, err := client.CertificatePoolCreate(ctx, pp.CertificateAuthority, pp.DisplayName, pp.Description, pp.ExternalIdentifier, pp.Filter)
+	if err != nil {
+		return err
+	}
+
+	meta.SetExternalName(cr, cp.ID)
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return err
+	}
+
+	cr.Status.AtProvider = observation(pp.CertificateAuthority, cp)
+	return c.kube.Status().Update(ctx, cr)
+}
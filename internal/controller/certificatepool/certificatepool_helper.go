@@ -0,0 +1,64 @@
+package certificatepool
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/certificatepool/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/certificatepool"
+)
+
+// observation Returns the CertificatePool status matching a certificate pool of the provider certificate authority in Confluent Cloud
+func observation(provider string, cp certificatepool.CertificatePool) v1alpha1.CertificatePoolObservation {
+	return v1alpha1.CertificatePoolObservation{
+		ID:                   cp.ID,
+		Principal:            cp.Principal(),
+		CertificateAuthority: provider,
+		DisplayName:          cp.Name,
+		Description:          cp.Description,
+		ExternalIdentifier:   cp.ExternalIdentifier,
+		Filter:               cp.Filter,
+	}
+}
+
+// validate Checks that the certificate authority of the CertificatePool is known, which it is not until its reference is resolved
+func validate(pp v1alpha1.CertificatePoolParameters) error {
+	if pp.CertificateAuthority == "" {
+		return errors.New(errNoCertificateAuthority)
+	}
+	return nil
+}
+
+// provider Returns the certificate authority the certificate pool is looked up in. That is the one it was last observed in, as a pool
+// moved to another certificate authority is only recreated there by an update
+func provider(cr *v1alpha1.CertificatePool) string {
+	if cr.Status.AtProvider.CertificateAuthority != "" {
+		return cr.Status.AtProvider.CertificateAuthority
+	}
+	return cr.Spec.ForProvider.CertificateAuthority
+}
+
+// upToDate Checks if a certificate pool of the provider certificate authority in Confluent Cloud matches the CertificatePool parameters
+func upToDate(pp v1alpha1.CertificatePoolParameters, provider string, cp certificatepool.CertificatePool) bool {
+	return pp.CertificateAuthority == provider && pp.DisplayName == cp.Name && pp.Description == cp.Description &&
+		pp.ExternalIdentifier == cp.ExternalIdentifier && pp.Filter == cp.Filter
+}
+
+// changes Returns the update turning the observed certificate pool into the desired one & whether there is anything to update
+func changes(pp v1alpha1.CertificatePoolParameters, observed v1alpha1.CertificatePoolObservation) (certificatepool.Update, bool) {
+	var u certificatepool.Update
+	if pp.DisplayName != observed.DisplayName {
+		u.Name = pp.DisplayName
+	}
+	if pp.Description != observed.Description {
+		description := pp.Description
+		u.Description = &description
+	}
+	if pp.ExternalIdentifier != observed.ExternalIdentifier {
+		u.ExternalIdentifier = pp.ExternalIdentifier
+	}
+	if pp.Filter != observed.Filter {
+		u.Filter = pp.Filter
+	}
+
+	return u, u.Name != "" || u.Description != nil || u.ExternalIdentifier != "" || u.Filter != ""
+}
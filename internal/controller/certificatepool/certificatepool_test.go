@@ -0,0 +1,134 @@
+package certificatepool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/certificatepool/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/certificatepool"
+)
+
+type fakeCertificatePools struct {
+	// pools by certificate authority & ID
+	pools   map[string]map[string]certificatepool.CertificatePool
+	updates []certificatepool.Update
+	created int
+}
+
+func (f *fakeCertificatePools) CertificatePoolCreate(_ context.Context, provider string, name string, description string, externalIdentifier string, filter string) (certificatepool.CertificatePool, error) {
+	f.created++
+	cp := certificatepool.CertificatePool{ID: "pool-" + string(rune('a'+f.created-1)), Name: name, Description: description, ExternalIdentifier: externalIdentifier, Filter: filter}
+	if f.pools[provider] == nil {
+		f.pools[provider] = map[string]certificatepool.CertificatePool{}
+	}
+	f.pools[provider][cp.ID] = cp
+	return cp, nil
+}
+
+func (f *fakeCertificatePools) CertificatePoolDescribe(_ context.Context, provider string, id string) (certificatepool.CertificatePool, error) {
+	cp, ok := f.pools[provider][id]
+	if !ok {
+		return cp, certificatepool.ErrNotFound
+	}
+	return cp, nil
+}
+
+func (f *fakeCertificatePools) CertificatePoolUpdate(_ context.Context, provider string, id string, u certificatepool.Update) error {
+	cp, ok := f.pools[provider][id]
+	if !ok {
+		return certificatepool.ErrNotFound
+	}
+	f.updates = append(f.updates, u)
+
+	if u.Filter != "" {
+		cp.Filter = u.Filter
+	}
+	f.pools[provider][id] = cp
+	return nil
+}
+
+func (f *fakeCertificatePools) CertificatePoolDelete(_ context.Context, provider string, id string) error {
+	if _, ok := f.pools[provider][id]; !ok {
+		return certificatepool.ErrNotFound
+	}
+	delete(f.pools[provider], id)
+	return nil
+}
+
+func TestChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	pp := v1alpha1.CertificatePoolParameters{CertificateAuthority: "op-abc123", DisplayName: "payments", ExternalIdentifier: "UID", Filter: `certificate.ou == "payments"`}
+	cp := certificatepool.CertificatePool{ID: "pool-abc123", Name: "payments", ExternalIdentifier: "UID", Filter: `certificate.ou == "payments"`}
+
+	assert.True(upToDate(pp, "op-abc123", cp))
+	assert.False(upToDate(pp, "op-def456", cp))
+
+	drifted := cp
+	drifted.Filter = `certificate.ou == "orders"`
+	assert.False(upToDate(pp, "op-abc123", drifted))
+
+	u, changed := changes(pp, observation("op-abc123", drifted))
+	assert.True(changed)
+	assert.Equal(certificatepool.Update{Filter: `certificate.ou == "payments"`}, u)
+
+	_, changed = changes(pp, observation("op-abc123", cp))
+	assert.False(changed)
+}
+
+func TestFilterDrift(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	pools := &fakeCertificatePools{pools: map[string]map[string]certificatepool.CertificatePool{}}
+	e := &external{service: pools, kube: test.NewMockClient()}
+
+	cr := v1alpha1.CertificatePool{}
+	cr.Spec.ForProvider = v1alpha1.CertificatePoolParameters{DisplayName: "payments", ExternalIdentifier: "UID", Filter: `certificate.ou == "payments"`}
+
+	// The certificate authority is not resolved yet
+	_, err := e.Create(ctx, &cr)
+	assert.EqualError(err, errNoCertificateAuthority)
+
+	cr.Spec.ForProvider.CertificateAuthority = "op-abc123"
+	_, err = e.Create(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal("pool-a", meta.GetExternalName(&cr))
+	assert.Equal("User:pool-a", cr.Status.AtProvider.Principal)
+
+	// The filter is changed outside of the provider
+	cp := pools.pools["op-abc123"]["pool-a"]
+	cp.Filter = "true"
+	pools.pools["op-abc123"]["pool-a"] = cp
+
+	obs, err := e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.False(obs.ResourceUpToDate)
+	assert.Equal("true", cr.Status.AtProvider.Filter)
+
+	_, err = e.Update(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal([]certificatepool.Update{{Filter: `certificate.ou == "payments"`}}, pools.updates)
+
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// Moving the pool to another certificate authority recreates it there
+	cr.Spec.ForProvider.CertificateAuthority = "op-def456"
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+
+	_, err = e.Update(ctx, &cr)
+	assert.NoError(err)
+	assert.Empty(pools.pools["op-abc123"])
+	assert.Contains(pools.pools["op-def456"], "pool-b")
+	assert.Equal("pool-b", meta.GetExternalName(&cr))
+	assert.Equal("op-def456", cr.Status.AtProvider.CertificateAuthority)
+}
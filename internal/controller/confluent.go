@@ -18,6 +18,18 @@ package controller
 
 import (
 	"github.com/dfds/provider-confluent/internal/controller/acl"
+	"github.com/dfds/provider-confluent/internal/controller/certificateauthority"
+	"github.com/dfds/provider-confluent/internal/controller/certificatepool"
+	"github.com/dfds/provider-confluent/internal/controller/consumergroup"
+	"github.com/dfds/provider-confluent/internal/controller/customconnectorplugin"
+	"github.com/dfds/provider-confluent/internal/controller/dnsforwarder"
+	"github.com/dfds/provider-confluent/internal/controller/environmentrolebinding"
+	"github.com/dfds/provider-confluent/internal/controller/flinkstatement"
+	"github.com/dfds/provider-confluent/internal/controller/groupmapping"
+	"github.com/dfds/provider-confluent/internal/controller/ipfilter"
+	"github.com/dfds/provider-confluent/internal/controller/ipgroup"
+	"github.com/dfds/provider-confluent/internal/controller/kafkacluster"
+	"github.com/dfds/provider-confluent/internal/controller/tableflowtopic"
 	"github.com/dfds/provider-confluent/internal/controller/topic"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -28,6 +40,7 @@ import (
 	"github.com/dfds/provider-confluent/internal/controller/config"
 	"github.com/dfds/provider-confluent/internal/controller/schema"
 	"github.com/dfds/provider-confluent/internal/controller/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/usagereport"
 )
 
 // Setup creates all controllers with the supplied logger and adds them to
@@ -40,6 +53,19 @@ func Setup(mgr ctrl.Manager, l logging.Logger, wl workqueue.RateLimiter) error {
 		apikey.Setup,
 		acl.Setup,
 		topic.Setup,
+		tableflowtopic.Setup,
+		flinkstatement.Setup,
+		ipgroup.Setup,
+		ipfilter.Setup,
+		customconnectorplugin.Setup,
+		consumergroup.Setup,
+		dnsforwarder.Setup,
+		groupmapping.Setup,
+		usagereport.Setup,
+		environmentrolebinding.Setup,
+		certificateauthority.Setup,
+		certificatepool.Setup,
+		kafkacluster.Setup,
 	} {
 		if err := setup(mgr, l, wl); err != nil {
 			return err
@@ -0,0 +1,227 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package consumergroup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/consumergroup/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/consumergroup"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+)
+
+const (
+	errNotMyType       = "managed resource is not a ConsumerGroup custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetCABundle     = "cannot get CA bundle"
+	errNewClient       = "cannot create new Service"
+	errAuthCredentials = "invalid client credentials"
+	errObserveOnly     = "consumer groups are observation-only and are never created by the provider"
+	msgNotFound        = "consumer group %s does not exist in cluster %s"
+)
+
+var (
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
+		credParts := strings.Split(string(clientCreds), ":")
+
+		if len(credParts) != 2 {
+			return nil, errors.New(errAuthCredentials)
+		}
+
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
+
+		if authErr != nil {
+			return nil, authErr
+		}
+
+		cgConfig := consumergroup.Config{
+			APICredentials: apiCreds,
+		}
+
+		return consumergroup.NewClient(cgConfig).(interface{}), nil
+	}
+)
+
+// Setup adds a controller that reconciles ConsumerGroup managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.ConsumerGroupGroupKind)
+
+	o := controller.Options{
+		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+	}
+
+	failures := retry.NewTracker()
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.ConsumerGroupGroupVersionKind),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: createAndConvertClientFunc})),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.ConsumerGroup).Spec.ForProvider.Environment })),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.ConsumerGroup{}).
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.ConsumerGroupList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.ConsumerGroup{} }, failures.Reconciler(r))))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.ConsumerGroup)
+	if !ok {
+		return nil, errors.New(errNotMyType)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	var apiCredentials clients.APICredentials
+
+	for _, value := range pc.Spec.APICredentials {
+		if value.Identifier == v1alpha1.SchemeGroupVersion.Identifier() {
+			apiCredentials = value
+
+			break
+		}
+	}
+
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// A 'client' used to connect to the external resource API. In practice this
+	// would be something like an AWS SDK client.
+	service interface{}
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.ConsumerGroup)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMyType)
+	}
+
+	// Consumer groups are never created, so the resource always exists & is up to date. A missing group is reported in the conditions
+	fp := cr.Spec.ForProvider
+	var client = c.service.(consumergroup.IClient)
+	cg, err := client.ConsumerGroupDescribe(ctx, fp.GroupID, fp.Environment, fp.Cluster)
+	switch {
+	case consumergroup.IsNotFound(err):
+		cr.Status.AtProvider = v1alpha1.ConsumerGroupObservation{}
+		cr.Status.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgNotFound, fp.GroupID, fp.Cluster)))
+	case err != nil:
+		return managed.ExternalObservation{}, err
+	default:
+		lags, err := client.ConsumerGroupLagList(ctx, fp.GroupID, fp.Environment, fp.Cluster)
+		if err != nil && !consumergroup.IsNotFound(err) {
+			return managed.ExternalObservation{}, err
+		}
+		cr.Status.AtProvider = observation(cg, lags)
+		cr.Status.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errObserveOnly)
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete Leaves the consumer group untouched, deleting a ConsumerGroup only stops observing it
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,43 @@
+package consumergroup
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dfds/provider-confluent/apis/consumergroup/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/consumergroup"
+)
+
+// observation Returns the observed state of a consumer group. Partitions are sorted by topic & partition, so an unchanged group yields an unchanged status
+func observation(cg consumergroup.ConsumerGroup, lags []consumergroup.Lag) v1alpha1.ConsumerGroupObservation {
+	o := v1alpha1.ConsumerGroupObservation{
+		State: strings.ToUpper(cg.State),
+	}
+
+	members := map[string]bool{}
+	for _, l := range lags {
+		o.Partitions = append(o.Partitions, v1alpha1.PartitionLag{
+			Topic:         l.Topic,
+			Partition:     l.Partition,
+			CurrentOffset: l.CurrentOffset,
+			LogEndOffset:  l.LogEndOffset,
+			Lag:           l.Lag,
+			ConsumerID:    l.ConsumerID,
+		})
+		o.TotalLag += l.Lag
+		if l.ConsumerID != "" && !members[l.ConsumerID] {
+			members[l.ConsumerID] = true
+			o.Members = append(o.Members, l.ConsumerID)
+		}
+	}
+
+	sort.Slice(o.Partitions, func(i, j int) bool {
+		if o.Partitions[i].Topic != o.Partitions[j].Topic {
+			return o.Partitions[i].Topic < o.Partitions[j].Topic
+		}
+		return o.Partitions[i].Partition < o.Partitions[j].Partition
+	})
+	sort.Strings(o.Members)
+
+	return o
+}
@@ -0,0 +1,85 @@
+package consumergroup
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/consumergroup/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/consumergroup"
+)
+
+func TestObservation(t *testing.T) {
+	assert := assert.New(t)
+
+	o := observation(consumergroup.ConsumerGroup{State: "Stable"}, []consumergroup.Lag{
+		{Topic: "payments", Partition: 0, CurrentOffset: 90, LogEndOffset: 100, Lag: 10, ConsumerID: "consumer-2"},
+		{Topic: "orders", Partition: 1, CurrentOffset: 5, LogEndOffset: 5, Lag: 0, ConsumerID: "consumer-1"},
+		{Topic: "orders", Partition: 0, CurrentOffset: 40, LogEndOffset: 42, Lag: 2, ConsumerID: "consumer-1"},
+		{Topic: "orders", Partition: 2, CurrentOffset: 7, LogEndOffset: 7, Lag: 0},
+	})
+
+	assert.Equal("STABLE", o.State)
+	assert.Equal(int64(12), o.TotalLag)
+	assert.Equal([]string{"consumer-1", "consumer-2"}, o.Members)
+	assert.Len(o.Partitions, 4)
+	assert.Equal(v1alpha1.PartitionLag{Topic: "orders", Partition: 0, CurrentOffset: 40, LogEndOffset: 42, Lag: 2, ConsumerID: "consumer-1"}, o.Partitions[0])
+	assert.Equal("payments", o.Partitions[3].Topic)
+}
+
+type fakeConsumerGroupClient struct {
+	groups map[string]consumergroup.ConsumerGroup
+	lags   map[string][]consumergroup.Lag
+}
+
+func (f *fakeConsumerGroupClient) ConsumerGroupDescribe(_ context.Context, groupID string, environment string, cluster string) (consumergroup.ConsumerGroup, error) {
+	cg, ok := f.groups[groupID]
+	if !ok {
+		return cg, consumergroup.ErrNotFound
+	}
+	return cg, nil
+}
+
+func (f *fakeConsumerGroupClient) ConsumerGroupLagList(_ context.Context, groupID string, environment string, cluster string) ([]consumergroup.Lag, error) {
+	return f.lags[groupID], nil
+}
+
+func TestObserveOnly(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeConsumerGroupClient{
+		groups: map[string]consumergroup.ConsumerGroup{"orders-service": {ConsumerGroupID: "orders-service", State: "STABLE"}},
+		lags:   map[string][]consumergroup.Lag{"orders-service": {{Topic: "orders", Lag: 3, ConsumerID: "consumer-1"}}},
+	}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	cr := &v1alpha1.ConsumerGroup{}
+	cr.Spec.ForProvider = v1alpha1.ConsumerGroupParameters{GroupID: "orders-service", Environment: "env-12345", Cluster: "lkc-12345"}
+
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(int64(3), cr.Status.AtProvider.TotalLag)
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+
+	// A missing group is never created, it is reported as unavailable
+	cr.Spec.ForProvider.GroupID = "gone"
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.Empty(cr.Status.AtProvider.State)
+	assert.Equal(xpv1.ReasonUnavailable, cr.GetCondition(xpv1.TypeReady).Reason)
+	assert.Equal("consumer group gone does not exist in cluster lkc-12345", cr.GetCondition(xpv1.TypeReady).Message)
+
+	_, err = e.Create(ctx, cr)
+	assert.EqualError(err, errObserveOnly)
+
+	// Deleting the resource leaves the group untouched
+	assert.NoError(e.Delete(ctx, cr))
+	assert.Contains(fake.groups, "orders-service")
+}
@@ -0,0 +1,392 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package customconnectorplugin
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/customconnectorplugin"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
+)
+
+const (
+	errNotMyType       = "managed resource is not a CustomConnectorPlugin custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetCABundle     = "cannot get CA bundle"
+	errNewClient       = "cannot create new Service"
+	errAuthCredentials = "invalid client credentials"
+	errFetchArtifact   = "cannot fetch plugin artifact"
+	errPendingGone     = "custom connector plugin %s uploaded by a previous attempt no longer exists"
+	errPendingOther    = "custom connector plugin %s (%s) of connector class %s was not uploaded by a previous attempt. Rename the plugin or set its ID as external name to adopt it"
+)
+
+var (
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
+		credParts := strings.Split(string(clientCreds), ":")
+
+		if len(credParts) != 2 {
+			return nil, errors.New(errAuthCredentials)
+		}
+
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
+
+		if authErr != nil {
+			return nil, authErr
+		}
+
+		ccpConfig := customconnectorplugin.Config{
+			APICredentials: apiCreds,
+		}
+
+		return customconnectorplugin.NewClient(ccpConfig).(interface{}), nil
+	}
+)
+
+// Setup adds a controller that reconciles CustomConnectorPlugin managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.CustomConnectorPluginGroupKind)
+
+	o := controller.Options{
+		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+	}
+
+	failures := retry.NewTracker()
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CustomConnectorPluginGroupVersionKind),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: createAndConvertClientFunc})),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.CustomConnectorPlugin{}).
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.CustomConnectorPluginList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.CustomConnectorPlugin{} }, failures.Reconciler(r))))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.CustomConnectorPlugin)
+	if !ok {
+		return nil, errors.New(errNotMyType)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	var apiCredentials clients.APICredentials
+
+	for _, value := range pc.Spec.APICredentials {
+		if value.Identifier == v1alpha1.SchemeGroupVersion.Identifier() {
+			apiCredentials = value
+
+			break
+		}
+	}
+
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// A 'client' used to connect to the external resource API. In practice this
+	// would be something like an AWS SDK client.
+	service interface{}
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.CustomConnectorPlugin)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMyType)
+	}
+
+	// The external name is the ID of the plugin, which is only known once it is uploaded or set to import an existing plugin
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+	if err := externalname.CheckID(cr, cr.Status.AtProvider.ID); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Confluent
+	var client = c.service.(customconnectorplugin.IClient)
+	p, err := client.CustomConnectorPluginDescribe(ctx, id)
+
+	if err != nil {
+		if customconnectorplugin.IsNotFound(err) {
+			return managed.ExternalObservation{
+				ResourceExists:    false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, nil // returning nil because we want create on not found
+		}
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, err
+	}
+
+	cr.Status.AtProvider = observation(p)
+	cr.Status.SetConditions(xpv1.Available())
+
+	// Diff
+	if !upToDate(cr.Spec.ForProvider, p) {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.CustomConnectorPlugin)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMyType)
+	}
+
+	// Record the name before uploading the plugin & its ID as soon as it is uploaded, so a retry after an upload that failed without a response
+	// or a failure to record the plugin adopts it instead of uploading another one
+	pending := cr.Status.AtProvider.PendingName != ""
+	cr.Status.AtProvider.PendingName = cr.Spec.ForProvider.PluginName
+
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	p, adopted, err := c.pendingPlugin(ctx, cr, pending)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if !adopted {
+		// The transfer of an artifact outlasts the deadline of the reconcile, so it & the writes recording the plugin run under their own
+		uctx, cancel := context.WithTimeout(context.Background(), timeout.Upload)
+		defer cancel()
+
+		if p, err = c.upload(uctx, cr); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+		ctx = uctx
+	}
+
+	meta.SetExternalName(cr, p.ID)
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.Status.AtProvider = observation(p)
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// upload Uploads the artifact of cr as a new plugin & records its ID
+func (c *external) upload(ctx context.Context, cr *v1alpha1.CustomConnectorPlugin) (customconnectorplugin.Plugin, error) {
+	file, err := fetchArtifact(ctx, c.kube, cr.Spec.ForProvider.Artifact)
+	if err != nil {
+		return customconnectorplugin.Plugin{}, errors.Wrap(err, errFetchArtifact)
+	}
+	defer os.Remove(file) //nolint:errcheck
+
+	var client = c.service.(customconnectorplugin.IClient)
+	p, err := client.CustomConnectorPluginCreate(ctx, file, cr.Spec.ForProvider)
+	if err != nil {
+		// A rejected upload created no plugin, so the retry uploads it without looking for it
+		if clients.IsRejected(err) {
+			cr.Status.AtProvider.PendingName = ""
+		}
+		return customconnectorplugin.Plugin{}, err
+	}
+
+	cr.Status.AtProvider.ID = p.ID
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return customconnectorplugin.Plugin{}, err
+	}
+	return p, nil
+}
+
+// pendingPlugin Returns the plugin uploaded by a previous attempt of Create & whether there is one. The plugin is adopted through the ID
+// recorded by that attempt or, when the upload failed before its ID was known, through its name. A plugin of the name is only adopted when it
+// is the only one & has the connector class of cr, otherwise it can't be told apart from a plugin of another owner
+func (c *external) pendingPlugin(ctx context.Context, cr *v1alpha1.CustomConnectorPlugin, pending bool) (customconnectorplugin.Plugin, bool, error) {
+	if !pending {
+		return customconnectorplugin.Plugin{}, false, nil
+	}
+
+	var client = c.service.(customconnectorplugin.IClient)
+	id := cr.Status.AtProvider.ID
+	if id == "" {
+		existing, err := client.CustomConnectorPluginByName(ctx, cr.Spec.ForProvider.PluginName)
+		switch {
+		case customconnectorplugin.IsNotFound(err):
+			return customconnectorplugin.Plugin{}, false, nil
+		case err != nil:
+			return customconnectorplugin.Plugin{}, false, err
+		}
+		id = existing.ID
+	}
+
+	p, err := client.CustomConnectorPluginDescribe(ctx, id)
+	switch {
+	case customconnectorplugin.IsNotFound(err) && cr.Status.AtProvider.ID != "":
+		return customconnectorplugin.Plugin{}, false, errors.Errorf(errPendingGone, id)
+	case err != nil:
+		return customconnectorplugin.Plugin{}, false, err
+	case p.ConnectorClass != cr.Spec.ForProvider.ConnectorClass:
+		return customconnectorplugin.Plugin{}, false, errors.Errorf(errPendingOther, p.Name, p.ID, p.ConnectorClass)
+	}
+	return p, true, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.CustomConnectorPlugin)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMyType)
+	}
+
+	var client = c.service.(customconnectorplugin.IClient)
+	if err := client.CustomConnectorPluginUpdate(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Delete deletes the plugin. Confluent Cloud refuses to delete a plugin used by connectors, which keeps the resource until they are gone
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.CustomConnectorPlugin)
+	if !ok {
+		return errors.New(errNotMyType)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	var client = c.service.(customconnectorplugin.IClient)
+	if err := client.CustomConnectorPluginDelete(ctx, meta.GetExternalName(cr)); err != nil && !customconnectorplugin.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package customconnectorplugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/customconnectorplugin"
+)
+
+const (
+	errArtifactSource = "plugin artifact requires exactly one of url and secretRef"
+	errArtifactFormat = "plugin artifact must be a .zip or .jar file"
+	errArtifactSize   = "plugin artifact exceeds the maximum size"
+	errArtifactEmpty  = "plugin artifact is empty"
+	errDownload       = "cannot download plugin artifact"
+	errGetSecret      = "cannot get plugin artifact secret"
+	errWriteArtifact  = "cannot write plugin artifact"
+
+	// artifactMaxSize is the largest plugin file Confluent Cloud accepts
+	artifactMaxSize = 200 << 20
+)
+
+// httpClient downloads plugin artifacts. A download is bounded by the context of the upload rather than a timeout of its own
+var httpClient = &http.Client{}
+
+// observation Returns the CustomConnectorPlugin status matching a plugin in Confluent Cloud
+func observation(p customconnectorplugin.Plugin) v1alpha1.CustomConnectorPluginObservation {
+	return v1alpha1.CustomConnectorPluginObservation{
+		ID:             p.ID,
+		PluginName:     p.Name,
+		ConnectorClass: p.ConnectorClass,
+		ConnectorType:  p.ConnectorType,
+	}
+}
+
+// upToDate Checks if the changeable fields of a plugin in Confluent Cloud match the CustomConnectorPlugin parameters. The order of the sensitive properties is ignored
+func upToDate(cp v1alpha1.CustomConnectorPluginParameters, p customconnectorplugin.Plugin) bool {
+	if cp.PluginName != p.Name || cp.Description != p.Description {
+		return false
+	}
+	if len(cp.SensitiveProperties) != len(p.SensitiveProperties) {
+		return false
+	}
+	for _, sp := range cp.SensitiveProperties {
+		if !contains(p.SensitiveProperties, sp) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// artifactExt Returns the file extension of a plugin artifact, which the Confluent CLI uses to tell .zip & .jar files apart
+func artifactExt(a v1alpha1.PluginArtifact) (string, error) {
+	var name string
+	switch {
+	case a.URL != "" && a.SecretRef == nil:
+		u, err := url.Parse(a.URL)
+		if err != nil {
+			return "", errors.Wrap(err, errDownload)
+		}
+		name = u.Path
+	case a.URL == "" && a.SecretRef != nil:
+		name = a.SecretRef.Key
+	default:
+		return "", errors.New(errArtifactSource)
+	}
+
+	ext := strings.ToLower(path.Ext(name))
+	if ext != ".zip" && ext != ".jar" {
+		return "", errors.New(errArtifactFormat)
+	}
+	return ext, nil
+}
+
+// fetchArtifact Writes the plugin file of a to a temporary file & returns its path. The caller removes the file
+func fetchArtifact(ctx context.Context, kube client.Reader, a v1alpha1.PluginArtifact) (string, error) {
+	ext, err := artifactExt(a)
+	if err != nil {
+		return "", err
+	}
+
+	var r io.Reader
+	if a.SecretRef != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: a.SecretRef.Name, Namespace: a.SecretRef.Namespace}, s); err != nil {
+			return "", errors.Wrap(err, errGetSecret)
+		}
+		r = strings.NewReader(string(s.Data[a.SecretRef.Key]))
+	} else {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.URL, nil)
+		if err != nil {
+			return "", errors.Wrap(err, errDownload)
+		}
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return "", errors.Wrap(err, errDownload)
+		}
+		defer resp.Body.Close() //nolint:errcheck
+		if resp.StatusCode != http.StatusOK {
+			return "", errors.Wrap(fmt.Errorf("unexpected status %s", resp.Status), errDownload)
+		}
+		r = resp.Body
+	}
+
+	return writeArtifact(r, ext)
+}
+
+// writeArtifact Copies r to a temporary file with the given extension, refusing files larger than artifactMaxSize
+func writeArtifact(r io.Reader, ext string) (string, error) {
+	f, err := ioutil.TempFile("", "plugin-*"+ext)
+	if err != nil {
+		return "", errors.Wrap(err, errWriteArtifact)
+	}
+
+	n, err := io.Copy(f, io.LimitReader(r, artifactMaxSize+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	switch {
+	case err != nil:
+		err = errors.Wrap(err, errWriteArtifact)
+	case n == 0:
+		err = errors.New(errArtifactEmpty)
+	case n > artifactMaxSize:
+		err = errors.New(errArtifactSize)
+	}
+	if err != nil {
+		os.Remove(f.Name()) //nolint:errcheck
+		return "", err
+	}
+
+	return f.Name(), nil
+}
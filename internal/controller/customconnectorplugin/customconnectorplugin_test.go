@@ -0,0 +1,258 @@
+package customconnectorplugin
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/customconnectorplugin/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/customconnectorplugin"
+)
+
+func TestArtifactExt(t *testing.T) {
+	assert := assert.New(t)
+
+	ext, err := artifactExt(v1alpha1.PluginArtifact{URL: "https://example.com/plugins/datagen.ZIP?version=1"})
+	assert.NoError(err)
+	assert.Equal(".zip", ext)
+
+	ext, err = artifactExt(v1alpha1.PluginArtifact{SecretRef: &xpv1.SecretKeySelector{Key: "datagen.jar"}})
+	assert.NoError(err)
+	assert.Equal(".jar", ext)
+
+	_, err = artifactExt(v1alpha1.PluginArtifact{URL: "https://example.com/plugins/datagen.tar.gz"})
+	assert.EqualError(err, errArtifactFormat)
+
+	_, err = artifactExt(v1alpha1.PluginArtifact{})
+	assert.EqualError(err, errArtifactSource)
+	_, err = artifactExt(v1alpha1.PluginArtifact{URL: "https://example.com/datagen.zip", SecretRef: &xpv1.SecretKeySelector{Key: "datagen.zip"}})
+	assert.EqualError(err, errArtifactSource)
+}
+
+func TestFetchArtifact(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// Secret
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"datagen.zip": []byte("PK-from-secret"), "empty.zip": {}}
+		return nil
+	}
+	ref := &xpv1.SecretKeySelector{Key: "datagen.zip"}
+	file, err := fetchArtifact(ctx, kube, v1alpha1.PluginArtifact{SecretRef: ref})
+	assert.NoError(err)
+	content, _ := ioutil.ReadFile(file)
+	assert.Equal("PK-from-secret", string(content))
+	assert.Equal(".zip", file[len(file)-4:])
+	os.Remove(file) //nolint:errcheck
+
+	_, err = fetchArtifact(ctx, kube, v1alpha1.PluginArtifact{SecretRef: &xpv1.SecretKeySelector{Key: "empty.zip"}})
+	assert.EqualError(err, errArtifactEmpty)
+
+	// URL
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/datagen.jar" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("PK-from-url")) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	file, err = fetchArtifact(ctx, kube, v1alpha1.PluginArtifact{URL: srv.URL + "/datagen.jar"})
+	assert.NoError(err)
+	content, _ = ioutil.ReadFile(file)
+	assert.Equal("PK-from-url", string(content))
+	os.Remove(file) //nolint:errcheck
+
+	_, err = fetchArtifact(ctx, kube, v1alpha1.PluginArtifact{URL: srv.URL + "/missing.jar"})
+	assert.Error(err)
+}
+
+func TestUpToDate(t *testing.T) {
+	assert := assert.New(t)
+
+	cp := v1alpha1.CustomConnectorPluginParameters{PluginName: "datagen", Description: "Datagen", SensitiveProperties: []string{"password", "token"}}
+	p := customconnectorplugin.Plugin{Name: "datagen", Description: "Datagen", SensitiveProperties: []string{"token", "password"}}
+	assert.True(upToDate(cp, p))
+
+	p.Description = "Old"
+	assert.False(upToDate(cp, p))
+	p.Description = "Datagen"
+
+	p.SensitiveProperties = []string{"password"}
+	assert.False(upToDate(cp, p))
+}
+
+type fakeCustomConnectorPluginClient struct {
+	customconnectorplugin.IClient
+	plugins   map[string]customconnectorplugin.Plugin
+	inUse     bool
+	createErr error
+	created   int
+}
+
+func (f *fakeCustomConnectorPluginClient) CustomConnectorPluginCreate(_ context.Context, file string, cp v1alpha1.CustomConnectorPluginParameters) (customconnectorplugin.Plugin, error) {
+	if f.createErr != nil {
+		return customconnectorplugin.Plugin{}, f.createErr
+	}
+	f.created++
+	p := customconnectorplugin.Plugin{ID: "ccp-12345", Name: cp.PluginName, ConnectorClass: cp.ConnectorClass, ConnectorType: cp.ConnectorType}
+	f.plugins[p.ID] = p
+	return p, nil
+}
+
+func (f *fakeCustomConnectorPluginClient) CustomConnectorPluginDescribe(_ context.Context, id string) (customconnectorplugin.Plugin, error) {
+	p, ok := f.plugins[id]
+	if !ok {
+		return p, customconnectorplugin.ErrNotFound
+	}
+	return p, nil
+}
+
+func (f *fakeCustomConnectorPluginClient) CustomConnectorPluginByName(_ context.Context, name string) (customconnectorplugin.Plugin, error) {
+	for _, p := range f.plugins {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return customconnectorplugin.Plugin{}, customconnectorplugin.ErrNotFound
+}
+
+func (f *fakeCustomConnectorPluginClient) CustomConnectorPluginDelete(_ context.Context, id string) error {
+	if f.inUse {
+		return errors.Wrap(customconnectorplugin.ErrInUse, "409: plugin is in use")
+	}
+	if _, ok := f.plugins[id]; !ok {
+		return customconnectorplugin.ErrNotFound
+	}
+	delete(f.plugins, id)
+	return nil
+}
+
+func TestPluginLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"datagen.zip": []byte("PK")}
+		return nil
+	}
+	fake := &fakeCustomConnectorPluginClient{plugins: map[string]customconnectorplugin.Plugin{}}
+	e := &external{service: fake, kube: kube}
+
+	cr := v1alpha1.CustomConnectorPlugin{}
+	cr.Spec.ForProvider = v1alpha1.CustomConnectorPluginParameters{
+		PluginName:     "datagen",
+		ConnectorClass: "io.confluent.kafka.connect.datagen.DatagenConnector",
+		ConnectorType:  "SOURCE",
+		Artifact:       v1alpha1.PluginArtifact{SecretRef: &xpv1.SecretKeySelector{Key: "datagen.zip"}},
+	}
+
+	obs, err := e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+
+	_, err = e.Create(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal("ccp-12345", meta.GetExternalName(&cr))
+	assert.Equal("ccp-12345", cr.Status.AtProvider.ID)
+
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// Deletion is blocked while connectors use the plugin
+	fake.inUse = true
+	err = e.Delete(ctx, &cr)
+	assert.True(customconnectorplugin.IsInUse(err))
+	assert.Contains(fake.plugins, "ccp-12345")
+
+	fake.inUse = false
+	assert.NoError(e.Delete(ctx, &cr))
+	assert.NoError(e.Delete(ctx, &cr))
+}
+
+func TestPendingPlugin(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		obj.(*corev1.Secret).Data = map[string][]byte{"datagen.zip": []byte("PK")}
+		return nil
+	}
+	fake := &fakeCustomConnectorPluginClient{plugins: map[string]customconnectorplugin.Plugin{}}
+	e := &external{service: fake, kube: kube}
+
+	newPlugin := func() *v1alpha1.CustomConnectorPlugin {
+		cr := &v1alpha1.CustomConnectorPlugin{}
+		cr.Spec.ForProvider = v1alpha1.CustomConnectorPluginParameters{
+			PluginName:     "datagen",
+			ConnectorClass: "io.confluent.kafka.connect.datagen.DatagenConnector",
+			ConnectorType:  "SOURCE",
+			Artifact:       v1alpha1.PluginArtifact{SecretRef: &xpv1.SecretKeySelector{Key: "datagen.zip"}},
+		}
+		return cr
+	}
+
+	// An upload killed before its response may have created the plugin, so the name stays pending
+	cr := newPlugin()
+	fake.createErr = errors.Wrap(clients.CommandError([]byte("signal: killed")), "unknown error")
+	_, err := e.Create(ctx, cr)
+	assert.Error(err)
+	assert.Equal("datagen", cr.Status.AtProvider.PendingName)
+
+	// The retry adopts the only plugin of the name instead of uploading it again
+	fake.createErr = nil
+	fake.plugins["ccp-11111"] = customconnectorplugin.Plugin{ID: "ccp-11111", Name: "datagen", ConnectorClass: cr.Spec.ForProvider.ConnectorClass}
+	_, err = e.Create(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(0, fake.created)
+	assert.Equal("ccp-11111", meta.GetExternalName(cr))
+	assert.Empty(cr.Status.AtProvider.PendingName)
+
+	// A plugin of the name with another connector class is not adopted
+	cr = newPlugin()
+	cr.Status.AtProvider.PendingName = "datagen"
+	cr.Spec.ForProvider.ConnectorClass = "io.example.OtherConnector"
+	_, err = e.Create(ctx, cr)
+	assert.Error(err)
+	assert.Equal(0, fake.created)
+	assert.Empty(meta.GetExternalName(cr))
+
+	// A recorded ID is adopted without looking up the name
+	cr = newPlugin()
+	cr.Status.AtProvider.PendingName = "datagen"
+	cr.Status.AtProvider.ID = "ccp-11111"
+	_, err = e.Create(ctx, cr)
+	assert.NoError(err)
+	assert.Equal("ccp-11111", meta.GetExternalName(cr))
+
+	cr = newPlugin()
+	cr.Status.AtProvider.PendingName = "datagen"
+	cr.Status.AtProvider.ID = "ccp-22222"
+	_, err = e.Create(ctx, cr)
+	assert.EqualError(err, "custom connector plugin ccp-22222 uploaded by a previous attempt no longer exists")
+
+	// A rejected upload created no plugin, so the retry uploads without looking for it
+	cr = newPlugin()
+	fake.createErr = errors.Wrap(clients.CommandError([]byte(`{"errors":[{"status":"400","detail":"Invalid plugin"}]}`)), "unknown error")
+	_, err = e.Create(ctx, cr)
+	assert.Error(err)
+	assert.Empty(cr.Status.AtProvider.PendingName)
+}
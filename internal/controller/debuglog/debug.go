@@ -0,0 +1,159 @@
+package debuglog
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// AnnotationKeyDebug logs the requests & responses of every reconcile of a managed resource when set to "true", without raising the log
+// level of the whole provider
+const AnnotationKeyDebug = "confluent.crossplane.io/debug"
+
+// Redacted replaces secret values in debug logs
+const Redacted = clients.Redacted
+
+// Log receives the requests & responses of the managed resources with AnnotationKeyDebug. It discards everything unless set
+var Log = logging.NewNopLogger()
+
+// secretKeys are fragments of the names of fields holding secret values
+var secretKeys = []string{"secret", "password", "token", "credential"}
+
+// Enabled reports whether the reconciles of o are logged in full
+func Enabled(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyDebug] == "true"
+}
+
+// Connecter wraps c so the external clients of managed resources with AnnotationKeyDebug log every request & response. Other managed
+// resources get the external client of c as is
+func Connecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if !Enabled(mg) {
+			return ec, err
+		}
+
+		log := Log.WithValues("kind", reflect.TypeOf(mg).Elem().Name(), "name", mg.GetName())
+		if err != nil {
+			log.Info("Connect failed", "error", describe(err))
+			return ec, err
+		}
+		return &external{ExternalClient: ec, log: log}, nil
+	})
+}
+
+// external logs the requests & responses of an ExternalClient
+type external struct {
+	managed.ExternalClient
+	log logging.Logger
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	e.log.Info("Observe request", "spec", field(mg, "spec"), "status", field(mg, "status"))
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	e.log.Info("Observe response", "resourceExists", o.ResourceExists, "resourceUpToDate", o.ResourceUpToDate, "resourceLateInitialized", o.ResourceLateInitialized,
+		"connectionDetails", keys(o.ConnectionDetails), "status", field(mg, "status"), "error", describe(err))
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	e.log.Info("Create request", "spec", field(mg, "spec"))
+	c, err := e.ExternalClient.Create(ctx, mg)
+	e.log.Info("Create response", "connectionDetails", keys(c.ConnectionDetails), "status", field(mg, "status"), "error", describe(err))
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	e.log.Info("Update request", "spec", field(mg, "spec"), "status", field(mg, "status"))
+	u, err := e.ExternalClient.Update(ctx, mg)
+	e.log.Info("Update response", "connectionDetails", keys(u.ConnectionDetails), "status", field(mg, "status"), "error", describe(err))
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	e.log.Info("Delete request", "status", field(mg, "status"))
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.log.Info("Delete response", "error", describe(err))
+	return err
+}
+
+// field Returns the top-level field name of mg as JSON, with the values of fields holding secrets redacted
+func field(mg resource.Managed, name string) string {
+	b, err := json.Marshal(mg)
+	if err != nil {
+		return ""
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return ""
+	}
+
+	b, err = json.Marshal(redact(fields[name]))
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+// redact Returns v with the values of fields holding secrets replaced by Redacted
+func redact(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, x := range t {
+			if isSecretKey(k) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redact(x)
+		}
+	case []interface{}:
+		for i, x := range t {
+			t[i] = redact(x)
+		}
+	}
+	return v
+}
+
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range secretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// describe Returns the message of err, followed by the full output of the failed Confluent CLI command with secret values redacted
+func describe(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var e *clients.Error
+	if errors.As(err, &e) && e.Output != "" {
+		return err.Error() + "\n" + clients.RedactOutput(e.Output)
+	}
+	return err.Error()
+}
+
+// keys Returns the sorted keys of connection details, their values are secret
+func keys(cd managed.ConnectionDetails) []string {
+	ks := make([]string, 0, len(cd))
+	for k := range cd {
+		ks = append(ks, k)
+	}
+	sort.Strings(ks)
+	return ks
+}
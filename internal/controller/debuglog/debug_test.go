@@ -0,0 +1,88 @@
+package debuglog
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+type fakeLogger struct {
+	logging.Logger
+	lines *[]string
+}
+
+func (l fakeLogger) Info(msg string, keysAndValues ...interface{}) {
+	*l.lines = append(*l.lines, fmt.Sprint(append([]interface{}{msg}, keysAndValues...)...))
+}
+
+func (l fakeLogger) WithValues(keysAndValues ...interface{}) logging.Logger {
+	return l
+}
+
+type fakeExternal struct {
+	managed.ExternalClient
+	err error
+}
+
+func (f *fakeExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return managed.ExternalObservation{ResourceExists: true, ConnectionDetails: managed.ConnectionDetails{"password": []byte("hunter2")}}, f.err
+}
+
+func TestDebugAnnotation(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	var lines []string
+	Log = fakeLogger{lines: &lines}
+	defer func() { Log = logging.NewNopLogger() }()
+
+	failed := errors.Wrap(clients.CommandError([]byte(`Error: {"errors":[{"status":"400","detail":"Invalid topic."}],"api_secret":"s3cr3t"}`)), "unknown error")
+	connecter := Connecter(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return &fakeExternal{err: failed}, nil
+	}))
+
+	// Resources without the annotation are not logged
+	cr := &v1alpha1.Topic{}
+	cr.SetName("orders")
+	ec, err := connecter.Connect(ctx, cr)
+	assert.NoError(err)
+	_, _ = ec.Observe(ctx, cr)
+	assert.Empty(lines)
+
+	cr.SetAnnotations(map[string]string{AnnotationKeyDebug: "true"})
+	ec, err = connecter.Connect(ctx, cr)
+	assert.NoError(err)
+	_, _ = ec.Observe(ctx, cr)
+	if assert.Len(lines, 2) {
+		assert.Contains(lines[0], "Observe request")
+		assert.Contains(lines[1], "Invalid topic.")
+		assert.Contains(lines[1], `"api_secret":`+Redacted)
+		assert.Contains(lines[1], "[password]")
+	}
+	for _, l := range lines {
+		assert.NotContains(l, "s3cr3t")
+		assert.NotContains(l, "hunter2")
+	}
+}
+
+func TestRedact(t *testing.T) {
+	assert := assert.New(t)
+
+	v := redact(map[string]interface{}{
+		"forProvider": map[string]interface{}{"name": "orders", "apiSecret": "s3cr3t"},
+		"items":       []interface{}{map[string]interface{}{"password": "hunter2"}},
+	})
+	assert.Equal(map[string]interface{}{
+		"forProvider": map[string]interface{}{"name": "orders", "apiSecret": Redacted},
+		"items":       []interface{}{map[string]interface{}{"password": Redacted}},
+	}, v)
+}
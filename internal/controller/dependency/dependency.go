@@ -0,0 +1,181 @@
+package dependency
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/common"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+// Condition types & reasons of the dependency gate
+const (
+	TypeBlocked xpv1.ConditionType = "Blocked"
+
+	ReasonDependenciesNotReady xpv1.ConditionReason = "DependenciesNotReady"
+	ReasonDependenciesReady    xpv1.ConditionReason = "DependenciesReady"
+	ReasonCredentialsMissing   xpv1.ConditionReason = "CredentialsMissing"
+	ReasonReferenceNotReady    xpv1.ConditionReason = "ReferenceNotReady"
+)
+
+const (
+	errDependenciesNotReady = "waiting for dependencies to become ready: %s"
+	msgCredentialsMissing   = "waiting for credentials secret %s of the ProviderConfig to exist"
+	errListServiceAccounts  = "cannot list ServiceAccounts"
+	errGetServiceAccount    = "cannot get referenced ServiceAccount"
+)
+
+// Blocked indicates that a managed resource waits for its dependencies to become ready
+func Blocked(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependenciesNotReady,
+		Message:            message,
+	}
+}
+
+// Unblocked indicates that all dependencies of a managed resource are ready
+func Unblocked() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBlocked,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependenciesReady,
+	}
+}
+
+// CredentialsMissing indicates that a managed resource waits for the credentials secret of its ProviderConfig, which may be re-created at the
+// moment
+func CredentialsMissing(secret string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonCredentialsMissing,
+		Message:            fmt.Sprintf(msgCredentialsMissing, secret),
+	}
+}
+
+// ReferenceNotReady indicates that a managed resource waits for a resource it references to become ready
+func ReferenceNotReady(message string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonReferenceNotReady,
+		Message:            message,
+	}
+}
+
+// NotReady Returns the names of the dependencies that do not report Ready
+func NotReady(deps ...resource.Managed) []string {
+	var names []string
+	for _, d := range deps {
+		if d.GetCondition(xpv1.TypeReady).Status != corev1.ConditionTrue {
+			names = append(names, d.GetName())
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Gate Checks that all dependencies of mg are ready before the controller calls Confluent. If one is not, mg gets the Blocked condition and an
+// error is returned so the reconcile is requeued. Resources being deleted are never blocked.
+func Gate(mg resource.Managed, deps ...resource.Managed) error {
+	return Wait(mg, NotReady(deps...)...)
+}
+
+// Wait Blocks mg the same way as Gate on dependencies that are not managed resources, described by notReady. mg is unblocked when notReady
+// is empty
+func Wait(mg resource.Managed, notReady ...string) error {
+	if meta.WasDeleted(mg) {
+		return nil
+	}
+
+	if len(notReady) > 0 {
+		msg := fmt.Sprintf(errDependenciesNotReady, strings.Join(notReady, ", "))
+		mg.SetConditions(Blocked(msg))
+		return errors.New(msg)
+	}
+
+	if mg.GetCondition(TypeBlocked).Status == corev1.ConditionTrue {
+		mg.SetConditions(Unblocked())
+	}
+
+	return nil
+}
+
+// ResolveReferences wraps rr so a reference to a resource that is not ready yet blocks the managed resource. The managed reconciler requeues
+// it shortly, like on any failure to resolve its references
+func ResolveReferences(rr managed.ReferenceResolver) managed.ReferenceResolver {
+	return managed.ReferenceResolverFn(func(ctx context.Context, mg resource.Managed) error {
+		err := rr.ResolveReferences(ctx, mg)
+		if common.IsReferenceNotReady(err) {
+			mg.SetConditions(ReferenceNotReady(err.Error()))
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if mg.GetCondition(TypeBlocked).Reason == ReasonReferenceNotReady {
+			mg.SetConditions(Unblocked())
+		}
+		return nil
+	})
+}
+
+// ServiceAccounts Returns the ServiceAccount managed resources a managed resource consuming a service account depends on. A reference is
+// followed through the name of the ServiceAccount, so the dependency holds before the service account is created & has an ID. A referenced
+// ServiceAccount that does not exist is returned without conditions, which blocks like any dependency that is not ready. Without a reference
+// the ServiceAccounts managing the service account of id are returned
+func ServiceAccounts(ctx context.Context, kube client.Reader, ref *xpv1.Reference, id string) ([]resource.Managed, error) {
+	if ref == nil {
+		return ServiceAccountsByID(ctx, kube, id)
+	}
+
+	sa := &saapi.ServiceAccount{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, sa); err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, errors.Wrap(err, errGetServiceAccount)
+		}
+		sa.SetName(ref.Name)
+	}
+	return []resource.Managed{sa}, nil
+}
+
+// ServiceAccountsByID Returns the ServiceAccount managed resources that manage the Confluent service account with the given id
+func ServiceAccountsByID(ctx context.Context, kube client.Reader, id string) ([]resource.Managed, error) {
+	if id == "" {
+		return nil, nil
+	}
+
+	l := &saapi.ServiceAccountList{}
+	if err := kube.List(ctx, l); err != nil {
+		return nil, errors.Wrap(err, errListServiceAccounts)
+	}
+
+	var deps []resource.Managed
+	for i := range l.Items {
+		if l.Items[i].Status.AtProvider.ID == id {
+			deps = append(deps, &l.Items[i])
+		}
+	}
+
+	return deps, nil
+}
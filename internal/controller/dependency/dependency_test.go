@@ -0,0 +1,164 @@
+package dependency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apikeyapi "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	"github.com/dfds/provider-confluent/apis/common"
+	erbapi "github.com/dfds/provider-confluent/apis/environmentrolebinding/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+func TestGate(t *testing.T) {
+	assert := assert.New(t)
+
+	ak := &apikeyapi.APIKey{}
+	sa := &saapi.ServiceAccount{}
+	sa.SetName("service-account")
+
+	// Dependency not ready
+	sa.SetConditions(xpv1.Creating())
+	err := Gate(ak, sa)
+	assert.Error(err)
+	assert.Equal(corev1.ConditionTrue, ak.GetCondition(TypeBlocked).Status)
+	assert.Contains(ak.GetCondition(TypeBlocked).Message, "service-account")
+
+	// Dependency ready clears the condition
+	sa.SetConditions(xpv1.Available())
+	assert.NoError(Gate(ak, sa))
+	assert.Equal(corev1.ConditionFalse, ak.GetCondition(TypeBlocked).Status)
+
+	// No dependencies
+	assert.NoError(Gate(&apikeyapi.APIKey{}))
+
+	// Deletion is never blocked
+	sa.SetConditions(xpv1.Deleting())
+	now := metav1.Now()
+	ak.SetDeletionTimestamp(&now)
+	assert.NoError(Gate(ak, sa))
+}
+
+func TestServiceAccountsByID(t *testing.T) {
+	assert := assert.New(t)
+
+	kube := &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			l := obj.(*saapi.ServiceAccountList)
+			l.Items = []saapi.ServiceAccount{{}, {}}
+			l.Items[0].SetName("first")
+			l.Items[0].Status.AtProvider.ID = "sa-11111"
+			l.Items[1].SetName("second")
+			l.Items[1].Status.AtProvider.ID = "sa-22222"
+			return nil
+		}),
+	}
+
+	deps, err := ServiceAccountsByID(context.Background(), kube, "sa-22222")
+	assert.NoError(err)
+	assert.Len(deps, 1)
+	assert.Equal("second", deps[0].GetName())
+
+	// Service accounts not managed by the provider are no dependency
+	deps, err = ServiceAccountsByID(context.Background(), kube, "sa-33333")
+	assert.NoError(err)
+	assert.Len(deps, 0)
+
+	// Empty id
+	deps, err = ServiceAccountsByID(context.Background(), kube, "")
+	assert.NoError(err)
+	assert.Len(deps, 0)
+}
+
+func TestServiceAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	// A referenced ServiceAccount is a dependency before it is created & has an ID
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			sa := obj.(*saapi.ServiceAccount)
+			sa.SetName("orders")
+			sa.SetConditions(xpv1.Creating())
+			return nil
+		}),
+	}
+	deps, err := ServiceAccounts(context.Background(), kube, &xpv1.Reference{Name: "orders"}, "")
+	assert.NoError(err)
+	assert.Len(deps, 1)
+	ak := &apikeyapi.APIKey{}
+	assert.Error(Gate(ak, deps...))
+	assert.Contains(ak.GetCondition(TypeBlocked).Message, "orders")
+
+	// A referenced ServiceAccount that does not exist blocks too
+	kube.MockGet = test.NewMockGetFn(kerrors.NewNotFound(schema.GroupResource{}, "orders"))
+	deps, err = ServiceAccounts(context.Background(), kube, &xpv1.Reference{Name: "orders"}, "")
+	assert.NoError(err)
+	assert.Equal([]string{"orders"}, NotReady(deps...))
+
+	kube.MockGet = test.NewMockGetFn(errors.New("boom"))
+	_, err = ServiceAccounts(context.Background(), kube, &xpv1.Reference{Name: "orders"}, "")
+	assert.Error(err)
+
+	// Without a reference the service account is looked up by its ID
+	kube.MockList = test.NewMockListFn(nil, func(obj client.ObjectList) error {
+		l := obj.(*saapi.ServiceAccountList)
+		l.Items = []saapi.ServiceAccount{{}}
+		l.Items[0].SetName("orders")
+		l.Items[0].Status.AtProvider.ID = "sa-11111"
+		return nil
+	})
+	deps, err = ServiceAccounts(context.Background(), kube, nil, "sa-11111")
+	assert.NoError(err)
+	assert.Len(deps, 1)
+}
+
+func TestResolveReferences(t *testing.T) {
+	assert := assert.New(t)
+
+	sa := &saapi.ServiceAccount{}
+	sa.SetName("service-account")
+	sa.Status.AtProvider.ID = "sa-11111"
+	sa.SetConditions(xpv1.Creating())
+
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			sa.DeepCopyInto(obj.(*saapi.ServiceAccount))
+			return nil
+		}),
+	}
+	rr := ResolveReferences(managed.NewAPISimpleReferenceResolver(kube))
+
+	erb := &erbapi.EnvironmentRoleBinding{}
+	erb.Spec.ForProvider.ServiceAccountRef = &xpv1.Reference{Name: "service-account"}
+
+	// The service account exists & has an ID, but is not ready yet
+	err := rr.ResolveReferences(context.Background(), erb)
+	assert.True(common.IsReferenceNotReady(err))
+	blocked := erb.GetCondition(TypeBlocked)
+	assert.Equal(corev1.ConditionTrue, blocked.Status)
+	assert.Equal(ReasonReferenceNotReady, blocked.Reason)
+	assert.Contains(blocked.Message, "ServiceAccount service-account is not ready")
+
+	// A ready service account is resolved & clears the condition
+	sa.SetConditions(xpv1.Available())
+	erb.Spec.ForProvider.ServiceAccount = ""
+	assert.NoError(rr.ResolveReferences(context.Background(), erb))
+	assert.Equal("sa-11111", erb.Spec.ForProvider.ServiceAccount)
+	assert.Equal(corev1.ConditionFalse, erb.GetCondition(TypeBlocked).Status)
+
+	// Other reasons to block are left alone
+	erb.SetConditions(CredentialsMissing("crossplane-system/confluent"))
+	assert.NoError(rr.ResolveReferences(context.Background(), erb))
+	assert.Equal(ReasonCredentialsMissing, erb.GetCondition(TypeBlocked).Reason)
+}
@@ -0,0 +1,68 @@
+package dnsforwarder
+
+import (
+	"github.com/dfds/provider-confluent/apis/dnsforwarder/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/dnsforwarder"
+)
+
+// observation Returns the DNSForwarder status matching a dns forwarder in Confluent Cloud
+func observation(df dnsforwarder.DNSForwarder) v1alpha1.DNSForwarderObservation {
+	return v1alpha1.DNSForwarderObservation{
+		ID:           df.ID,
+		Phase:        df.Phase,
+		Domains:      df.Domains,
+		DNSServerIPs: df.DNSServerIPs,
+	}
+}
+
+// upToDate Checks if a dns forwarder in Confluent Cloud matches the DNSForwarder parameters. The order of domains & dns servers is ignored,
+// and an empty name leaves the name chosen by Confluent
+func upToDate(dp v1alpha1.DNSForwarderParameters, df dnsforwarder.DNSForwarder) bool {
+	return (dp.Name == "" || dp.Name == df.Name) &&
+		dp.Gateway == df.Gateway &&
+		sameStrings(dp.Domains, df.Domains) &&
+		sameStrings(dp.DNSServerIPs, df.DNSServerIPs)
+}
+
+// changes Returns the update turning the observed dns forwarder into the desired one. Domains & dns servers are replaced as a whole
+func changes(dp v1alpha1.DNSForwarderParameters, df dnsforwarder.DNSForwarder) dnsforwarder.Update {
+	var u dnsforwarder.Update
+	if dp.Name != "" && dp.Name != df.Name {
+		u.Name = dp.Name
+	}
+	if !sameStrings(dp.Domains, df.Domains) {
+		u.Domains = dp.Domains
+	}
+	if !sameStrings(dp.DNSServerIPs, df.DNSServerIPs) {
+		u.DNSServerIPs = dp.DNSServerIPs
+	}
+
+	return u
+}
+
+// sameStrings Checks if a & b hold the same values, regardless of order
+func sameStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, v := range a {
+		if !contains(b, v) {
+			return false
+		}
+	}
+	for _, v := range b {
+		if !contains(a, v) {
+			return false
+		}
+	}
+	return true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
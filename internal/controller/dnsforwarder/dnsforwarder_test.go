@@ -0,0 +1,148 @@
+package dnsforwarder
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/dnsforwarder/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/dnsforwarder"
+)
+
+func TestUpToDate(t *testing.T) {
+	assert := assert.New(t)
+
+	dp := v1alpha1.DNSForwarderParameters{Gateway: "gw-12345", Domains: []string{"example.com", "example.org"}, DNSServerIPs: []string{"10.0.0.2"}}
+	df := dnsforwarder.DNSForwarder{Name: "dnsf-default", Gateway: "gw-12345", Domains: []string{"example.org", "example.com"}, DNSServerIPs: []string{"10.0.0.2"}}
+
+	// Order of the domains is ignored & no name leaves the name chosen by Confluent
+	assert.True(upToDate(dp, df))
+
+	dp.Name = "corp"
+	assert.False(upToDate(dp, df))
+	dp.Name = ""
+
+	df.DNSServerIPs = []string{"10.0.0.2", "10.0.0.3"}
+	assert.False(upToDate(dp, df))
+}
+
+func TestChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	dp := v1alpha1.DNSForwarderParameters{Name: "corp", Domains: []string{"example.com"}, DNSServerIPs: []string{"10.0.0.2"}}
+
+	u := changes(dp, dnsforwarder.DNSForwarder{Name: "corp", Domains: []string{"example.org"}, DNSServerIPs: []string{"10.0.0.2"}})
+	assert.Empty(u.Name)
+	assert.Equal([]string{"example.com"}, u.Domains)
+	assert.Empty(u.DNSServerIPs)
+
+	u = changes(dp, dnsforwarder.DNSForwarder{Name: "old", Domains: dp.Domains, DNSServerIPs: []string{"10.0.0.3"}})
+	assert.Equal("corp", u.Name)
+	assert.Empty(u.Domains)
+	assert.Equal([]string{"10.0.0.2"}, u.DNSServerIPs)
+}
+
+type fakeDNSForwarderClient struct {
+	forwarders map[string]dnsforwarder.DNSForwarder
+}
+
+func (f *fakeDNSForwarderClient) DNSForwarderCreate(_ context.Context, df dnsforwarder.DNSForwarder) (dnsforwarder.DNSForwarder, error) {
+	df.ID = "dnsf-12345"
+	df.Phase = "PROVISIONING"
+	f.forwarders[df.ID] = df
+	return df, nil
+}
+
+func (f *fakeDNSForwarderClient) DNSForwarderDescribe(_ context.Context, id string, environment string) (dnsforwarder.DNSForwarder, error) {
+	df, ok := f.forwarders[id]
+	if !ok || df.Environment != environment {
+		return dnsforwarder.DNSForwarder{}, dnsforwarder.ErrNotFound
+	}
+	return df, nil
+}
+
+func (f *fakeDNSForwarderClient) DNSForwarderUpdate(_ context.Context, id string, environment string, u dnsforwarder.Update) error {
+	df := f.forwarders[id]
+	if u.Name != "" {
+		df.Name = u.Name
+	}
+	if len(u.Domains) > 0 {
+		df.Domains = u.Domains
+	}
+	if len(u.DNSServerIPs) > 0 {
+		df.DNSServerIPs = u.DNSServerIPs
+	}
+	f.forwarders[id] = df
+	return nil
+}
+
+func (f *fakeDNSForwarderClient) DNSForwarderDelete(_ context.Context, id string, environment string) error {
+	if _, ok := f.forwarders[id]; !ok {
+		return dnsforwarder.ErrNotFound
+	}
+	delete(f.forwarders, id)
+	return nil
+}
+
+func TestLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeDNSForwarderClient{forwarders: map[string]dnsforwarder.DNSForwarder{}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	cr := &v1alpha1.DNSForwarder{}
+	cr.Spec.ForProvider = v1alpha1.DNSForwarderParameters{
+		Name:         "corp",
+		Environment:  "env-12345",
+		Gateway:      "gw-12345",
+		Domains:      []string{"example.com"},
+		DNSServerIPs: []string{"10.0.0.2"},
+	}
+
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+
+	_, err = e.Create(ctx, cr)
+	assert.NoError(err)
+	assert.Equal("dnsf-12345", meta.GetExternalName(cr))
+
+	// The dns forwarder is not ready until Confluent provisioned it
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(xpv1.ReasonCreating, cr.Status.GetCondition(xpv1.TypeReady).Reason)
+
+	df := fake.forwarders["dnsf-12345"]
+	df.Phase = "READY"
+	fake.forwarders["dnsf-12345"] = df
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(xpv1.ReasonAvailable, cr.Status.GetCondition(xpv1.TypeReady).Reason)
+
+	// Domains are updated in place
+	cr.Spec.ForProvider.Domains = []string{"example.com", "example.org"}
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+
+	// The gateway cannot be changed
+	cr.Spec.ForProvider.Gateway = "gw-67890"
+	_, err = e.Update(ctx, cr)
+	assert.EqualError(err, errGatewayImmutable)
+	cr.Spec.ForProvider.Gateway = "gw-12345"
+
+	assert.NoError(e.Delete(ctx, cr))
+	assert.Empty(fake.forwarders)
+	assert.NoError(e.Delete(ctx, cr), "deleting a dns forwarder that is gone should succeed")
+}
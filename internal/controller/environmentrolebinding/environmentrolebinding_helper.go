@@ -0,0 +1,131 @@
+package environmentrolebinding
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/environmentrolebinding/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/rolebinding"
+)
+
+// principalOf Returns the principal of a service account in role bindings, e.g. User:sa-abc123
+func principalOf(serviceAccount string) string {
+	return "User:" + serviceAccount
+}
+
+// bound Returns the principal & role of the role bindings last observed, or the desired ones before the first observation
+func bound(cr *v1alpha1.EnvironmentRoleBinding) (string, string) {
+	principal, role := cr.Status.AtProvider.Principal, cr.Status.AtProvider.Role
+	if principal == "" {
+		principal = principalOf(cr.Spec.ForProvider.ServiceAccount)
+	}
+	if role == "" {
+		role = cr.Spec.ForProvider.Role
+	}
+	return principal, role
+}
+
+// upToDate Checks if the role is bound to the service account in exactly the desired environments. The order of the environments is ignored
+func upToDate(p v1alpha1.EnvironmentRoleBindingParameters, observed v1alpha1.EnvironmentRoleBindingObservation) bool {
+	add, remove := diffEnvironments(p.Environments, observed.Environments)
+	return observed.Principal == principalOf(p.ServiceAccount) && observed.Role == p.Role && len(add) == 0 && len(remove) == 0
+}
+
+// validate Checks that the role is known to Confluent Cloud & the environments are distinct, so no role binding is attempted when any of them
+// would fail
+func validate(p v1alpha1.EnvironmentRoleBindingParameters) error {
+	if p.ServiceAccount == "" {
+		return errors.New(errNoServiceAccount)
+	}
+	if !rolebinding.IsKnownRole(p.Role) {
+		return errors.Errorf(errUnknownRole, p.Role, strings.Join(rolebinding.Roles, ", "))
+	}
+	for i, env := range p.Environments {
+		if contains(p.Environments[:i], env) {
+			return errors.Errorf(errDuplicateEnvironment, env)
+		}
+	}
+	return nil
+}
+
+// union Returns the distinct environments of desired & observed, sorted so they are always looked up in the same order
+func union(desired []string, observed []string) []string {
+	var envs []string
+	for _, env := range append(append([]string(nil), desired...), observed...) {
+		if !contains(envs, env) {
+			envs = append(envs, env)
+		}
+	}
+	sort.Strings(envs)
+	return envs
+}
+
+// listEnvironments Returns the environments among envs that role is bound in to principal. Bindings of the role to a cluster of an environment
+// are not bindings in the environment
+func listEnvironments(ctx context.Context, client rolebinding.IClient, principal string, role string, envs []string) ([]string, error) {
+	var bound []string
+	for _, env := range envs {
+		rbs, err := client.RoleBindingList(ctx, principal, rolebinding.Scope{Environment: env})
+		if err != nil {
+			return nil, errors.Wrapf(err, errListRoleBindings, principal, env)
+		}
+
+		for _, rb := range rbs {
+			if rb.Role == role && rb.Cluster == "" {
+				bound = append(bound, env)
+				break
+			}
+		}
+	}
+
+	return bound, nil
+}
+
+// syncEnvironments Binds role to principal in the desired environments missing from observed & removes it from the observed environments that
+// are not desired. Role bindings that are already gone are ignored
+func syncEnvironments(ctx context.Context, client rolebinding.IClient, principal string, role string, desired []string, observed []string) error {
+	add, remove := diffEnvironments(desired, observed)
+
+	for _, env := range add {
+		if err := client.RoleBindingCreate(ctx, rolebinding.RoleBinding{Principal: principal, Role: role, Environment: env}); err != nil {
+			return errors.Wrapf(err, errCreateRoleBinding, role, principal, env)
+		}
+	}
+	for _, env := range remove {
+		if err := client.RoleBindingDelete(ctx, rolebinding.RoleBinding{Principal: principal, Role: role, Environment: env}); err != nil && !rolebinding.IsNotFound(err) {
+			return errors.Wrapf(err, errDeleteRoleBinding, role, principal, env)
+		}
+	}
+
+	return nil
+}
+
+// diffEnvironments Returns the environments of desired missing from observed & the environments of observed missing from desired
+func diffEnvironments(desired []string, observed []string) ([]string, []string) {
+	var add, remove []string
+
+	for _, env := range desired {
+		if !contains(observed, env) {
+			add = append(add, env)
+		}
+	}
+	for _, env := range observed {
+		if !contains(desired, env) {
+			remove = append(remove, env)
+		}
+	}
+
+	return add, remove
+}
+
+func contains(envs []string, env string) bool {
+	for _, x := range envs {
+		if x == env {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+package environmentrolebinding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/environmentrolebinding/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/rolebinding"
+)
+
+type fakeRoleBindings struct {
+	bindings []rolebinding.RoleBinding
+}
+
+func (f *fakeRoleBindings) RoleBindingList(_ context.Context, principal string, s rolebinding.Scope) ([]rolebinding.RoleBinding, error) {
+	var rbs []rolebinding.RoleBinding
+	for _, rb := range f.bindings {
+		if rb.Principal == principal && rb.Scope() == s {
+			rbs = append(rbs, rb)
+		}
+	}
+	return rbs, nil
+}
+
+func (f *fakeRoleBindings) RoleBindingCreate(_ context.Context, rb rolebinding.RoleBinding) error {
+	f.bindings = append(f.bindings, rb)
+	return nil
+}
+
+func (f *fakeRoleBindings) RoleBindingDelete(_ context.Context, rb rolebinding.RoleBinding) error {
+	for i, x := range f.bindings {
+		if x == rb {
+			f.bindings = append(f.bindings[:i], f.bindings[i+1:]...)
+			return nil
+		}
+	}
+	return rolebinding.ErrNotFound
+}
+
+// environments Returns the environments role is bound in to principal, leaving out its bindings to clusters
+func (f *fakeRoleBindings) environments(principal string, role string) []string {
+	var envs []string
+	for _, rb := range f.bindings {
+		if rb.Principal == principal && rb.Role == role && rb.Cluster == "" {
+			envs = append(envs, rb.Environment)
+		}
+	}
+	return envs
+}
+
+// reconcile Observes cr & creates or updates it like the managed reconciler would
+func reconcile(t *testing.T, e *external, cr *v1alpha1.EnvironmentRoleBinding) {
+	ctx := context.Background()
+
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(t, err)
+	switch {
+	case !obs.ResourceExists:
+		_, err = e.Create(ctx, cr)
+	case !obs.ResourceUpToDate:
+		_, err = e.Update(ctx, cr)
+	}
+	assert.NoError(t, err)
+
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(t, err)
+	assert.True(t, obs.ResourceUpToDate)
+}
+
+func TestEnvironmentsChange(t *testing.T) {
+	assert := assert.New(t)
+
+	principal := "User:sa-abc123"
+	fake := &fakeRoleBindings{bindings: []rolebinding.RoleBinding{
+		// Bindings of other roles, principals & clusters are left alone
+		{Principal: principal, Role: "EnvironmentAdmin", Environment: "env-aaa111"},
+		{Principal: principal, Role: "DeveloperRead", Environment: "env-aaa111", Cluster: "lkc-aaa111"},
+		{Principal: "User:sa-def456", Role: "DeveloperRead", Environment: "env-bbb222"},
+	}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	cr := &v1alpha1.EnvironmentRoleBinding{}
+	cr.Spec.ForProvider = v1alpha1.EnvironmentRoleBindingParameters{
+		ServiceAccount: "sa-abc123",
+		Role:           "DeveloperRead",
+		Environments:   []string{"env-aaa111", "env-bbb222"},
+	}
+	reconcile(t, e, cr)
+	assert.ElementsMatch([]string{"env-aaa111", "env-bbb222"}, fake.environments(principal, "DeveloperRead"))
+
+	// Added environments are bound & removed ones unbound
+	cr.Spec.ForProvider.Environments = []string{"env-bbb222", "env-ccc333"}
+	reconcile(t, e, cr)
+	assert.ElementsMatch([]string{"env-bbb222", "env-ccc333"}, fake.environments(principal, "DeveloperRead"))
+	assert.ElementsMatch([]string{"env-bbb222", "env-ccc333"}, cr.Status.AtProvider.Environments)
+
+	// A changed role is bound anew & the old role unbound
+	cr.Spec.ForProvider.Role = "DeveloperWrite"
+	reconcile(t, e, cr)
+	assert.Empty(fake.environments(principal, "DeveloperRead"))
+	assert.ElementsMatch([]string{"env-bbb222", "env-ccc333"}, fake.environments(principal, "DeveloperWrite"))
+
+	assert.NoError(e.Delete(context.Background(), cr))
+	assert.Empty(fake.environments(principal, "DeveloperWrite"))
+	assert.Len(fake.bindings, 3)
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	p := v1alpha1.EnvironmentRoleBindingParameters{ServiceAccount: "sa-abc123", Role: "EnvironmentAdmin", Environments: []string{"env-aaa111", "env-bbb222"}}
+	assert.NoError(validate(p))
+
+	unknown := p
+	unknown.Role = "ClusterOwner"
+	assert.Error(validate(unknown))
+
+	duplicate := p
+	duplicate.Environments = []string{"env-aaa111", "env-aaa111"}
+	assert.EqualError(validate(duplicate), "environment env-aaa111 is listed more than once")
+
+	unresolved := p
+	unresolved.ServiceAccount = ""
+	assert.EqualError(validate(unresolved), errNoServiceAccount)
+}
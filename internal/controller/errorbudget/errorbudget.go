@@ -0,0 +1,171 @@
+package errorbudget
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+var (
+	// Enabled turns on throttling of all reconciles while the error rate of Confluent commands exceeds Threshold. Disabled by default
+	Enabled bool
+
+	// Threshold is the share of failed Confluent commands within Window, from 0 to 1, at which reconciles are throttled
+	Threshold = 0.5
+
+	// RecoveryThreshold is the share of failed Confluent commands within Window below which throttling ends. It is lower than Threshold, so
+	// an error rate around the threshold does not toggle throttling on every command
+	RecoveryThreshold = 0.1
+
+	// Window is the rolling period over which the error rate is computed
+	Window = 5 * time.Minute
+
+	// MinCalls is the number of Confluent commands within Window below which throttling never starts, so a few failures of a quiet provider
+	// do not throttle it
+	MinCalls = 20
+
+	// PollFactor is how many times longer resources wait before their next reconcile while throttled
+	PollFactor = 4
+
+	// Concurrency is the number of reconciles across all controllers that may run at once while throttled
+	Concurrency = 1
+
+	// Log receives a message whenever throttling starts or ends. It discards everything unless set
+	Log = logging.NewNopLogger()
+)
+
+// buckets is the number of slices Window is split into, the error rate forgets a slice at a time
+const buckets = 10
+
+var now = time.Now
+
+var budget = &Budget{}
+
+type bucket struct {
+	start    time.Time
+	calls    int
+	failures int
+}
+
+// Budget tracks the rolling error rate of Confluent commands & whether reconciles are throttled because of it
+type Budget struct {
+	mu        sync.Mutex
+	buckets   []bucket
+	throttled bool
+	slots     chan struct{}
+}
+
+// Record counts the outcome of a Confluent command. Transient failures, like 5xx or 429 responses & timeouts, use up the error budget. Any
+// other failed command counts as a call, errors not coming from a Confluent command are ignored
+func Record(err error) {
+	if !Enabled {
+		return
+	}
+	if err != nil && !clients.IsTransient(err) && !clients.IsPermanent(err) {
+		return
+	}
+	budget.record(now(), clients.IsTransient(err))
+}
+
+// Throttled reports whether reconciles are currently throttled
+func Throttled() bool {
+	return budget.evaluate(now())
+}
+
+// Reconciler wraps inner so that, while throttled, resources wait PollFactor times longer before their next reconcile & at most
+// Concurrency reconciles run at once across all controllers. Reconciles are passed through unchanged when throttling is disabled
+func Reconciler(inner reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		if !Enabled || !Throttled() {
+			return inner.Reconcile(ctx, req)
+		}
+
+		slots := budget.slotsOf()
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-ctx.Done():
+			return reconcile.Result{}, ctx.Err()
+		}
+
+		res, err := inner.Reconcile(ctx, req)
+		if err == nil && res.RequeueAfter > 0 && PollFactor > 1 {
+			res.RequeueAfter *= time.Duration(PollFactor)
+		}
+		return res, err
+	})
+}
+
+func (b *Budget) record(t time.Time, failed bool) {
+	b.mu.Lock()
+	width := Window / buckets
+	if n := len(b.buckets); n == 0 || t.Sub(b.buckets[n-1].start) >= width {
+		b.buckets = append(b.buckets, bucket{start: t})
+	}
+	last := &b.buckets[len(b.buckets)-1]
+	last.calls++
+	if failed {
+		last.failures++
+	}
+	b.mu.Unlock()
+
+	b.evaluate(t)
+}
+
+// evaluate Starts or ends throttling according to the error rate within Window before t & returns whether reconciles are throttled
+func (b *Budget) evaluate(t time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for len(b.buckets) > 0 && t.Sub(b.buckets[0].start) >= Window {
+		b.buckets = b.buckets[1:]
+	}
+
+	calls, failures := 0, 0
+	for _, bk := range b.buckets {
+		calls += bk.calls
+		failures += bk.failures
+	}
+	rate := 0.0
+	if calls > 0 {
+		rate = float64(failures) / float64(calls)
+	}
+	errorRate.Set(rate)
+
+	switch {
+	case !b.throttled && calls >= MinCalls && rate >= Threshold:
+		b.throttled = true
+		b.slots = make(chan struct{}, concurrency())
+		throttled.Set(1)
+		transitions.WithLabelValues(stateThrottled).Inc()
+		Log.Info("Throttling reconciles, the error rate of Confluent commands exceeds the error budget", "errorRate", rate, "calls", calls, "window", Window.String(), "pollFactor", PollFactor, "concurrency", concurrency())
+	case b.throttled && rate < RecoveryThreshold:
+		b.throttled = false
+		throttled.Set(0)
+		transitions.WithLabelValues(stateRecovered).Inc()
+		Log.Info("Stopped throttling reconciles, the error rate of Confluent commands recovered", "errorRate", rate, "calls", calls, "window", Window.String())
+	}
+
+	return b.throttled
+}
+
+func (b *Budget) slotsOf() chan struct{} {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.slots == nil {
+		b.slots = make(chan struct{}, concurrency())
+	}
+	return b.slots
+}
+
+func concurrency() int {
+	if Concurrency < 1 {
+		return 1
+	}
+	return Concurrency
+}
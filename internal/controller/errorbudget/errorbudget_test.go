@@ -0,0 +1,115 @@
+package errorbudget
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+var (
+	errUnavailable = errors.Wrap(clients.CommandError([]byte(`{"errors":[{"status":"503","detail":"Service Unavailable"}]}`)), "unknown error")
+	errBadRequest  = errors.Wrap(clients.CommandError([]byte(`{"errors":[{"status":"400","detail":"Invalid partition count."}]}`)), "unknown error")
+)
+
+func setup(t *testing.T) *time.Time {
+	current := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	budget = &Budget{}
+	Enabled, MinCalls = true, 10
+	t.Cleanup(func() {
+		now = time.Now
+		budget = &Budget{}
+		Enabled, MinCalls = false, 20
+	})
+	return &current
+}
+
+func TestThrottling(t *testing.T) {
+	assert := assert.New(t)
+	current := setup(t)
+
+	// Failures of a quiet provider & permanent failures never throttle
+	for i := 0; i < MinCalls-1; i++ {
+		Record(errUnavailable)
+	}
+	assert.False(Throttled())
+	budget = &Budget{}
+	for i := 0; i < 10; i++ {
+		Record(errBadRequest)
+	}
+	assert.False(Throttled())
+
+	// Errors not coming from a Confluent command are ignored
+	for i := 0; i < 20; i++ {
+		Record(errors.New("cannot update status"))
+	}
+	for i := 0; i < 10; i++ {
+		Record(errUnavailable)
+	}
+	assert.True(Throttled())
+
+	// A lower error rate does not end throttling until it drops below the recovery threshold
+	*current = current.Add(Window / 2)
+	for i := 0; i < 30; i++ {
+		Record(nil)
+	}
+	assert.True(Throttled())
+
+	// Throttling ends once the failures left the window
+	*current = current.Add(Window/2 + time.Second)
+	assert.False(Throttled())
+}
+
+func TestReconciler(t *testing.T) {
+	assert := assert.New(t)
+	setup(t)
+
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	})
+
+	res, err := Reconciler(inner).Reconcile(context.Background(), reconcile.Request{})
+	assert.NoError(err)
+	assert.Equal(time.Minute, res.RequeueAfter)
+
+	for i := 0; i < MinCalls; i++ {
+		Record(errUnavailable)
+	}
+	res, err = Reconciler(inner).Reconcile(context.Background(), reconcile.Request{})
+	assert.NoError(err)
+	assert.Equal(4*time.Minute, res.RequeueAfter)
+}
+
+func TestReconcilerConcurrency(t *testing.T) {
+	setup(t)
+	for i := 0; i < MinCalls; i++ {
+		Record(errUnavailable)
+	}
+
+	// A reconcile is running, so no other one may start while throttled
+	release := make(chan struct{})
+	started := make(chan struct{})
+	blocking := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		close(started)
+		<-release
+		return reconcile.Result{}, nil
+	})
+	go func() { _, _ = Reconciler(blocking).Reconcile(context.Background(), reconcile.Request{}) }()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		t.Error("reconciled beyond the throttled concurrency")
+		return reconcile.Result{}, nil
+	})).Reconcile(ctx, reconcile.Request{})
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	close(release)
+}
@@ -0,0 +1,39 @@
+package errorbudget
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// States entered by a transition of the error budget
+const (
+	stateThrottled = "throttled"
+	stateRecovered = "recovered"
+)
+
+var (
+	throttled = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "provider_confluent",
+		Subsystem: "error_budget",
+		Name:      "throttled",
+		Help:      "Whether reconciles are throttled because the error rate of Confluent commands exceeds the error budget.",
+	})
+
+	errorRate = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "provider_confluent",
+		Subsystem: "error_budget",
+		Name:      "error_rate",
+		Help:      "Share of Confluent commands that failed transiently within the error budget window.",
+	})
+
+	transitions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "provider_confluent",
+		Subsystem: "error_budget",
+		Name:      "transitions_total",
+		Help:      "Number of times throttling started or ended, by the state entered.",
+	}, []string{"state"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(throttled, errorRate, transitions)
+}
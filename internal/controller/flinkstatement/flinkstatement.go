@@ -0,0 +1,363 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flinkstatement
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/flinkstatement"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/provisioning"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
+)
+
+const (
+	errNotMyType                   = "managed resource is not a FlinkStatement custom resource"
+	errTrackPCUsage                = "cannot track ProviderConfig usage"
+	errGetPC                       = "cannot get ProviderConfig"
+	errGetCreds                    = "cannot get credentials"
+	errGetCABundle                 = "cannot get CA bundle"
+	errNewClient                   = "cannot create new Service"
+	errAuthCredentials             = "invalid client credentials"
+	errDestructiveUpdateNotAllowed = "cannot update flink statement. DeletionPolicy is set to Orphan, but statements can only be changed by deleting and submitting them again"
+)
+
+var (
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
+		credParts := strings.Split(string(clientCreds), ":")
+
+		if len(credParts) != 2 {
+			return nil, errors.New(errAuthCredentials)
+		}
+
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
+
+		if authErr != nil {
+			return nil, authErr
+		}
+
+		fsConfig := flinkstatement.Config{
+			APICredentials: apiCreds,
+		}
+
+		return flinkstatement.NewClient(fsConfig).(interface{}), nil
+	}
+)
+
+// Setup adds a controller that reconciles FlinkStatement managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.FlinkStatementGroupKind)
+
+	o := controller.Options{
+		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+	}
+
+	failures := retry.NewTracker()
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.FlinkStatementGroupVersionKind),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: createAndConvertClientFunc})),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient(), v1alpha1.FlinkStatementKind), providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.FlinkStatement).Spec.ForProvider.Environment })),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	newObject := func() client.Object { return &v1alpha1.FlinkStatement{} }
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.FlinkStatement{}).
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.FlinkStatementList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), newObject, failures.Reconciler(provisioning.NewReconciler(mgr.GetClient(), newObject, r)))))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.FlinkStatement)
+	if !ok {
+		return nil, errors.New(errNotMyType)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	deps, err := dependency.ServiceAccounts(ctx, c.kube, nil, cr.Spec.ForProvider.ServiceAccount)
+	if err != nil {
+		return nil, err
+	}
+	if err := dependency.Gate(cr, deps...); err != nil {
+		return nil, err
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	var apiCredentials clients.APICredentials
+
+	for _, value := range pc.Spec.APICredentials {
+		if value.Identifier == v1alpha1.SchemeGroupVersion.Identifier() {
+			apiCredentials = value
+
+			break
+		}
+	}
+
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// A 'client' used to connect to the external resource API. In practice this
+	// would be something like an AWS SDK client.
+	service interface{}
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.FlinkStatement)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMyType)
+	}
+
+	// Support for importing resource using external name
+	name, _ := externalname.Get(cr, cr.Name)
+
+	// Confluent
+	var client = c.service.(flinkstatement.IClient)
+	fs, err := client.FlinkStatementDescribe(ctx, v1alpha1.FlinkStatementObservation{Name: name, Environment: cr.Spec.ForProvider.Environment})
+
+	if err != nil {
+		if flinkstatement.IsNotFound(err) {
+			return managed.ExternalObservation{
+				ResourceExists:    false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, nil // returning nil because we want create on not found
+		}
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, err
+	}
+
+	// Statement phase
+	updateObservation(cr, name, fs)
+	cr.Status.SetConditions(phaseCondition(cr.Status.AtProvider))
+	if err := provisioning.Record(ctx, c.kube, cr, isProvisioning(cr.Status.AtProvider.Phase)); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// A statement being deleted to be submitted again is left alone until it is gone
+	if cr.Status.AtProvider.Phase == phaseDeleting {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  true,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+
+	// Diff
+	if statementChanged(cr.Spec.ForProvider, cr.Status.AtProvider, fs) {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.FlinkStatement)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMyType)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	name, _ := externalname.Get(cr, cr.Name)
+
+	var client = c.service.(flinkstatement.IClient)
+	if err := client.FlinkStatementCreate(ctx, name, cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	meta.SetExternalName(cr, name)
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.Status.AtProvider = v1alpha1.FlinkStatementObservation{
+		Name:        name,
+		Environment: cr.Spec.ForProvider.Environment,
+		ComputePool: cr.Spec.ForProvider.ComputePool,
+		Statement:   cr.Spec.ForProvider.Statement,
+		Phase:       phasePending,
+	}
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+// Update deletes a changed statement, it is submitted again by Create once Observe no longer finds it
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.FlinkStatement)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMyType)
+	}
+
+	if cr.GetDeletionPolicy() != xpv1.DeletionDelete {
+		return managed.ExternalUpdate{}, errors.New(errDestructiveUpdateNotAllowed)
+	}
+
+	var client = c.service.(flinkstatement.IClient)
+	if err := c.stopAndDelete(ctx, client, cr.Status.AtProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	cr.Status.AtProvider.Phase = phaseDeleting
+	cr.Status.AtProvider.ExceptionMessage = ""
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.FlinkStatement)
+	if !ok {
+		return errors.New(errNotMyType)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	var client = c.service.(flinkstatement.IClient)
+
+	return c.stopAndDelete(ctx, client, cr.Status.AtProvider)
+}
+
+// stopAndDelete Stops a statement that is still running and deletes it. A statement that no longer exists is considered deleted
+func (c *external) stopAndDelete(ctx context.Context, client flinkstatement.IClient, fo v1alpha1.FlinkStatementObservation) error {
+	if isActive(fo.Phase) {
+		if err := client.FlinkStatementStop(ctx, fo); err != nil && !flinkstatement.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if err := client.FlinkStatementDelete(ctx, fo); err != nil && !flinkstatement.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package flinkstatement
+
+import (
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/flinkstatement"
+)
+
+// Flink statement phases
+const (
+	phasePending   = "PENDING"
+	phaseRunning   = "RUNNING"
+	phaseCompleted = "COMPLETED"
+	phaseFailed    = "FAILED"
+	phaseStopping  = "STOPPING"
+	phaseStopped   = "STOPPED"
+	phaseDeleting  = "DELETING"
+)
+
+// updateObservation Sets the observed statement & phase on the FlinkStatement status and reports whether it changed
+func updateObservation(cr *v1alpha1.FlinkStatement, name string, fs flinkstatement.DescribeResponse) bool {
+	observed := cr.Status.AtProvider
+	observed.Name = name
+	observed.Environment = cr.Spec.ForProvider.Environment
+	if fs.ComputePool != "" {
+		observed.ComputePool = fs.ComputePool
+	}
+	if fs.Statement != "" {
+		observed.Statement = fs.Statement
+	}
+	observed.Phase = strings.ToUpper(fs.Status)
+	observed.ExceptionMessage = ""
+	if observed.Phase == phaseFailed {
+		observed.ExceptionMessage = fs.StatusDetail
+	}
+
+	if observed == cr.Status.AtProvider {
+		return false
+	}
+
+	cr.Status.AtProvider = observed
+	return true
+}
+
+// phaseCondition Returns the Ready condition matching the observed statement phase
+func phaseCondition(o v1alpha1.FlinkStatementObservation) xpv1.Condition {
+	return clients.PhaseCondition(o.Phase, "flink statement", o.ExceptionMessage)
+}
+
+// isActive Reports whether a statement in the given phase has to be stopped before it is deleted
+func isActive(phase string) bool {
+	return phase == phasePending || phase == phaseRunning
+}
+
+// isProvisioning Reports whether a statement in the given phase is still being submitted or deleted, so it is polled more often
+func isProvisioning(phase string) bool {
+	return phase == phasePending || phase == phaseDeleting
+}
+
+// statementChanged Reports whether fp differs from the submitted statement. Statements are immutable, so any change requires deleting and submitting the statement again
+func statementChanged(fp v1alpha1.FlinkStatementParameters, fo v1alpha1.FlinkStatementObservation, fs flinkstatement.DescribeResponse) bool {
+	if normalizeStatement(fp.Statement) != normalizeStatement(fo.Statement) {
+		return true
+	}
+	if fo.ComputePool != "" && fp.ComputePool != fo.ComputePool {
+		return true
+	}
+
+	// Flink reports default properties as well, so only the configured ones are compared
+	for k, v := range fp.Properties {
+		if observed, ok := fs.Properties[k]; fs.Properties != nil && (!ok || observed != v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// normalizeStatement Strips the surrounding whitespace & trailing semicolon Flink may add or remove from a statement
+func normalizeStatement(s string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(s), ";"))
+}
@@ -0,0 +1,185 @@
+package flinkstatement
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/flinkstatement"
+	"github.com/dfds/provider-confluent/internal/controller/provisioning"
+)
+
+func TestUpdateObservation(t *testing.T) {
+	assert := assert.New(t)
+
+	cr := v1alpha1.FlinkStatement{}
+	cr.Spec.ForProvider.Environment = "env-vvvvv"
+	fs := flinkstatement.DescribeResponse{Statement: "SELECT 1;", ComputePool: "lfcp-12345", Status: "pending"}
+
+	assert.True(updateObservation(&cr, "statement", fs))
+	assert.Equal(phasePending, cr.Status.AtProvider.Phase)
+	assert.Equal("statement", cr.Status.AtProvider.Name)
+	assert.Equal("env-vvvvv", cr.Status.AtProvider.Environment)
+	assert.Equal("SELECT 1;", cr.Status.AtProvider.Statement)
+	assert.False(updateObservation(&cr, "statement", fs))
+
+	// Exception message is surfaced for failed statements only
+	fs.Status = phaseFailed
+	fs.StatusDetail = "Table 'orders' does not exist"
+	assert.True(updateObservation(&cr, "statement", fs))
+	assert.Equal("Table 'orders' does not exist", cr.Status.AtProvider.ExceptionMessage)
+
+	fs.Status = phaseRunning
+	assert.True(updateObservation(&cr, "statement", fs))
+	assert.Empty(cr.Status.AtProvider.ExceptionMessage)
+}
+
+func TestPhaseCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(phaseCondition(v1alpha1.FlinkStatementObservation{Phase: phaseRunning}).Equal(xpv1.Available()))
+	assert.True(phaseCondition(v1alpha1.FlinkStatementObservation{Phase: phaseCompleted}).Equal(xpv1.Available()))
+	assert.True(phaseCondition(v1alpha1.FlinkStatementObservation{Phase: phasePending}).Equal(xpv1.Creating()))
+	assert.True(phaseCondition(v1alpha1.FlinkStatementObservation{}).Equal(xpv1.Creating()))
+
+	failed := phaseCondition(v1alpha1.FlinkStatementObservation{Phase: phaseFailed, ExceptionMessage: "Table 'orders' does not exist"})
+	assert.Equal(xpv1.ReasonUnavailable, failed.Reason)
+	assert.Equal("flink statement is failed: Table 'orders' does not exist", failed.Message)
+}
+
+func TestStatementChanged(t *testing.T) {
+	assert := assert.New(t)
+
+	fp := v1alpha1.FlinkStatementParameters{Statement: "SELECT * FROM orders;", ComputePool: "lfcp-12345", Properties: map[string]string{"sql.local-time-zone": "UTC"}}
+	fo := v1alpha1.FlinkStatementObservation{Statement: "SELECT * FROM orders", ComputePool: "lfcp-12345"}
+	fs := flinkstatement.DescribeResponse{Properties: map[string]string{"sql.local-time-zone": "UTC", "sql.current-catalog": "env-vvvvv"}}
+
+	// Trailing semicolon and default properties are ignored
+	assert.False(statementChanged(fp, fo, fs))
+
+	// Edited statement text
+	fp.Statement = "SELECT id FROM orders"
+	assert.True(statementChanged(fp, fo, fs))
+	fp.Statement = "SELECT * FROM orders"
+
+	// Moved to another compute pool
+	fp.ComputePool = "lfcp-67890"
+	assert.True(statementChanged(fp, fo, fs))
+	fp.ComputePool = "lfcp-12345"
+
+	// Changed property
+	fp.Properties["sql.local-time-zone"] = "Europe/Copenhagen"
+	assert.True(statementChanged(fp, fo, fs))
+}
+
+type fakeFlinkStatementClient struct {
+	flinkstatement.IClient
+	statements map[string]flinkstatement.DescribeResponse
+	stopped    []string
+}
+
+func (f *fakeFlinkStatementClient) FlinkStatementCreate(_ context.Context, name string, fp v1alpha1.FlinkStatementParameters) error {
+	f.statements[name] = flinkstatement.DescribeResponse{Name: name, Statement: fp.Statement, ComputePool: fp.ComputePool, Status: phasePending}
+	return nil
+}
+
+func (f *fakeFlinkStatementClient) FlinkStatementDescribe(_ context.Context, fo v1alpha1.FlinkStatementObservation) (flinkstatement.DescribeResponse, error) {
+	fs, ok := f.statements[fo.Name]
+	if !ok {
+		return fs, flinkstatement.ErrNotFound
+	}
+	return fs, nil
+}
+
+func (f *fakeFlinkStatementClient) FlinkStatementStop(_ context.Context, fo v1alpha1.FlinkStatementObservation) error {
+	f.stopped = append(f.stopped, fo.Name)
+	return nil
+}
+
+func (f *fakeFlinkStatementClient) FlinkStatementDelete(_ context.Context, fo v1alpha1.FlinkStatementObservation) error {
+	if _, ok := f.statements[fo.Name]; !ok {
+		return flinkstatement.ErrNotFound
+	}
+	delete(f.statements, fo.Name)
+	return nil
+}
+
+func TestStatementLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeFlinkStatementClient{statements: map[string]flinkstatement.DescribeResponse{}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	cr := v1alpha1.FlinkStatement{}
+	cr.Name = "orders-enriched"
+	cr.SetDeletionPolicy(xpv1.DeletionDelete)
+	cr.Spec.ForProvider = v1alpha1.FlinkStatementParameters{Statement: "SELECT * FROM orders", ComputePool: "lfcp-12345", Environment: "env-vvvvv"}
+
+	// Not submitted yet
+	obs, err := e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+
+	// Submit
+	_, err = e.Create(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal("orders-enriched", meta.GetExternalName(&cr))
+	assert.Contains(fake.statements, "orders-enriched")
+
+	// Pending statements are polled more often
+	_, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.Contains(cr.GetAnnotations(), provisioning.AnnotationKeyProvisioningSince)
+
+	// Running
+	fake.statements["orders-enriched"] = flinkstatement.DescribeResponse{Statement: "SELECT * FROM orders", ComputePool: "lfcp-12345", Status: phaseRunning}
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+	assert.NotContains(cr.GetAnnotations(), provisioning.AnnotationKeyProvisioningSince)
+
+	// Edited statement is stopped and deleted, then submitted again
+	cr.Spec.ForProvider.Statement = "SELECT id FROM orders"
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+
+	_, err = e.Update(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal([]string{"orders-enriched"}, fake.stopped)
+	assert.NotContains(fake.statements, "orders-enriched")
+
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+
+	_, err = e.Create(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal("SELECT id FROM orders", fake.statements["orders-enriched"].Statement)
+
+	// Orphaned statements are not deleted to apply an edit
+	cr.SetDeletionPolicy(xpv1.DeletionOrphan)
+	_, err = e.Update(ctx, &cr)
+	assert.EqualError(err, errDestructiveUpdateNotAllowed)
+
+	// Failed statement surfaces the exception
+	fake.statements["orders-enriched"] = flinkstatement.DescribeResponse{Statement: "SELECT id FROM orders", ComputePool: "lfcp-12345", Status: phaseFailed, StatusDetail: "Table 'orders' does not exist"}
+	_, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal("Table 'orders' does not exist", cr.Status.AtProvider.ExceptionMessage)
+	assert.Equal(xpv1.ReasonUnavailable, cr.GetCondition(xpv1.TypeReady).Reason)
+
+	// Delete
+	assert.NoError(e.Delete(ctx, &cr))
+	assert.NotContains(fake.statements, "orders-enriched")
+
+	// Deleting a statement that is already gone succeeds
+	assert.NoError(e.Delete(ctx, &cr))
+}
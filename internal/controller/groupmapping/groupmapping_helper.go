@@ -0,0 +1,178 @@
+package groupmapping
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/groupmapping/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/groupmapping"
+	"github.com/dfds/provider-confluent/internal/clients/rolebinding"
+)
+
+// observation Returns the GroupMapping status matching a group mapping & its role bindings in Confluent Cloud
+func observation(gm groupmapping.GroupMapping, bindings []v1alpha1.GroupRoleBinding) v1alpha1.GroupMappingObservation {
+	return v1alpha1.GroupMappingObservation{
+		ID:           gm.ID,
+		Principal:    gm.Principal(),
+		DisplayName:  gm.Name,
+		Description:  gm.Description,
+		Filter:       gm.Filter,
+		RoleBindings: bindings,
+	}
+}
+
+// upToDate Checks if a group mapping & its role bindings in Confluent Cloud match the GroupMapping parameters. The order of the role
+// bindings is ignored
+func upToDate(gp v1alpha1.GroupMappingParameters, gm groupmapping.GroupMapping, bindings []v1alpha1.GroupRoleBinding) bool {
+	add, remove := diffRoleBindings(gp.RoleBindings, bindings)
+	return gp.DisplayName == gm.Name && gp.Description == gm.Description && gp.Filter == gm.Filter && len(add) == 0 && len(remove) == 0
+}
+
+// changes Returns the update turning the observed group mapping into the desired one & whether there is anything to update
+func changes(gp v1alpha1.GroupMappingParameters, observed v1alpha1.GroupMappingObservation) (groupmapping.Update, bool) {
+	var u groupmapping.Update
+	if gp.DisplayName != observed.DisplayName {
+		u.Name = gp.DisplayName
+	}
+	if gp.Description != observed.Description {
+		description := gp.Description
+		u.Description = &description
+	}
+	if gp.Filter != observed.Filter {
+		u.Filter = gp.Filter
+	}
+
+	return u, u.Name != "" || u.Description != nil || u.Filter != ""
+}
+
+// validateRoleBindings Checks that the roles of bindings are known to Confluent Cloud & bound in a valid scope, so no role binding is
+// attempted when any of them would fail
+func validateRoleBindings(bindings []v1alpha1.GroupRoleBinding) error {
+	for i, b := range bindings {
+		if !rolebinding.IsKnownRole(b.Role) {
+			return errors.Errorf(errUnknownRole, b.Role, strings.Join(rolebinding.Roles, ", "))
+		}
+		if b.Cluster != "" && b.Environment == "" {
+			return errors.Errorf(errClusterWithoutEnv, b.Role, b.Cluster)
+		}
+		if containsRoleBinding(bindings[:i], b) {
+			return errors.Errorf(errDuplicateRoleBinding, b.Role)
+		}
+	}
+	return nil
+}
+
+// scopes Returns the distinct scopes of the desired & observed role bindings, sorted so they are always looked up in the same order
+func scopes(desired []v1alpha1.GroupRoleBinding, observed []v1alpha1.GroupRoleBinding) []rolebinding.Scope {
+	var ss []rolebinding.Scope
+	for _, b := range append(append([]v1alpha1.GroupRoleBinding(nil), desired...), observed...) {
+		s := rolebinding.Scope{Environment: b.Environment, Cluster: b.Cluster}
+		if !containsScope(ss, s) {
+			ss = append(ss, s)
+		}
+	}
+
+	sort.Slice(ss, func(i, j int) bool {
+		if ss[i].Environment != ss[j].Environment {
+			return ss[i].Environment < ss[j].Environment
+		}
+		return ss[i].Cluster < ss[j].Cluster
+	})
+
+	return ss
+}
+
+// listRoleBindings Returns the role bindings of principal in each of ss
+func listRoleBindings(ctx context.Context, client rolebinding.IClient, principal string, ss []rolebinding.Scope) ([]v1alpha1.GroupRoleBinding, error) {
+	var bindings []v1alpha1.GroupRoleBinding
+	for _, s := range ss {
+		rbs, err := client.RoleBindingList(ctx, principal, s)
+		if err != nil {
+			return nil, errors.Wrapf(err, errListRoleBindings, principal)
+		}
+
+		for _, rb := range rbs {
+			b := v1alpha1.GroupRoleBinding{Role: rb.Role, Environment: rb.Environment, Cluster: rb.Cluster}
+			if !containsRoleBinding(bindings, b) {
+				bindings = append(bindings, b)
+			}
+		}
+	}
+
+	return bindings, nil
+}
+
+// syncRoleBindings Binds the desired roles missing from observed to principal & removes the observed role bindings that are not desired.
+// Role bindings that are already gone are ignored
+func syncRoleBindings(ctx context.Context, client rolebinding.IClient, principal string, desired []v1alpha1.GroupRoleBinding, observed []v1alpha1.GroupRoleBinding) error {
+	add, remove := diffRoleBindings(desired, observed)
+
+	for _, b := range add {
+		if err := client.RoleBindingCreate(ctx, roleBinding(principal, b)); err != nil {
+			return errors.Wrapf(err, errCreateRoleBinding, describe(b), principal)
+		}
+	}
+	for _, b := range remove {
+		if err := client.RoleBindingDelete(ctx, roleBinding(principal, b)); err != nil && !rolebinding.IsNotFound(err) {
+			return errors.Wrapf(err, errDeleteRoleBinding, describe(b), principal)
+		}
+	}
+
+	return nil
+}
+
+// diffRoleBindings Returns the role bindings of desired missing from observed & the role bindings of observed missing from desired
+func diffRoleBindings(desired []v1alpha1.GroupRoleBinding, observed []v1alpha1.GroupRoleBinding) ([]v1alpha1.GroupRoleBinding, []v1alpha1.GroupRoleBinding) {
+	var add, remove []v1alpha1.GroupRoleBinding
+
+	for _, d := range desired {
+		if !containsRoleBinding(observed, d) {
+			add = append(add, d)
+		}
+	}
+	for _, o := range observed {
+		if !containsRoleBinding(desired, o) {
+			remove = append(remove, o)
+		}
+	}
+
+	return add, remove
+}
+
+func roleBinding(principal string, b v1alpha1.GroupRoleBinding) rolebinding.RoleBinding {
+	return rolebinding.RoleBinding{Principal: principal, Role: b.Role, Environment: b.Environment, Cluster: b.Cluster}
+}
+
+// describe Returns the role of b & the scope it is bound in, e.g. CloudClusterAdmin in env-abc123/lkc-abc123
+func describe(b v1alpha1.GroupRoleBinding) string {
+	switch {
+	case b.Cluster != "":
+		return fmt.Sprintf("%s in %s/%s", b.Role, b.Environment, b.Cluster)
+	case b.Environment != "":
+		return fmt.Sprintf("%s in %s", b.Role, b.Environment)
+	default:
+		return fmt.Sprintf("%s in the organization", b.Role)
+	}
+}
+
+func containsRoleBinding(bindings []v1alpha1.GroupRoleBinding, b v1alpha1.GroupRoleBinding) bool {
+	for _, x := range bindings {
+		if x == b {
+			return true
+		}
+	}
+	return false
+}
+
+func containsScope(ss []rolebinding.Scope, s rolebinding.Scope) bool {
+	for _, x := range ss {
+		if x == s {
+			return true
+		}
+	}
+	return false
+}
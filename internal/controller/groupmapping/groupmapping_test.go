@@ -0,0 +1,129 @@
+package groupmapping
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/groupmapping/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/groupmapping"
+	"github.com/dfds/provider-confluent/internal/clients/rolebinding"
+)
+
+type fakeRoleBindings struct {
+	bindings []rolebinding.RoleBinding
+	deleted  []rolebinding.RoleBinding
+}
+
+func (f *fakeRoleBindings) RoleBindingList(_ context.Context, principal string, s rolebinding.Scope) ([]rolebinding.RoleBinding, error) {
+	var rbs []rolebinding.RoleBinding
+	for _, rb := range f.bindings {
+		if rb.Principal == principal && rb.Scope() == s {
+			rbs = append(rbs, rb)
+		}
+	}
+	return rbs, nil
+}
+
+func (f *fakeRoleBindings) RoleBindingCreate(_ context.Context, rb rolebinding.RoleBinding) error {
+	f.bindings = append(f.bindings, rb)
+	return nil
+}
+
+func (f *fakeRoleBindings) RoleBindingDelete(_ context.Context, rb rolebinding.RoleBinding) error {
+	for i, x := range f.bindings {
+		if x == rb {
+			f.bindings = append(f.bindings[:i], f.bindings[i+1:]...)
+			f.deleted = append(f.deleted, rb)
+			return nil
+		}
+	}
+	return rolebinding.ErrNotFound
+}
+
+func TestUpToDate(t *testing.T) {
+	assert := assert.New(t)
+
+	gp := v1alpha1.GroupMappingParameters{
+		DisplayName: "engineering",
+		Filter:      `"engineering" in groups`,
+		RoleBindings: []v1alpha1.GroupRoleBinding{
+			{Role: "MetricsViewer"},
+			{Role: "DeveloperRead", Environment: "env-abc123", Cluster: "lkc-abc123"},
+		},
+	}
+	gm := groupmapping.GroupMapping{ID: "group-abc123", Name: "engineering", Filter: `"engineering" in groups`}
+
+	// Order of the role bindings is ignored
+	assert.True(upToDate(gp, gm, []v1alpha1.GroupRoleBinding{gp.RoleBindings[1], gp.RoleBindings[0]}))
+
+	drifted := gm
+	drifted.Filter = `"sales" in groups`
+	assert.False(upToDate(gp, drifted, gp.RoleBindings))
+	assert.False(upToDate(gp, gm, gp.RoleBindings[:1]))
+	assert.False(upToDate(gp, gm, append(gp.RoleBindings, v1alpha1.GroupRoleBinding{Role: "EnvironmentAdmin", Environment: "env-abc123"})))
+}
+
+func TestChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	gp := v1alpha1.GroupMappingParameters{DisplayName: "engineering", Filter: `"engineering" in groups`}
+
+	_, changed := changes(gp, v1alpha1.GroupMappingObservation{DisplayName: "engineering", Filter: `"engineering" in groups`})
+	assert.False(changed)
+
+	u, changed := changes(gp, v1alpha1.GroupMappingObservation{DisplayName: "engineering", Description: "Engineers", Filter: `"sales" in groups`})
+	assert.True(changed)
+	assert.Empty(u.Name)
+	assert.Equal(`"engineering" in groups`, u.Filter)
+	if assert.NotNil(u.Description) {
+		assert.Empty(*u.Description)
+	}
+}
+
+func TestValidateRoleBindings(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validateRoleBindings([]v1alpha1.GroupRoleBinding{{Role: "OrganizationAdmin"}, {Role: "CloudClusterAdmin", Environment: "env-abc123", Cluster: "lkc-abc123"}}))
+
+	err := validateRoleBindings([]v1alpha1.GroupRoleBinding{{Role: "MetricsViewer"}, {Role: "ClusterOwner"}})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "unknown role ClusterOwner")
+	}
+	assert.Error(validateRoleBindings([]v1alpha1.GroupRoleBinding{{Role: "CloudClusterAdmin", Cluster: "lkc-abc123"}}))
+	assert.Error(validateRoleBindings([]v1alpha1.GroupRoleBinding{{Role: "MetricsViewer"}, {Role: "MetricsViewer"}}))
+}
+
+func TestSyncRoleBindings(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	principal := "User:group-abc123"
+	rbs := &fakeRoleBindings{bindings: []rolebinding.RoleBinding{
+		{Principal: principal, Role: "MetricsViewer"},
+		{Principal: principal, Role: "EnvironmentAdmin", Environment: "env-abc123"},
+		{Principal: "User:group-def456", Role: "EnvironmentAdmin", Environment: "env-abc123"},
+	}}
+	desired := []v1alpha1.GroupRoleBinding{
+		{Role: "MetricsViewer"},
+		{Role: "DeveloperRead", Environment: "env-abc123", Cluster: "lkc-abc123"},
+	}
+	previous := []v1alpha1.GroupRoleBinding{{Role: "MetricsViewer"}, {Role: "EnvironmentAdmin", Environment: "env-abc123"}}
+
+	// The environment of the removed role binding is only known from the previous observation
+	observed, err := listRoleBindings(ctx, rbs, principal, scopes(desired, previous))
+	assert.NoError(err)
+	assert.ElementsMatch(previous, observed)
+
+	assert.NoError(syncRoleBindings(ctx, rbs, principal, desired, observed))
+	assert.Equal([]rolebinding.RoleBinding{{Principal: principal, Role: "EnvironmentAdmin", Environment: "env-abc123"}}, rbs.deleted)
+
+	observed, err = listRoleBindings(ctx, rbs, principal, scopes(desired, observed))
+	assert.NoError(err)
+	assert.ElementsMatch(desired, observed)
+
+	// Role bindings removed outside of the provider are ignored
+	assert.NoError(syncRoleBindings(ctx, rbs, principal, nil, append(observed, v1alpha1.GroupRoleBinding{Role: "BillingAdmin"})))
+	assert.Len(rbs.bindings, 1)
+}
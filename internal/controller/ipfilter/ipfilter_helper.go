@@ -0,0 +1,186 @@
+package ipfilter
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dfds/provider-confluent/apis/ipfilter/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/ipfilter"
+)
+
+// Conditions
+const (
+	TypeDegraded xpv1.ConditionType = "Degraded"
+
+	ReasonEgressExcluded xpv1.ConditionReason = "EgressExcluded"
+	ReasonEgressAllowed  xpv1.ConditionReason = "EgressAllowed"
+)
+
+const (
+	msgEgressExcluded = "ip filter does not allow access from the provider egress range(s) %s, applying it locks the provider out"
+
+	errParseCIDR = "cannot parse cidr block"
+)
+
+// Resource groups
+const (
+	resourceGroupManagement = "management"
+	resourceGroupMultiple   = "multiple"
+)
+
+// EgressExcluded indicates that an IP filter does not allow access from some of the provider egress ranges
+func EgressExcluded(ranges []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEgressExcluded,
+		Message:            fmt.Sprintf(msgEgressExcluded, strings.Join(ranges, ", ")),
+	}
+}
+
+// EgressAllowed indicates that an IP filter allows access from all provider egress ranges
+func EgressAllowed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonEgressAllowed,
+	}
+}
+
+// observation Returns the IPFilter status matching an ip filter in Confluent Cloud
+func observation(f ipfilter.IPFilter) v1alpha1.IPFilterObservation {
+	return v1alpha1.IPFilterObservation{
+		ID:              f.ID,
+		FilterName:      f.Name,
+		ResourceGroup:   f.ResourceGroup,
+		OperationGroups: f.OperationGroups,
+		IPGroups:        f.IPGroups,
+	}
+}
+
+// resourceGroup Returns the resource group of the IPFilter parameters, defaulting to management
+func resourceGroup(fp v1alpha1.IPFilterParameters) string {
+	if fp.ResourceGroup == "" {
+		return resourceGroupManagement
+	}
+	return fp.ResourceGroup
+}
+
+// upToDate Checks if an ip filter in Confluent Cloud matches the IPFilter parameters. The order of ip groups & operation groups is ignored, operation groups only matter for the multiple resource group
+func upToDate(fp v1alpha1.IPFilterParameters, f ipfilter.IPFilter) bool {
+	u := changes(fp, observation(f))
+	return u.Name == "" && u.ResourceGroup == "" &&
+		len(u.AddIPGroups) == 0 && len(u.RemoveIPGroups) == 0 &&
+		len(u.AddOperationGroups) == 0 && len(u.RemoveOperationGroups) == 0
+}
+
+// changes Returns the update turning the observed ip filter into the desired one
+func changes(fp v1alpha1.IPFilterParameters, fo v1alpha1.IPFilterObservation) ipfilter.Update {
+	var u ipfilter.Update
+	if fp.FilterName != fo.FilterName {
+		u.Name = fp.FilterName
+	}
+	if rg := resourceGroup(fp); rg != fo.ResourceGroup {
+		u.ResourceGroup = rg
+	}
+	u.AddIPGroups, u.RemoveIPGroups = diffStrings(fp.IPGroups, fo.IPGroups)
+	if resourceGroup(fp) == resourceGroupMultiple {
+		u.AddOperationGroups, u.RemoveOperationGroups = diffStrings(fp.OperationGroups, fo.OperationGroups)
+	}
+
+	return u
+}
+
+// excludedEgress Returns the egress ranges not contained in any of the cidr blocks. An egress range may be a single address
+func excludedEgress(egress []string, cidrBlocks []string) ([]string, error) {
+	blocks := make([]*net.IPNet, 0, len(cidrBlocks))
+	for _, b := range cidrBlocks {
+		_, n, err := net.ParseCIDR(b)
+		if err != nil {
+			return nil, errors.Wrap(err, errParseCIDR)
+		}
+		blocks = append(blocks, n)
+	}
+
+	var excluded []string
+	for _, e := range egress {
+		n, err := parseRange(e)
+		if err != nil {
+			return nil, err
+		}
+		if !containedIn(n, blocks) {
+			excluded = append(excluded, e)
+		}
+	}
+
+	return excluded, nil
+}
+
+// parseRange Parses a cidr block or a single address, which is treated as a block of one
+func parseRange(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, errors.Errorf("%s: %s", errParseCIDR, s)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 8 * net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, n, err := net.ParseCIDR(s)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseCIDR)
+	}
+	return n, nil
+}
+
+// containedIn Checks if a range lies entirely within one of the blocks
+func containedIn(n *net.IPNet, blocks []*net.IPNet) bool {
+	ones, bits := n.Mask.Size()
+	for _, b := range blocks {
+		bOnes, bBits := b.Mask.Size()
+		if bits == bBits && bOnes <= ones && b.Contains(n.IP) {
+			return true
+		}
+	}
+	return false
+}
+
+// diffStrings Returns the values of desired missing from observed & the values of observed missing from desired
+func diffStrings(desired []string, observed []string) ([]string, []string) {
+	var add, remove []string
+
+	for _, d := range desired {
+		if !contains(observed, d) {
+			add = append(add, d)
+		}
+	}
+	for _, o := range observed {
+		if !contains(desired, o) {
+			remove = append(remove, o)
+		}
+	}
+
+	return add, remove
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
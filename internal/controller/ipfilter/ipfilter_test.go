@@ -0,0 +1,162 @@
+package ipfilter
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/dfds/provider-confluent/apis/ipfilter/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/ipfilter"
+	"github.com/dfds/provider-confluent/internal/clients/ipgroup"
+)
+
+func TestExcludedEgress(t *testing.T) {
+	assert := assert.New(t)
+
+	blocks := []string{"192.0.2.0/24", "198.51.100.0/25"}
+
+	excluded, err := excludedEgress([]string{"192.0.2.17", "192.0.2.128/26", "198.51.100.0/25"}, blocks)
+	assert.NoError(err)
+	assert.Empty(excluded)
+
+	// Ranges only partly covered, or not at all, are excluded
+	excluded, err = excludedEgress([]string{"198.51.100.0/24", "203.0.113.5"}, blocks)
+	assert.NoError(err)
+	assert.Equal([]string{"198.51.100.0/24", "203.0.113.5"}, excluded)
+
+	_, err = excludedEgress([]string{"not-an-ip"}, blocks)
+	assert.Error(err)
+	_, err = excludedEgress([]string{"192.0.2.17"}, []string{"192.0.2.0/33"})
+	assert.Error(err)
+}
+
+func TestChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	fp := v1alpha1.IPFilterParameters{FilterName: "office-only", IPGroups: []string{"ipg-11111", "ipg-22222"}, OperationGroups: []string{"SCHEMA"}}
+	fo := v1alpha1.IPFilterObservation{FilterName: "office-only", ResourceGroup: "management", IPGroups: []string{"ipg-22222", "ipg-11111"}}
+
+	// Unset resource group defaults to management, operation groups are ignored for it
+	assert.True(upToDate(fp, ipfilter.IPFilter{Name: fo.FilterName, ResourceGroup: fo.ResourceGroup, IPGroups: fo.IPGroups}))
+
+	fp.ResourceGroup = resourceGroupMultiple
+	u := changes(fp, fo)
+	assert.Equal(resourceGroupMultiple, u.ResourceGroup)
+	assert.Equal([]string{"SCHEMA"}, u.AddOperationGroups)
+
+	fp.IPGroups = []string{"ipg-11111", "ipg-33333"}
+	u = changes(fp, fo)
+	assert.Equal([]string{"ipg-33333"}, u.AddIPGroups)
+	assert.Equal([]string{"ipg-22222"}, u.RemoveIPGroups)
+}
+
+type fakeIPFilterClient struct {
+	ipfilter.IClient
+	filters map[string]ipfilter.IPFilter
+}
+
+func (f *fakeIPFilterClient) IPFilterCreate(_ context.Context, fp v1alpha1.IPFilterParameters) (ipfilter.IPFilter, error) {
+	filter := ipfilter.IPFilter{ID: "ipf-12345", Name: fp.FilterName, ResourceGroup: resourceGroup(fp), IPGroups: fp.IPGroups}
+	f.filters[filter.ID] = filter
+	return filter, nil
+}
+
+func (f *fakeIPFilterClient) IPFilterDescribe(_ context.Context, id string) (ipfilter.IPFilter, error) {
+	filter, ok := f.filters[id]
+	if !ok {
+		return filter, ipfilter.ErrNotFound
+	}
+	return filter, nil
+}
+
+type fakeIPGroupClient struct {
+	ipgroup.IClient
+	groups map[string]ipgroup.IPGroup
+}
+
+func (f *fakeIPGroupClient) IPGroupDescribe(_ context.Context, id string) (ipgroup.IPGroup, error) {
+	group, ok := f.groups[id]
+	if !ok {
+		return group, ipgroup.ErrNotFound
+	}
+	return group, nil
+}
+
+func TestEgressSafeguard(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	EgressCIDRs = []string{"203.0.113.10"}
+	defer func() { EgressCIDRs = nil }()
+
+	filters := &fakeIPFilterClient{filters: map[string]ipfilter.IPFilter{}}
+	groups := &fakeIPGroupClient{groups: map[string]ipgroup.IPGroup{
+		"ipg-11111": {ID: "ipg-11111", Name: "office", CIDRBlocks: []string{"192.0.2.0/24"}},
+		"ipg-22222": {ID: "ipg-22222", Name: "provider", CIDRBlocks: []string{"203.0.113.0/24"}},
+	}}
+	e := &external{service: filters, ipGroupService: groups, kube: test.NewMockClient()}
+
+	cr := v1alpha1.IPFilter{}
+	cr.Spec.ForProvider = v1alpha1.IPFilterParameters{FilterName: "office-only", IPGroups: []string{"ipg-11111"}}
+
+	// Not created yet
+	obs, err := e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+
+	// The filter is still created, but reported as locking the provider out
+	_, err = e.Create(ctx, &cr)
+	assert.NoError(err)
+	assert.Equal("ipf-12345", meta.GetExternalName(&cr))
+	degraded := cr.GetCondition(TypeDegraded)
+	assert.Equal(corev1.ConditionTrue, degraded.Status)
+	assert.Equal(ReasonEgressExcluded, degraded.Reason)
+	assert.Contains(degraded.Message, "203.0.113.10")
+
+	// Adding the provider ip group clears the warning
+	filters.filters["ipf-12345"] = ipfilter.IPFilter{ID: "ipf-12345", Name: "office-only", ResourceGroup: "management", IPGroups: []string{"ipg-11111", "ipg-22222"}}
+	cr.Spec.ForProvider.IPGroups = []string{"ipg-11111", "ipg-22222"}
+	obs, err = e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(ReasonEgressAllowed, cr.GetCondition(TypeDegraded).Reason)
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+}
+
+func TestExternalNameChanged(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	filters := &fakeIPFilterClient{filters: map[string]ipfilter.IPFilter{
+		"ipf-67890": {ID: "ipf-67890", Name: "someone-elses", ResourceGroup: "management", IPGroups: []string{"ipg-22222"}},
+	}}
+	e := &external{service: filters, ipGroupService: &fakeIPGroupClient{}, kube: test.NewMockClient()}
+
+	cr := v1alpha1.IPFilter{}
+	cr.Spec.ForProvider = v1alpha1.IPFilterParameters{FilterName: "office-only", IPGroups: []string{"ipg-11111"}}
+	_, err := e.Create(ctx, &cr)
+	assert.NoError(err)
+
+	// The filter of another external name is not adopted
+	meta.SetExternalName(&cr, "ipf-67890")
+	_, err = e.Observe(ctx, &cr)
+	assert.EqualError(err, "external name was changed from ipf-12345 to ipf-67890, restore it or recreate the managed resource to manage another resource")
+	assert.Equal("ipf-12345", cr.Status.AtProvider.ID)
+
+	meta.SetExternalName(&cr, "ipf-12345")
+	obs, err := e.Observe(ctx, &cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+}
+
+func TestCreateWithoutIPGroups(t *testing.T) {
+	e := &external{service: &fakeIPFilterClient{filters: map[string]ipfilter.IPFilter{}}, kube: test.NewMockClient()}
+
+	_, err := e.Create(context.Background(), &v1alpha1.IPFilter{})
+	assert.EqualError(t, err, errNoIPGroups)
+}
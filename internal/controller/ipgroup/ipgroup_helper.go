@@ -0,0 +1,59 @@
+package ipgroup
+
+import (
+	"github.com/dfds/provider-confluent/apis/ipgroup/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/ipgroup"
+)
+
+// observation Returns the IPGroup status matching an ip group in Confluent Cloud
+func observation(ig ipgroup.IPGroup) v1alpha1.IPGroupObservation {
+	return v1alpha1.IPGroupObservation{
+		ID:         ig.ID,
+		GroupName:  ig.Name,
+		CIDRBlocks: ig.CIDRBlocks,
+	}
+}
+
+// upToDate Checks if an ip group in Confluent Cloud matches the IPGroup parameters. The order of the cidr blocks is ignored
+func upToDate(ip v1alpha1.IPGroupParameters, ig ipgroup.IPGroup) bool {
+	add, remove := diffStrings(ip.CIDRBlocks, ig.CIDRBlocks)
+	return ip.GroupName == ig.Name && len(add) == 0 && len(remove) == 0
+}
+
+// changes Returns the update turning the observed ip group into the desired one
+func changes(ip v1alpha1.IPGroupParameters, io v1alpha1.IPGroupObservation) ipgroup.Update {
+	var u ipgroup.Update
+	if ip.GroupName != io.GroupName {
+		u.Name = ip.GroupName
+	}
+	u.AddCIDRBlocks, u.RemoveCIDRBlocks = diffStrings(ip.CIDRBlocks, io.CIDRBlocks)
+
+	return u
+}
+
+// diffStrings Returns the values of desired missing from observed & the values of observed missing from desired
+func diffStrings(desired []string, observed []string) ([]string, []string) {
+	var add, remove []string
+
+	for _, d := range desired {
+		if !contains(observed, d) {
+			add = append(add, d)
+		}
+	}
+	for _, o := range observed {
+		if !contains(desired, o) {
+			remove = append(remove, o)
+		}
+	}
+
+	return add, remove
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
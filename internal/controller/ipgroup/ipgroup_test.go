@@ -0,0 +1,39 @@
+package ipgroup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/ipgroup/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/ipgroup"
+)
+
+func TestUpToDate(t *testing.T) {
+	assert := assert.New(t)
+
+	ip := v1alpha1.IPGroupParameters{GroupName: "office", CIDRBlocks: []string{"192.0.2.0/24", "198.51.100.0/24"}}
+
+	// Order of the cidr blocks is ignored
+	assert.True(upToDate(ip, ipgroup.IPGroup{Name: "office", CIDRBlocks: []string{"198.51.100.0/24", "192.0.2.0/24"}}))
+
+	assert.False(upToDate(ip, ipgroup.IPGroup{Name: "vpn", CIDRBlocks: []string{"192.0.2.0/24", "198.51.100.0/24"}}))
+	assert.False(upToDate(ip, ipgroup.IPGroup{Name: "office", CIDRBlocks: []string{"192.0.2.0/24"}}))
+	assert.False(upToDate(ip, ipgroup.IPGroup{Name: "office", CIDRBlocks: []string{"192.0.2.0/24", "198.51.100.0/24", "203.0.113.0/24"}}))
+}
+
+func TestChanges(t *testing.T) {
+	assert := assert.New(t)
+
+	ip := v1alpha1.IPGroupParameters{GroupName: "office", CIDRBlocks: []string{"192.0.2.0/24", "198.51.100.0/24"}}
+
+	u := changes(ip, v1alpha1.IPGroupObservation{GroupName: "office", CIDRBlocks: []string{"192.0.2.0/24", "203.0.113.0/24"}})
+	assert.Empty(u.Name)
+	assert.Equal([]string{"198.51.100.0/24"}, u.AddCIDRBlocks)
+	assert.Equal([]string{"203.0.113.0/24"}, u.RemoveCIDRBlocks)
+
+	u = changes(ip, v1alpha1.IPGroupObservation{GroupName: "vpn", CIDRBlocks: ip.CIDRBlocks})
+	assert.Equal("office", u.Name)
+	assert.Empty(u.AddCIDRBlocks)
+	assert.Empty(u.RemoveCIDRBlocks)
+}
@@ -0,0 +1,403 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kafkacluster
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/kafkacluster/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/provisioning"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
+)
+
+const (
+	errNotMyType       = "managed resource is not a KafkaCluster custom resource"
+	errTrackPCUsage    = "cannot track ProviderConfig usage"
+	errGetPC           = "cannot get ProviderConfig"
+	errGetCreds        = "cannot get credentials"
+	errGetCABundle     = "cannot get CA bundle"
+	errNewClient       = "cannot create new Service"
+	errAuthCredentials = "invalid client credentials"
+
+	errDedicatedWithoutCKU = "dedicated kafka cluster needs a cku"
+	errCKUWithoutDedicated = "cku can only be set on a dedicated kafka cluster, not on a %s one"
+	errImmutable           = "cannot change %s of kafka cluster %s, it can only be set when the cluster is created"
+	errPendingGone         = "kafka cluster %s created by a previous attempt no longer exists"
+	errPendingUnknown      = "a previous attempt may have created kafka cluster %s in environment %s. If %s is that cluster, set it as external name to adopt it"
+)
+
+var (
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
+		credParts := strings.Split(string(clientCreds), ":")
+
+		if len(credParts) != 2 {
+			return nil, errors.New(errAuthCredentials)
+		}
+
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
+
+		if authErr != nil {
+			return nil, authErr
+		}
+
+		kcConfig := kafkacluster.Config{
+			APICredentials: apiCreds,
+		}
+
+		return kafkacluster.NewClient(kcConfig).(interface{}), nil
+	}
+)
+
+// Setup adds a controller that reconciles KafkaCluster managed resources.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	name := managed.ControllerName(v1alpha1.KafkaClusterGroupKind)
+
+	o := controller.Options{
+		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+	}
+
+	failures := retry.NewTracker()
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.KafkaClusterGroupVersionKind),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: createAndConvertClientFunc})),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.KafkaCluster).Spec.ForProvider.Environment })),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	newObject := func() client.Object { return &v1alpha1.KafkaCluster{} }
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.KafkaCluster{}).
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.KafkaClusterList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), newObject, failures.Reconciler(provisioning.NewReconciler(mgr.GetClient(), newObject, r)))))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.KafkaCluster)
+	if !ok {
+		return nil, errors.New(errNotMyType)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	var apiCredentials clients.APICredentials
+
+	for _, value := range pc.Spec.APICredentials {
+		if value.Identifier == v1alpha1.SchemeGroupVersion.Identifier() {
+			apiCredentials = value
+
+			break
+		}
+	}
+
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// A 'client' used to connect to the external resource API. In practice this
+	// would be something like an AWS SDK client.
+	service interface{}
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.KafkaCluster)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMyType)
+	}
+
+	// The external name is the ID of the cluster, which is only known once it is created or set to import an existing one
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+	if err := externalname.CheckID(cr, cr.Status.AtProvider.ID); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Confluent
+	var client = c.service.(kafkacluster.IClient)
+	kc, err := client.ClusterDescribe(ctx, id, cr.Spec.ForProvider.Environment)
+
+	if err != nil {
+		if kafkacluster.IsNotFound(err) {
+			return managed.ExternalObservation{
+				ResourceExists:    false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, nil // returning nil because we want create on not found
+		}
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, err
+	}
+
+	// Cluster status
+	cr.Status.AtProvider = observation(kc, cr.Spec.ForProvider.Environment)
+	cr.Status.SetConditions(clients.PhaseCondition(kc.Status, "kafka cluster", ""))
+	pending := clients.NormalizePhase(kc.Status) == clients.PhaseProvisioning
+	if err := provisioning.Record(ctx, c.kube, cr, pending); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// A cluster that is still provisioning or resizing can't be changed until it is done
+	if pending {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  true,
+			ConnectionDetails: connectionDetails(cr.Status.AtProvider),
+		}, nil
+	}
+
+	// Diff
+	if !upToDate(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			ConnectionDetails: connectionDetails(cr.Status.AtProvider),
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: connectionDetails(cr.Status.AtProvider),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.KafkaCluster)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMyType)
+	}
+
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// Record the name before creating the cluster & its ID as soon as it is created, so a retry after a failure to record the cluster adopts
+	// it instead of creating another one
+	pending := cr.Status.AtProvider.PendingName != ""
+	cr.Status.AtProvider.PendingName = cr.GetName()
+
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	kp := cr.Spec.ForProvider
+	var client = c.service.(kafkacluster.IClient)
+	kc, adopted, err := c.pendingCluster(ctx, cr, pending)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if !adopted {
+		created, err := client.ClusterCreate(ctx, cr.GetName(), kp.Environment, kp.CloudProvider, kp.Region, kp.Availability, kp.Type, cku(kp))
+		if err != nil {
+			// A rejected request created no cluster, so the retry creates it without looking for it
+			if clients.IsRejected(err) {
+				cr.Status.AtProvider.PendingName = ""
+			}
+			return managed.ExternalCreation{}, err
+		}
+		kc = created
+
+		cr.Status.AtProvider.ID = kc.ID
+		if err := c.kube.Status().Update(ctx, cr); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
+	meta.SetExternalName(cr, kc.ID)
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.Status.AtProvider = observation(kc, kp.Environment)
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr.Status.AtProvider),
+	}, nil
+}
+
+// pendingCluster Returns the cluster created by a previous attempt of Create & whether there is one. The cluster is only adopted through the
+// ID recorded by that attempt, as the names of clusters are not unique. A pending cluster without a recorded ID is refused when a cluster
+// of its name exists, as it can't be told apart from a cluster of another owner
+func (c *external) pendingCluster(ctx context.Context, cr *v1alpha1.KafkaCluster, pending bool) (kafkacluster.Cluster, bool, error) {
+	if !pending {
+		return kafkacluster.Cluster{}, false, nil
+	}
+
+	var client = c.service.(kafkacluster.IClient)
+	env := cr.Spec.ForProvider.Environment
+
+	if id := cr.Status.AtProvider.ID; id != "" {
+		kc, err := client.ClusterDescribe(ctx, id, env)
+		if kafkacluster.IsNotFound(err) {
+			return kafkacluster.Cluster{}, false, errors.Errorf(errPendingGone, id)
+		}
+		return kc, err == nil, err
+	}
+
+	existing, err := client.ClusterByName(ctx, cr.GetName(), env)
+	switch {
+	case kafkacluster.IsNotFound(err):
+		return kafkacluster.Cluster{}, false, nil
+	case err != nil:
+		return kafkacluster.Cluster{}, false, err
+	}
+	return kafkacluster.Cluster{}, false, errors.Errorf(errPendingUnknown, cr.GetName(), env, existing.ID)
+}
+
+// Update expands or shrinks a dedicated cluster, the other parameters of a cluster can't be changed
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.KafkaCluster)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMyType)
+	}
+
+	if err := validate(cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if changed := immutableChanges(cr.Spec.ForProvider, cr.Status.AtProvider); len(changed) > 0 {
+		return managed.ExternalUpdate{}, errors.Errorf(errImmutable, strings.Join(changed, ", "), meta.GetExternalName(cr))
+	}
+
+	var client = c.service.(kafkacluster.IClient)
+	if ckuChanged(cr.Spec.ForProvider, cr.Status.AtProvider) {
+		if err := client.ClusterUpdate(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider.Environment, cku(cr.Spec.ForProvider)); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: connectionDetails(cr.Status.AtProvider),
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.KafkaCluster)
+	if !ok {
+		return errors.New(errNotMyType)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	var client = c.service.(kafkacluster.IClient)
+	if err := client.ClusterDelete(ctx, meta.GetExternalName(cr), cr.Spec.ForProvider.Environment); err != nil && !kafkacluster.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package kafkacluster
+
+import (
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/pkg/errors"
+
+	"github.com/dfds/provider-confluent/apis/kafkacluster/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster"
+)
+
+// ConnectionSecretBootstrapEndpoint is the key of the bootstrap endpoint in the connection secret of a KafkaCluster
+const ConnectionSecretBootstrapEndpoint = "bootstrap-endpoint"
+
+const typeDedicated = "dedicated"
+
+// observation Returns the KafkaCluster status matching a kafka cluster in Confluent Cloud. Confluent reports the type, cloud provider &
+// availability in its own casing, they are observed the way they are declared
+func observation(kc kafkacluster.Cluster, environment string) v1alpha1.KafkaClusterObservation {
+	return v1alpha1.KafkaClusterObservation{
+		ID:            kc.ID,
+		Environment:   environment,
+		CloudProvider: normalize(kc.Provider),
+		Region:        kc.Region,
+		Availability:  normalize(kc.Availability),
+		Type:          normalize(kc.Type),
+		CKU:           kc.ClusterSize,
+		Endpoint:      kc.Bootstrap(),
+		Status:        kc.Status,
+	}
+}
+
+// normalize Returns s in lower case with underscores replaced by dashes, e.g. SINGLE_ZONE becomes single-zone
+func normalize(s string) string {
+	return strings.ReplaceAll(strings.ToLower(s), "_", "-")
+}
+
+// validate Checks that a dedicated cluster declares its CKU & no other type of cluster does
+func validate(kp v1alpha1.KafkaClusterParameters) error {
+	if kp.Type == typeDedicated && kp.CKU == nil {
+		return errors.New(errDedicatedWithoutCKU)
+	}
+	if kp.Type != typeDedicated && kp.CKU != nil {
+		return errors.Errorf(errCKUWithoutDedicated, kp.Type)
+	}
+	return nil
+}
+
+// cku Returns the desired CKU of a KafkaCluster, 0 when it has none
+func cku(kp v1alpha1.KafkaClusterParameters) int {
+	if kp.CKU == nil {
+		return 0
+	}
+	return *kp.CKU
+}
+
+// immutableChanges Returns the parameters of a KafkaCluster that differ from the observed cluster but can't be changed once it is created.
+// Parameters that are not observed are ignored
+func immutableChanges(kp v1alpha1.KafkaClusterParameters, ko v1alpha1.KafkaClusterObservation) []string {
+	var changed []string
+	if ko.CloudProvider != "" && kp.CloudProvider != ko.CloudProvider {
+		changed = append(changed, "cloudProvider")
+	}
+	if ko.Region != "" && kp.Region != ko.Region {
+		changed = append(changed, "region")
+	}
+	if ko.Availability != "" && kp.Availability != "" && kp.Availability != ko.Availability {
+		changed = append(changed, "availability")
+	}
+	if ko.Type != "" && kp.Type != "" && kp.Type != ko.Type {
+		changed = append(changed, "type")
+	}
+	return changed
+}
+
+// ckuChanged Checks if the CKU of a dedicated KafkaCluster differs from the CKU of the observed cluster
+func ckuChanged(kp v1alpha1.KafkaClusterParameters, ko v1alpha1.KafkaClusterObservation) bool {
+	return kp.Type == typeDedicated && kp.CKU != nil && *kp.CKU != ko.CKU
+}
+
+// upToDate Checks if an observed kafka cluster matches the KafkaCluster parameters
+func upToDate(kp v1alpha1.KafkaClusterParameters, ko v1alpha1.KafkaClusterObservation) bool {
+	return len(immutableChanges(kp, ko)) == 0 && !ckuChanged(kp, ko)
+}
+
+// connectionDetails Returns the bootstrap endpoint of an observed kafka cluster as connection details, none while the cluster has no
+// endpoint yet
+func connectionDetails(ko v1alpha1.KafkaClusterObservation) managed.ConnectionDetails {
+	if ko.Endpoint == "" {
+		return managed.ConnectionDetails{}
+	}
+	return managed.ConnectionDetails{ConnectionSecretBootstrapEndpoint: []byte(ko.Endpoint)}
+}
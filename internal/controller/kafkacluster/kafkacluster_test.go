@@ -0,0 +1,234 @@
+package kafkacluster
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/kafkacluster/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster"
+)
+
+type fakeClusters struct {
+	cluster   kafkacluster.Cluster
+	resized   []int
+	creates   int
+	createErr error
+}
+
+func (f *fakeClusters) ClusterCreate(_ context.Context, name string, environment string, cloud string, region string, availability string, clusterType string, cku int) (kafkacluster.Cluster, error) {
+	f.creates++
+	if f.createErr != nil {
+		return kafkacluster.Cluster{}, f.createErr
+	}
+	f.cluster = kafkacluster.Cluster{ID: "lkc-abc123", Name: name, Type: clusterType, ClusterSize: cku, Availability: availability, Provider: cloud, Region: region, Status: "PROVISIONING"}
+	return f.cluster, nil
+}
+
+func (f *fakeClusters) ClusterDescribe(_ context.Context, id string, environment string) (kafkacluster.Cluster, error) {
+	if f.cluster.ID != id {
+		return kafkacluster.Cluster{}, kafkacluster.ErrNotFound
+	}
+	return f.cluster, nil
+}
+
+func (f *fakeClusters) ClusterByName(_ context.Context, name string, environment string) (kafkacluster.Cluster, error) {
+	if f.cluster.ID == "" || f.cluster.Name != name {
+		return kafkacluster.Cluster{}, kafkacluster.ErrNotFound
+	}
+	return f.cluster, nil
+}
+
+func (f *fakeClusters) ClusterUpdate(_ context.Context, id string, environment string, cku int) error {
+	f.resized = append(f.resized, cku)
+	return nil
+}
+
+func (f *fakeClusters) ClusterDelete(_ context.Context, id string, environment string) error {
+	return nil
+}
+
+func newDedicatedCluster(cku int) *v1alpha1.KafkaCluster {
+	cr := &v1alpha1.KafkaCluster{}
+	cr.SetName("orders")
+	cr.Spec.ForProvider = v1alpha1.KafkaClusterParameters{
+		Environment:   "env-abc123",
+		CloudProvider: "aws",
+		Region:        "eu-west-1",
+		Availability:  "multi-zone",
+		Type:          "dedicated",
+		CKU:           &cku,
+	}
+	return cr
+}
+
+func TestValidate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(validate(newDedicatedCluster(2).Spec.ForProvider))
+	assert.NoError(validate(v1alpha1.KafkaClusterParameters{Type: "basic"}))
+
+	assert.EqualError(validate(v1alpha1.KafkaClusterParameters{Type: "dedicated"}), errDedicatedWithoutCKU)
+	cku := 1
+	assert.Error(validate(v1alpha1.KafkaClusterParameters{Type: "standard", CKU: &cku}))
+}
+
+func TestObservation(t *testing.T) {
+	assert := assert.New(t)
+
+	ko := observation(kafkacluster.Cluster{
+		ID:           "lkc-abc123",
+		Type:         "DEDICATED",
+		ClusterSize:  2,
+		Availability: "MULTI_ZONE",
+		Provider:     "AWS",
+		Region:       "eu-west-1",
+		Status:       "UP",
+		Endpoint:     "SASL_SSL://pkc-abc123.eu-west-1.aws.confluent.cloud:9092",
+	}, "env-abc123")
+
+	assert.Equal("dedicated", ko.Type)
+	assert.Equal("multi-zone", ko.Availability)
+	assert.Equal("aws", ko.CloudProvider)
+	assert.Equal("pkc-abc123.eu-west-1.aws.confluent.cloud:9092", ko.Endpoint)
+	assert.True(upToDate(newDedicatedCluster(2).Spec.ForProvider, ko))
+}
+
+func TestLifecycle(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeClusters{}
+	e := &external{service: fake, kube: test.NewMockClient()}
+	cr := newDedicatedCluster(1)
+
+	// Without an external name the cluster is created
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+
+	_, err = e.Create(ctx, cr)
+	assert.NoError(err)
+	assert.Equal("lkc-abc123", meta.GetExternalName(cr))
+	assert.Equal("lkc-abc123", cr.Status.AtProvider.ID)
+
+	// A provisioning cluster is left alone & has no endpoint yet
+	*cr.Spec.ForProvider.CKU = 2
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Empty(obs.ConnectionDetails)
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Creating()))
+
+	// Once up, its endpoint is published & a CKU change is applied by an update
+	fake.cluster.Status = "UP"
+	fake.cluster.Endpoint = "SASL_SSL://pkc-abc123.eu-west-1.aws.confluent.cloud:9092"
+	obs, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+	assert.Equal("pkc-abc123.eu-west-1.aws.confluent.cloud:9092", string(obs.ConnectionDetails[ConnectionSecretBootstrapEndpoint]))
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	assert.Equal([]int{2}, fake.resized)
+
+	// Parameters that can't be changed are refused rather than recreating the cluster
+	cr.Spec.ForProvider.Region = "eu-central-1"
+	_, err = e.Update(ctx, cr)
+	assert.EqualError(err, "cannot change region of kafka cluster lkc-abc123, it can only be set when the cluster is created")
+	assert.Len(fake.resized, 1)
+}
+
+func TestCreateRetryAfterCrash(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// The status persisted in the cluster, the in-memory status of a crashed reconcile is lost
+	var persisted *v1alpha1.KafkaCluster
+	kube := test.NewMockClient()
+	kube.MockStatusUpdate = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		persisted = obj.(*v1alpha1.KafkaCluster).DeepCopy()
+		return nil
+	}
+	kube.MockUpdate = test.NewMockUpdateFn(errors.New("provider crashed"))
+
+	fake := &fakeClusters{}
+	e := &external{service: fake, kube: kube}
+
+	_, err := e.Create(ctx, newDedicatedCluster(1))
+	assert.Error(err)
+	assert.Equal(1, fake.creates)
+	assert.Equal("lkc-abc123", persisted.Status.AtProvider.ID, "the ID should be recorded as soon as the cluster is created")
+	assert.Equal("orders", persisted.Status.AtProvider.PendingName)
+
+	// The retry adopts the cluster of the recorded ID instead of creating another one
+	kube.MockUpdate = test.NewMockUpdateFn(nil)
+	retried := persisted.DeepCopy()
+	obs, err := e.Observe(ctx, retried)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+	_, err = e.Create(ctx, retried)
+	assert.NoError(err)
+	assert.Equal(1, fake.creates)
+	assert.Equal("lkc-abc123", meta.GetExternalName(retried))
+	assert.Equal("lkc-abc123", persisted.Status.AtProvider.ID)
+	assert.Empty(persisted.Status.AtProvider.PendingName)
+
+	// A recorded cluster that is gone is not replaced by another one
+	fake.cluster = kafkacluster.Cluster{}
+	retried = newDedicatedCluster(1)
+	retried.Status.AtProvider.PendingName = "orders"
+	retried.Status.AtProvider.ID = "lkc-abc123"
+	_, err = e.Create(ctx, retried)
+	assert.EqualError(err, "kafka cluster lkc-abc123 created by a previous attempt no longer exists")
+	assert.Equal(1, fake.creates)
+
+	// A cluster that was never created is created by the retry
+	retried = newDedicatedCluster(1)
+	retried.Status.AtProvider.PendingName = "orders"
+	_, err = e.Create(ctx, retried)
+	assert.NoError(err)
+	assert.Equal(2, fake.creates)
+}
+
+func TestCreateRetryWithoutID(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// Another team's cluster of the same name, the retry can't tell whether it was created by the cut-off attempt
+	fake := &fakeClusters{cluster: kafkacluster.Cluster{ID: "lkc-other", Name: "orders"}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	cr := newDedicatedCluster(1)
+	cr.Status.AtProvider.PendingName = "orders"
+	_, err := e.Create(ctx, cr)
+	assert.Error(err)
+	assert.Contains(err.Error(), "lkc-other")
+	assert.Equal(0, fake.creates)
+	assert.Empty(meta.GetExternalName(cr))
+
+	// A rejected create created no cluster, so the retry doesn't look for one
+	fake = &fakeClusters{createErr: &clients.Error{Status: "400", Message: "invalid region"}}
+	e = &external{service: fake, kube: test.NewMockClient()}
+	cr = newDedicatedCluster(1)
+	_, err = e.Create(ctx, cr)
+	assert.Error(err)
+	assert.Empty(cr.Status.AtProvider.PendingName)
+
+	// A create that timed out or was killed may have created the cluster
+	for _, createErr := range []error{&clients.TransientError{Err: &clients.Error{Status: "504", Message: "gateway timeout"}}, &clients.Error{Message: "confluent command failed"}} {
+		fake.createErr = createErr
+		cr.Status.AtProvider.PendingName = ""
+		_, err = e.Create(ctx, cr)
+		assert.Error(err)
+		assert.Equal("orders", cr.Status.AtProvider.PendingName)
+	}
+}
@@ -0,0 +1,180 @@
+package managementpolicy
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AnnotationKeyManagementPolicy limits what the provider may do to the Confluent resource of a managed resource. The crossplane-runtime in use
+// predates spec.managementPolicy, so it is set as an annotation with the same values
+const AnnotationKeyManagementPolicy = "confluent.crossplane.io/management-policy"
+
+// Policy is what the provider may do to the Confluent resource of a managed resource
+type Policy string
+
+// Management policies
+const (
+	// FullControl creates, updates & deletes the Confluent resource. It is the default
+	FullControl Policy = "FullControl"
+	// ObserveOnly only observes the Confluent resource, which must exist. Drift is reported by the UpToDate condition instead of being undone
+	ObserveOnly Policy = "ObserveOnly"
+	// OrphanOnDelete creates & updates the Confluent resource, but leaves it in place when the managed resource is deleted
+	OrphanOnDelete Policy = "OrphanOnDelete"
+)
+
+// Condition type & reasons of the drift of observe-only resources
+const (
+	TypeUpToDate xpv1.ConditionType = "UpToDate"
+
+	ReasonUpToDate          xpv1.ConditionReason = "UpToDate"
+	ReasonChangesNotApplied xpv1.ConditionReason = "ChangesNotApplied"
+	ReasonNotObserveOnly    xpv1.ConditionReason = "NotObserveOnly"
+)
+
+const (
+	errUnknownPolicy  = "unknown management policy %q, must be one of FullControl, ObserveOnly or OrphanOnDelete"
+	errCreateRefused  = "the Confluent resource does not exist and management policy ObserveOnly does not create it"
+	msgChangesPending = "the Confluent resource differs from the spec, management policy ObserveOnly does not apply the changes"
+)
+
+// Of Returns the management policy of mg. A missing annotation is FullControl
+func Of(mg metav1.Object) (Policy, error) {
+	p := Policy(mg.GetAnnotations()[AnnotationKeyManagementPolicy])
+	switch p {
+	case "":
+		return FullControl, nil
+	case FullControl, ObserveOnly, OrphanOnDelete:
+		return p, nil
+	default:
+		return "", errors.Errorf(errUnknownPolicy, p)
+	}
+}
+
+// ChangesNotApplied indicates that an observe-only resource differs from its spec
+func ChangesNotApplied() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpToDate,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonChangesNotApplied,
+		Message:            msgChangesPending,
+	}
+}
+
+// UpToDate indicates that an observe-only resource matches its spec
+func UpToDate() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpToDate,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonUpToDate,
+	}
+}
+
+// NotObserveOnly indicates that the drift of a resource is undone by the provider, so it is no longer reported
+func NotObserveOnly() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeUpToDate,
+		Status:             corev1.ConditionUnknown,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonNotObserveOnly,
+	}
+}
+
+// Connecter wraps c so the external clients of managed resources only do what their management policy allows. Calls that are not allowed
+// are skipped without reaching Confluent
+func Connecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		if err != nil {
+			return nil, err
+		}
+		return &external{ExternalClient: ec}, nil
+	})
+}
+
+// external skips the calls of an ExternalClient the management policy of a managed resource does not allow
+type external struct {
+	managed.ExternalClient
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	p, err := Of(mg)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil {
+		return o, err
+	}
+
+	// Delete leaves the Confluent resource in place, so it is reported gone to let the reconciler remove the finalizer
+	if meta.WasDeleted(mg) && p != FullControl {
+		o.ResourceExists = false
+		return o, nil
+	}
+
+	if p != ObserveOnly {
+		if mg.GetCondition(TypeUpToDate).Status != corev1.ConditionUnknown {
+			mg.SetConditions(NotObserveOnly())
+		}
+		return o, nil
+	}
+
+	// A missing resource is refused by Create, while a deleted managed resource is released
+	if !o.ResourceExists {
+		return o, nil
+	}
+	if o.ResourceUpToDate {
+		mg.SetConditions(UpToDate())
+		return o, nil
+	}
+
+	// Reporting the drifted resource as up to date skips the update, the drift is reported by the condition instead
+	mg.SetConditions(ChangesNotApplied(), xpv1.Available())
+	o.ResourceUpToDate = true
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	p, err := Of(mg)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if p == ObserveOnly {
+		return managed.ExternalCreation{}, errors.New(errCreateRefused)
+	}
+	return e.ExternalClient.Create(ctx, mg)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	p, err := Of(mg)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if p == ObserveOnly {
+		return managed.ExternalUpdate{}, nil
+	}
+	return e.ExternalClient.Update(ctx, mg)
+}
+
+// Delete Leaves the Confluent resource in place unless the policy is FullControl. An unknown policy fails the deletion, so a mistyped policy
+// never deletes a resource meant to be kept
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	p, err := Of(mg)
+	if err != nil {
+		return err
+	}
+	if p != FullControl {
+		return nil
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
@@ -0,0 +1,228 @@
+package managementpolicy
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+// fakeExternal stands in for the external client of a single Confluent resource
+type fakeExternal struct {
+	exists   bool
+	upToDate bool
+	calls    []string
+}
+
+func (f *fakeExternal) Observe(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	f.calls = append(f.calls, "Observe")
+	return managed.ExternalObservation{ResourceExists: f.exists, ResourceUpToDate: f.upToDate}, nil
+}
+
+func (f *fakeExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	f.calls = append(f.calls, "Create")
+	f.exists, f.upToDate = true, true
+	return managed.ExternalCreation{}, nil
+}
+
+func (f *fakeExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	f.calls = append(f.calls, "Update")
+	f.upToDate = true
+	return managed.ExternalUpdate{}, nil
+}
+
+func (f *fakeExternal) Delete(_ context.Context, _ resource.Managed) error {
+	f.calls = append(f.calls, "Delete")
+	f.exists = false
+	return nil
+}
+
+func connect(t *testing.T, f *fakeExternal, policy string) (managed.ExternalClient, *v1alpha1.ServiceAccount) {
+	cr := &v1alpha1.ServiceAccount{}
+	cr.Name = "account"
+	if policy != "" {
+		cr.SetAnnotations(map[string]string{AnnotationKeyManagementPolicy: policy})
+	}
+
+	c := Connecter(managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+		return f, nil
+	}))
+	ec, err := c.Connect(context.Background(), cr)
+	assert.NoError(t, err)
+
+	return ec, cr
+}
+
+func TestOf(t *testing.T) {
+	assert := assert.New(t)
+
+	cr := &v1alpha1.ServiceAccount{}
+	p, err := Of(cr)
+	assert.NoError(err)
+	assert.Equal(FullControl, p)
+
+	for _, want := range []Policy{FullControl, ObserveOnly, OrphanOnDelete} {
+		cr.SetAnnotations(map[string]string{AnnotationKeyManagementPolicy: string(want)})
+		p, err = Of(cr)
+		assert.NoError(err)
+		assert.Equal(want, p)
+	}
+
+	cr.SetAnnotations(map[string]string{AnnotationKeyManagementPolicy: "ObserveOnlyy"})
+	_, err = Of(cr)
+	assert.EqualError(err, `unknown management policy "ObserveOnlyy", must be one of FullControl, ObserveOnly or OrphanOnDelete`)
+}
+
+func TestFullControl(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	f := &fakeExternal{}
+	ec, cr := connect(t, f, "")
+
+	_, err := ec.Create(ctx, cr)
+	assert.NoError(err)
+	f.upToDate = false
+	_, err = ec.Update(ctx, cr)
+	assert.NoError(err)
+	assert.NoError(ec.Delete(ctx, cr))
+
+	assert.Equal([]string{"Create", "Update", "Delete"}, f.calls)
+	assert.False(f.exists)
+}
+
+func TestObserveOnly(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// A missing resource is not created
+	f := &fakeExternal{}
+	ec, cr := connect(t, f, string(ObserveOnly))
+	o, err := ec.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.False(o.ResourceExists)
+	_, err = ec.Create(ctx, cr)
+	assert.EqualError(err, errCreateRefused)
+
+	// Drift is reported instead of being undone
+	f = &fakeExternal{exists: true}
+	ec, cr = connect(t, f, string(ObserveOnly))
+	o, err = ec.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(o.ResourceUpToDate)
+	assert.Equal(ReasonChangesNotApplied, cr.GetCondition(TypeUpToDate).Reason)
+	assert.Equal(corev1.ConditionFalse, cr.GetCondition(TypeUpToDate).Status)
+	assert.Equal(corev1.ConditionTrue, cr.GetCondition(xpv1.TypeReady).Status)
+	_, err = ec.Update(ctx, cr)
+	assert.NoError(err)
+
+	f.upToDate = true
+	_, err = ec.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(corev1.ConditionTrue, cr.GetCondition(TypeUpToDate).Status)
+
+	// The Confluent resource outlives the managed resource
+	assert.NoError(ec.Delete(ctx, cr))
+	assert.True(f.exists)
+
+	assert.Equal([]string{"Observe", "Observe"}, f.calls)
+
+	// The condition is reset once the provider undoes drift again
+	cr.SetAnnotations(nil)
+	_, err = ec.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(ReasonNotObserveOnly, cr.GetCondition(TypeUpToDate).Reason)
+	assert.Equal(corev1.ConditionUnknown, cr.GetCondition(TypeUpToDate).Status)
+}
+
+func TestOrphanOnDelete(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	f := &fakeExternal{}
+	ec, cr := connect(t, f, string(OrphanOnDelete))
+
+	_, err := ec.Create(ctx, cr)
+	assert.NoError(err)
+	f.upToDate = false
+	_, err = ec.Update(ctx, cr)
+	assert.NoError(err)
+
+	// Deleting the managed resource leaves the Confluent resource intact
+	assert.NoError(ec.Delete(ctx, cr))
+	assert.True(f.exists)
+	assert.Equal([]string{"Create", "Update"}, f.calls)
+}
+
+func TestUnknownPolicy(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	f := &fakeExternal{exists: true, upToDate: true}
+	ec, cr := connect(t, f, "Orphan")
+
+	_, err := ec.Observe(ctx, cr)
+	assert.Error(err)
+	_, err = ec.Create(ctx, cr)
+	assert.Error(err)
+	_, err = ec.Update(ctx, cr)
+	assert.Error(err)
+	assert.Error(ec.Delete(ctx, cr))
+
+	assert.Empty(f.calls)
+	assert.True(f.exists)
+}
+
+func TestDeletedManagedResource(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		policy Policy
+		exists bool
+		calls  []string
+	}{
+		"FullControl": {
+			policy: FullControl,
+			exists: true,
+			calls:  []string{"Observe", "Delete"},
+		},
+		"ObserveOnly": {
+			policy: ObserveOnly,
+			calls:  []string{"Observe"},
+		},
+		"OrphanOnDelete": {
+			policy: OrphanOnDelete,
+			calls:  []string{"Observe"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			f := &fakeExternal{exists: true, upToDate: true}
+			ec, cr := connect(t, f, string(tc.policy))
+			now := metav1.Now()
+			cr.SetDeletionTimestamp(&now)
+
+			// Like the reconciler, Delete is only called while the resource is reported to exist. A resource that is left in place is reported
+			// gone, so the finalizer is removed instead of deleting it over & over
+			o, err := ec.Observe(ctx, cr)
+			assert.NoError(err)
+			assert.Equal(tc.exists, o.ResourceExists)
+			if o.ResourceExists {
+				assert.NoError(ec.Delete(ctx, cr))
+			}
+
+			assert.Equal(tc.calls, f.calls)
+			assert.Equal(tc.policy != FullControl, f.exists)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package providerconfig
+
+import (
+	"context"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+)
+
+// Errors
+const (
+	errNotFound      = "referenced ProviderConfig %q not found"
+	errBeingDeleted  = "referenced ProviderConfig %q is being deleted, it is kept until no managed resource uses it"
+	errNoProviderRef = "no ProviderConfig referenced"
+	errAmbiguous     = "environment %s is served by more than one ProviderConfig: %s"
+	errListPC        = "cannot list ProviderConfigs"
+	errUpdateManaged = "cannot update managed resource"
+	errSecretMissing = "credentials secret %s not found"
+)
+
+// DefaultName is the ProviderConfig the CRDs point managed resources at when they don't reference one
+const DefaultName = "default"
+
+// Get Gets the ProviderConfig referenced by mg into pc. A missing ProviderConfig is reported by name rather than as a plain get error. A ProviderConfig being deleted is only handed out to managed resources that are themselves being deleted: its in-use finalizer keeps it until all of its users are gone, so those users have to be able to connect to clean up
+func Get(ctx context.Context, kube client.Reader, mg resource.Managed, pc *apisv1alpha1.ProviderConfig) error {
+	ref := mg.GetProviderConfigReference()
+	if ref == nil {
+		return errors.New(errNoProviderRef)
+	}
+
+	if err := kube.Get(ctx, types.NamespacedName{Name: ref.Name}, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return errors.Errorf(errNotFound, ref.Name)
+		}
+		return err
+	}
+
+	if meta.WasDeleted(pc) && !meta.WasDeleted(mg) {
+		return errors.Errorf(errBeingDeleted, ref.Name)
+	}
+
+	return nil
+}
+
+// Credentials Extracts the credentials of pc for mg. A credentials secret that doesn't exist is often only briefly absent, e.g. while a secret
+// operator re-creates it, so mg is blocked until it exists & a TemporaryError is returned to retry soon. Other failures are returned as is
+func Credentials(ctx context.Context, kube client.Client, mg resource.Managed, pc *apisv1alpha1.ProviderConfig) ([]byte, error) {
+	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, kube, pc.Spec.Credentials.CommonCredentialSelectors)
+	if kerrors.IsNotFound(err) && pc.Spec.Credentials.SecretRef != nil {
+		secret := pc.Spec.Credentials.SecretRef.Namespace + "/" + pc.Spec.Credentials.SecretRef.Name
+		mg.SetConditions(dependency.CredentialsMissing(secret))
+		return nil, &clients.TemporaryError{Err: errors.Wrapf(err, errSecretMissing, secret)}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mg.GetCondition(dependency.TypeBlocked).Reason == dependency.ReasonCredentialsMissing {
+		mg.SetConditions(dependency.Unblocked())
+	}
+	return data, nil
+}
+
+// ForEnvironment Returns the name of the ProviderConfig serving environment, or an empty string when none does. An environment served by more than one ProviderConfig is an error
+func ForEnvironment(ctx context.Context, kube client.Reader, environment string) (string, error) {
+	l := &apisv1alpha1.ProviderConfigList{}
+	if err := kube.List(ctx, l); err != nil {
+		return "", errors.Wrap(err, errListPC)
+	}
+
+	var names []string
+	for _, pc := range l.Items {
+		for _, e := range pc.Spec.Environments {
+			if e == environment {
+				names = append(names, pc.GetName())
+				break
+			}
+		}
+	}
+
+	switch len(names) {
+	case 0:
+		return "", nil
+	case 1:
+		return names[0], nil
+	default:
+		return "", errors.Errorf(errAmbiguous, environment, strings.Join(names, ", "))
+	}
+}
+
+// EnvironmentDefaulter points managed resources that use the default ProviderConfig at the ProviderConfig serving their environment
+type EnvironmentDefaulter struct {
+	kube        client.Client
+	environment func(mg resource.Managed) string
+}
+
+// NewEnvironmentDefaulter Returns an EnvironmentDefaulter for managed resources whose environment is returned by environment
+func NewEnvironmentDefaulter(kube client.Client, environment func(mg resource.Managed) string) *EnvironmentDefaulter {
+	return &EnvironmentDefaulter{kube: kube, environment: environment}
+}
+
+// Initialize Sets the ProviderConfig reference of mg when it is unset or refers to the default ProviderConfig, which the CRDs fill in when it is omitted. Resources are defaulted once, later changes of the environments a ProviderConfig serves don't move them
+func (d *EnvironmentDefaulter) Initialize(ctx context.Context, mg resource.Managed) error {
+	ref := mg.GetProviderConfigReference()
+	if ref != nil && ref.Name != DefaultName {
+		return nil
+	}
+
+	env := d.environment(mg)
+	if env == "" {
+		return nil
+	}
+
+	name, err := ForEnvironment(ctx, d.kube, env)
+	if err != nil || name == "" || (ref != nil && ref.Name == name) {
+		return err
+	}
+
+	mg.SetProviderConfigReference(&xpv1.Reference{Name: name})
+	return errors.Wrap(d.kube.Update(ctx, mg), errUpdateManaged)
+}
@@ -0,0 +1,163 @@
+package providerconfig
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+	topicv1alpha1 "github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+)
+
+func TestGet(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	var deleting bool
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if key.Name != "default" {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "providerconfigs"}, key.Name)
+		}
+		if deleting {
+			now := metav1.Now()
+			obj.SetDeletionTimestamp(&now)
+		}
+		return nil
+	}
+
+	mg := &v1alpha1.ServiceAccount{}
+	mg.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+	assert.NoError(Get(ctx, kube, mg, &apisv1alpha1.ProviderConfig{}))
+
+	// Missing ProviderConfig is reported by name
+	mg.SetProviderConfigReference(&xpv1.Reference{Name: "missing"})
+	assert.EqualError(Get(ctx, kube, mg, &apisv1alpha1.ProviderConfig{}), `referenced ProviderConfig "missing" not found`)
+
+	// ProviderConfig being deleted is refused to live resources, but not to resources being deleted
+	deleting = true
+	mg.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+	assert.EqualError(Get(ctx, kube, mg, &apisv1alpha1.ProviderConfig{}), `referenced ProviderConfig "default" is being deleted, it is kept until no managed resource uses it`)
+
+	now := metav1.Now()
+	mg.SetDeletionTimestamp(&now)
+	assert.NoError(Get(ctx, kube, mg, &apisv1alpha1.ProviderConfig{}))
+}
+
+func TestCredentials(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// The secret is briefly absent, e.g. while it is re-created during a resync
+	exists := false
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		if !exists {
+			return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+		}
+		obj.(*corev1.Secret).Data = map[string][]byte{"credentials": []byte("key:secret")}
+		return nil
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	pc.Spec.Credentials.Source = xpv1.CredentialsSourceSecret
+	pc.Spec.Credentials.SecretRef = &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "crossplane-system", Name: "confluent"}, Key: "credentials"}
+	mg := &v1alpha1.ServiceAccount{}
+
+	_, err := Credentials(ctx, kube, mg, pc)
+	assert.True(clients.IsTemporary(err))
+	assert.Equal(dependency.ReasonCredentialsMissing, mg.GetCondition(dependency.TypeBlocked).Reason)
+	assert.Contains(mg.GetCondition(dependency.TypeBlocked).Message, "crossplane-system/confluent")
+
+	// The resource recovers once the secret appears
+	exists = true
+	data, err := Credentials(ctx, kube, mg, pc)
+	assert.NoError(err)
+	assert.Equal([]byte("key:secret"), data)
+	assert.Equal(corev1.ConditionFalse, mg.GetCondition(dependency.TypeBlocked).Status)
+
+	// Blocked conditions of other dependencies are left alone
+	mg.SetConditions(dependency.Blocked("waiting for dependencies to become ready: cluster lkc-1 (provisioning)"))
+	_, err = Credentials(ctx, kube, mg, pc)
+	assert.NoError(err)
+	assert.Equal(corev1.ConditionTrue, mg.GetCondition(dependency.TypeBlocked).Status)
+
+	// Other failures are not temporary
+	kube.MockGet = test.NewMockGetFn(errors.New("boom"))
+	_, err = Credentials(ctx, kube, mg, pc)
+	assert.Error(err)
+	assert.False(clients.IsTemporary(err))
+}
+
+func newProviderConfig(name string, environments ...string) apisv1alpha1.ProviderConfig {
+	pc := apisv1alpha1.ProviderConfig{}
+	pc.SetName(name)
+	pc.Spec.Environments = environments
+	return pc
+}
+
+func TestEnvironmentDefaulter(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	pcs := []apisv1alpha1.ProviderConfig{
+		newProviderConfig("default"),
+		newProviderConfig("team-a", "env-aaaaa"),
+		newProviderConfig("team-b", "env-bbbbb", "env-ccccc"),
+	}
+	var updates int
+	kube := test.NewMockClient()
+	kube.MockList = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+		list.(*apisv1alpha1.ProviderConfigList).Items = pcs
+		return nil
+	}
+	kube.MockUpdate = func(context.Context, client.Object, ...client.UpdateOption) error {
+		updates++
+		return nil
+	}
+	d := NewEnvironmentDefaulter(kube, func(mg resource.Managed) string { return mg.(*topicv1alpha1.Topic).Spec.ForProvider.Environment })
+
+	newTopic := func(env string, ref *xpv1.Reference) *topicv1alpha1.Topic {
+		cr := &topicv1alpha1.Topic{}
+		cr.Spec.ForProvider.Environment = env
+		cr.SetProviderConfigReference(ref)
+		return cr
+	}
+
+	// Unset & default references are pointed at the ProviderConfig of the environment
+	cr := newTopic("env-ccccc", nil)
+	assert.NoError(d.Initialize(ctx, cr))
+	assert.Equal("team-b", cr.GetProviderConfigReference().Name)
+	cr = newTopic("env-aaaaa", &xpv1.Reference{Name: DefaultName})
+	assert.NoError(d.Initialize(ctx, cr))
+	assert.Equal("team-a", cr.GetProviderConfigReference().Name)
+	assert.Equal(2, updates)
+
+	// Explicit references & environments without ProviderConfig are left alone
+	cr = newTopic("env-aaaaa", &xpv1.Reference{Name: "team-b"})
+	assert.NoError(d.Initialize(ctx, cr))
+	assert.Equal("team-b", cr.GetProviderConfigReference().Name)
+	cr = newTopic("env-ddddd", &xpv1.Reference{Name: DefaultName})
+	assert.NoError(d.Initialize(ctx, cr))
+	assert.Equal(DefaultName, cr.GetProviderConfigReference().Name)
+	assert.Equal(2, updates)
+
+	// An environment may only be served by one ProviderConfig
+	pcs = append(pcs, newProviderConfig("team-c", "env-aaaaa"))
+	cr = newTopic("env-aaaaa", nil)
+	assert.EqualError(d.Initialize(ctx, cr), "environment env-aaaaa is served by more than one ProviderConfig: team-a, team-c")
+	assert.Nil(cr.GetProviderConfigReference())
+}
@@ -0,0 +1,114 @@
+package provisioning
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// AnnotationKeyProvisioningSince records when a managed resource started provisioning in Confluent Cloud
+const AnnotationKeyProvisioningSince = "confluent.crossplane.io/provisioning-since"
+
+var (
+	// MinInterval is the poll interval of a resource that just started provisioning
+	MinInterval = 5 * time.Second
+
+	// MaxInterval caps the poll interval of a provisioning resource. It matches the poll interval of the managed reconciler
+	MaxInterval = time.Minute
+)
+
+var now = time.Now
+
+// Set Records on o whether it is provisioning, keeping the recorded start while provisioning continues & returns true if o was changed
+func Set(o metav1.Object, provisioning bool) bool {
+	_, recorded := o.GetAnnotations()[AnnotationKeyProvisioningSince]
+
+	if !provisioning {
+		if !recorded {
+			return false
+		}
+		meta.RemoveAnnotations(o, AnnotationKeyProvisioningSince)
+		return true
+	}
+
+	if recorded {
+		return false
+	}
+	meta.AddAnnotations(o, map[string]string{AnnotationKeyProvisioningSince: now().UTC().Format(time.RFC3339)})
+	return true
+}
+
+// Record Sets the provisioning annotation on mg & persists it when it changed. A copy of mg is written, so the status set in memory is kept
+// for the managed reconciler to persist
+func Record(ctx context.Context, kube client.Client, mg resource.Managed, provisioning bool) error {
+	if !Set(mg, provisioning) {
+		return nil
+	}
+
+	cp := mg.DeepCopyObject().(resource.Managed)
+	if err := kube.Update(ctx, cp); err != nil {
+		return err
+	}
+	mg.SetResourceVersion(cp.GetResourceVersion())
+	return nil
+}
+
+// RequeueAfter Returns how long to wait before polling o again & false if o is not provisioning. The interval equals the time provisioning took
+// so far, so every poll doubles the elapsed time, bounded by MinInterval & MaxInterval
+func RequeueAfter(o metav1.Object) (time.Duration, bool) {
+	v, ok := o.GetAnnotations()[AnnotationKeyProvisioningSince]
+	if !ok {
+		return 0, false
+	}
+
+	since, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return MinInterval, true
+	}
+
+	d := now().Sub(since)
+	if d < MinInterval {
+		return MinInterval, true
+	}
+	if d > MaxInterval {
+		return MaxInterval, true
+	}
+	return d, true
+}
+
+// Reconciler polls provisioning resources sooner than the poll interval of the inner reconciler
+type Reconciler struct {
+	inner     reconcile.Reconciler
+	kube      client.Client
+	newObject func() client.Object
+}
+
+// NewReconciler is a factory method for Reconciler. newObject returns an empty object of the kind reconciled by inner
+func NewReconciler(kube client.Client, newObject func() client.Object, inner reconcile.Reconciler) reconcile.Reconciler {
+	return &Reconciler{inner: inner, kube: kube, newObject: newObject}
+}
+
+// Reconcile passes req to the inner reconciler & shortens the scheduled poll when the resource is provisioning. Errors & immediate requeues are
+// left to the inner reconciler
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	res, err := r.inner.Reconcile(ctx, req)
+	if err != nil || res.Requeue || res.RequeueAfter == 0 {
+		return res, err
+	}
+
+	o := r.newObject()
+	if err := r.kube.Get(ctx, req.NamespacedName, o); err != nil {
+		return res, nil
+	}
+
+	if d, ok := RequeueAfter(o); ok && d < res.RequeueAfter {
+		res.RequeueAfter = d
+	}
+
+	return res, nil
+}
@@ -0,0 +1,111 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+)
+
+func TestSet(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+	defer func() { now = time.Now }()
+
+	o := &metav1.ObjectMeta{}
+	assert.False(Set(o, false))
+
+	assert.True(Set(o, true))
+	assert.Equal("2021-09-01T12:00:00Z", o.GetAnnotations()[AnnotationKeyProvisioningSince])
+
+	// The start is kept while provisioning continues
+	now = func() time.Time { return start.Add(time.Minute) }
+	assert.False(Set(o, true))
+	assert.Equal("2021-09-01T12:00:00Z", o.GetAnnotations()[AnnotationKeyProvisioningSince])
+
+	assert.True(Set(o, false))
+	assert.NotContains(o.GetAnnotations(), AnnotationKeyProvisioningSince)
+}
+
+func TestRequeueAfter(t *testing.T) {
+	assert := assert.New(t)
+
+	start := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	defer func() { now = time.Now }()
+
+	o := &metav1.ObjectMeta{}
+	_, ok := RequeueAfter(o)
+	assert.False(ok)
+
+	o.SetAnnotations(map[string]string{AnnotationKeyProvisioningSince: start.Format(time.RFC3339)})
+	for elapsed, want := range map[time.Duration]time.Duration{
+		time.Second:      MinInterval,
+		20 * time.Second: 20 * time.Second,
+		40 * time.Second: 40 * time.Second,
+		time.Hour:        MaxInterval,
+	} {
+		now = func() time.Time { return start.Add(elapsed) }
+		d, ok := RequeueAfter(o)
+		assert.True(ok)
+		assert.Equal(want, d, "after %s", elapsed)
+	}
+
+	o.SetAnnotations(map[string]string{AnnotationKeyProvisioningSince: "yesterday"})
+	d, ok := RequeueAfter(o)
+	assert.True(ok)
+	assert.Equal(MinInterval, d)
+}
+
+func TestReconcile(t *testing.T) {
+	start := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start.Add(10 * time.Second) }
+	defer func() { now = time.Now }()
+
+	cases := map[string]struct {
+		annotations map[string]string
+		inner       reconcile.Result
+		want        reconcile.Result
+	}{
+		"NotProvisioning": {
+			inner: reconcile.Result{RequeueAfter: time.Minute},
+			want:  reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"Provisioning": {
+			annotations: map[string]string{AnnotationKeyProvisioningSince: start.Format(time.RFC3339)},
+			inner:       reconcile.Result{RequeueAfter: time.Minute},
+			want:        reconcile.Result{RequeueAfter: 10 * time.Second},
+		},
+		"ImmediateRequeue": {
+			annotations: map[string]string{AnnotationKeyProvisioningSince: start.Format(time.RFC3339)},
+			inner:       reconcile.Result{Requeue: true},
+			want:        reconcile.Result{Requeue: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				return tc.inner, nil
+			})
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.SetAnnotations(tc.annotations)
+					return nil
+				},
+			}
+
+			res, err := NewReconciler(kube, func() client.Object { return &v1alpha1.FlinkStatement{} }, inner).Reconcile(context.Background(), reconcile.Request{})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package reconcilenow
+
+import (
+	"context"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// AnnotationKeyReconcileNow forces an immediate reconcile of a managed resource when set or changed. It is removed once a reconcile left the
+// resource Synced, so forcing another reconcile requires setting it again
+const AnnotationKeyReconcileNow = "confluent.crossplane.io/reconcile-now"
+
+const (
+	errGetObject       = "cannot get managed resource after reconcile"
+	errClearAnnotation = "cannot clear reconcile-now annotation"
+)
+
+// Reconciler clears the reconcile-now annotation after the inner reconciler acted on it. The controller already watches all changes of its
+// managed resources, so setting the annotation is enough to trigger the reconcile itself
+type Reconciler struct {
+	inner     reconcile.Reconciler
+	kube      client.Client
+	newObject func() client.Object
+}
+
+// NewReconciler is a factory method for Reconciler. newObject returns an empty object of the kind reconciled by inner
+func NewReconciler(kube client.Client, newObject func() client.Object, inner reconcile.Reconciler) reconcile.Reconciler {
+	return &Reconciler{inner: inner, kube: kube, newObject: newObject}
+}
+
+// Reconcile passes req to the inner reconciler & removes the reconcile-now annotation once it left the resource Synced
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	o := r.newObject()
+	if err := r.kube.Get(ctx, req.NamespacedName, o); err != nil {
+		// The inner reconciler deals with resources that are gone or cannot be read
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	if _, ok := o.GetAnnotations()[AnnotationKeyReconcileNow]; !ok {
+		return r.inner.Reconcile(ctx, req)
+	}
+
+	res, err := r.inner.Reconcile(ctx, req)
+	if err != nil {
+		return res, err
+	}
+
+	// The managed reconciler reports a failed Observe, Create or Update through the Synced condition rather than an error
+	o = r.newObject()
+	if err := r.kube.Get(ctx, req.NamespacedName, o); err != nil {
+		return res, errors.Wrap(client.IgnoreNotFound(err), errGetObject)
+	}
+	if c, ok := o.(resource.Conditioned); !ok || c.GetCondition(xpv1.TypeSynced).Status != corev1.ConditionTrue {
+		return res, nil
+	}
+
+	patch := client.MergeFrom(o.DeepCopyObject().(client.Object))
+	meta.RemoveAnnotations(o, AnnotationKeyReconcileNow)
+	if err := r.kube.Patch(ctx, o, patch); err != nil {
+		return res, errors.Wrap(client.IgnoreNotFound(err), errClearAnnotation)
+	}
+
+	return res, nil
+}
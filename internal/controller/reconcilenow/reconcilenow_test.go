@@ -0,0 +1,102 @@
+package reconcilenow
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+)
+
+func newTopic() client.Object { return &v1alpha1.Topic{} }
+
+func TestReconcile(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		annotations map[string]string
+		innerErr    error
+		synced      xpv1.Condition
+		wantPatched bool
+		wantErr     error
+	}{
+		"WithoutAnnotation": {
+			annotations: map[string]string{"other": "value"},
+		},
+		"WithAnnotation": {
+			annotations: map[string]string{AnnotationKeyReconcileNow: "2026-01-01T00:00:00Z", "other": "value"},
+			synced:      xpv1.ReconcileSuccess(),
+			wantPatched: true,
+		},
+		"ReconcileFailed": {
+			annotations: map[string]string{AnnotationKeyReconcileNow: "now"},
+			synced:      xpv1.ReconcileError(errBoom),
+		},
+		"InnerFailed": {
+			annotations: map[string]string{AnnotationKeyReconcileNow: "now"},
+			innerErr:    errBoom,
+			wantErr:     errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			calls := 0
+			inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				calls++
+				return reconcile.Result{}, tc.innerErr
+			})
+
+			var patched client.Object
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+					obj.SetAnnotations(tc.annotations)
+					if calls > 0 {
+						obj.(resource.Conditioned).SetConditions(tc.synced)
+					}
+					return nil
+				},
+				MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+					patched = obj
+					return nil
+				},
+			}
+
+			_, err := NewReconciler(kube, newTopic, inner).Reconcile(context.Background(), reconcile.Request{})
+			assert.Equal(tc.wantErr, err)
+			assert.Equal(1, calls)
+
+			if !tc.wantPatched {
+				assert.Nil(patched)
+				return
+			}
+			if assert.NotNil(patched) {
+				_, ok := patched.GetAnnotations()[AnnotationKeyReconcileNow]
+				assert.False(ok, "annotation should be removed")
+				assert.Equal("value", patched.GetAnnotations()["other"])
+			}
+		})
+	}
+}
+
+func TestReconcileObjectGone(t *testing.T) {
+	calls := 0
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		calls++
+		return reconcile.Result{}, nil
+	})
+	kube := &test.MockClient{MockGet: test.NewMockGetFn(errors.New("not found"))}
+
+	_, err := NewReconciler(kube, newTopic, inner).Reconcile(context.Background(), reconcile.Request{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,117 @@
+package refresh
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Signal triggers a refresh of all managed resources when received by the provider. Unlike the reconcile-now annotation, it reaches every
+// resource at once without writing to any of them
+var Signal os.Signal = syscall.SIGUSR1
+
+const errListResources = "cannot list managed resources to refresh"
+
+type kind struct {
+	newList func() resource.ManagedList
+	events  chan event.GenericEvent
+}
+
+var (
+	mu    sync.Mutex
+	kinds []kind
+)
+
+// Source returns the source of refresh events of a controller & registers the managed resources listed by newList for refreshes. It is
+// meant to be watched with Handler
+func Source(newList func() resource.ManagedList) source.Source {
+	events := make(chan event.GenericEvent)
+
+	mu.Lock()
+	kinds = append(kinds, kind{newList: newList, events: events})
+	mu.Unlock()
+
+	return &source.Channel{Source: events}
+}
+
+// Handler enqueues the resources of a refresh through the rate limiter of the controller, so a refresh of many resources is spread out the
+// same way as their retries instead of hitting Confluent at once
+type Handler struct{}
+
+// Create is not a refresh & enqueues nothing
+func (h *Handler) Create(event.CreateEvent, workqueue.RateLimitingInterface) {}
+
+// Update is not a refresh & enqueues nothing
+func (h *Handler) Update(event.UpdateEvent, workqueue.RateLimitingInterface) {}
+
+// Delete is not a refresh & enqueues nothing
+func (h *Handler) Delete(event.DeleteEvent, workqueue.RateLimitingInterface) {}
+
+// Generic enqueues the refreshed resource of e
+func (h *Handler) Generic(e event.GenericEvent, q workqueue.RateLimitingInterface) {
+	if e.Object == nil {
+		return
+	}
+	q.AddRateLimited(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: e.Object.GetNamespace(), Name: e.Object.GetName()}})
+}
+
+// All hands every registered managed resource to its controller for an observe & returns how many were refreshed. It blocks until all of
+// them were handed over or ctx is done
+func All(ctx context.Context, kube client.Reader) (int, error) {
+	mu.Lock()
+	registered := append([]kind(nil), kinds...)
+	mu.Unlock()
+
+	refreshed := 0
+	for _, k := range registered {
+		l := k.newList()
+		if err := kube.List(ctx, l); err != nil {
+			return refreshed, errors.Wrap(err, errListResources)
+		}
+
+		for _, mg := range l.GetItems() {
+			select {
+			case k.events <- event.GenericEvent{Object: mg}:
+				refreshed++
+			case <-ctx.Done():
+				return refreshed, ctx.Err()
+			}
+		}
+	}
+
+	return refreshed, nil
+}
+
+// Watch refreshes all managed resources whenever the provider receives Signal, until ctx is done
+func Watch(ctx context.Context, kube client.Reader, log logging.Logger) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, Signal)
+	defer signal.Stop(signals)
+
+	for {
+		select {
+		case <-signals:
+			log.Info("Refreshing all managed resources")
+			n, err := All(ctx, kube)
+			if err != nil {
+				log.Info("Cannot refresh all managed resources", "refreshed", n, "error", err)
+				continue
+			}
+			log.Info("Refreshed all managed resources", "refreshed", n)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
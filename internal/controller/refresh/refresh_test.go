@@ -0,0 +1,100 @@
+package refresh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+)
+
+type fakeQueue struct {
+	workqueue.RateLimitingInterface
+	added []interface{}
+}
+
+func (q *fakeQueue) AddRateLimited(item interface{}) { q.added = append(q.added, item) }
+
+func TestAll(t *testing.T) {
+	assert := assert.New(t)
+	kinds = nil
+
+	src := Source(func() resource.ManagedList { return &v1alpha1.TopicList{} }).(*source.Channel)
+
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			l := list.(*v1alpha1.TopicList)
+			l.Items = []v1alpha1.Topic{{}, {}}
+			l.Items[0].SetName("orders")
+			l.Items[1].SetName("payments")
+			return nil
+		},
+	}
+
+	// The controller consumes the events & enqueues them through its rate limiter
+	q := &fakeQueue{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 2; i++ {
+			(&Handler{}).Generic(<-src.Source, q)
+		}
+	}()
+
+	n, err := All(context.Background(), kube)
+	<-done
+	assert.NoError(err)
+	assert.Equal(2, n)
+	if assert.Len(q.added, 2) {
+		assert.Equal("orders", q.added[0].(reconcile.Request).Name)
+		assert.Equal("payments", q.added[1].(reconcile.Request).Name)
+	}
+}
+
+func TestAllListFailed(t *testing.T) {
+	kinds = nil
+	Source(func() resource.ManagedList { return &v1alpha1.TopicList{} })
+
+	kube := &test.MockClient{MockList: test.NewMockListFn(errors.New("boom"))}
+
+	_, err := All(context.Background(), kube)
+	assert.EqualError(t, err, errListResources+": boom")
+}
+
+func TestAllContextDone(t *testing.T) {
+	kinds = nil
+	Source(func() resource.ManagedList { return &v1alpha1.TopicList{} })
+
+	kube := &test.MockClient{
+		MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*v1alpha1.TopicList).Items = []v1alpha1.Topic{{}}
+			return nil
+		},
+	}
+
+	// No controller consumes the events, e.g. when it is not leading
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	n, err := All(ctx, kube)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, 0, n)
+}
+
+func TestHandlerIgnoresOtherEvents(t *testing.T) {
+	q := &fakeQueue{}
+	h := &Handler{}
+	h.Create(event.CreateEvent{Object: &v1alpha1.Topic{}}, q)
+	h.Update(event.UpdateEvent{ObjectNew: &v1alpha1.Topic{}}, q)
+	h.Delete(event.DeleteEvent{Object: &v1alpha1.Topic{}}, q)
+	h.Generic(event.GenericEvent{}, q)
+	assert.Empty(t, q.added)
+}
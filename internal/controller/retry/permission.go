@@ -0,0 +1,84 @@
+package retry
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+// Condition type & reasons of the permission check
+const (
+	TypeDegraded xpv1.ConditionType = "Degraded"
+
+	ReasonInsufficientPermissions xpv1.ConditionReason = "InsufficientPermissions"
+	ReasonPermissionsGranted      xpv1.ConditionReason = "PermissionsGranted"
+)
+
+// Operations of an external client, as they read in the message of a permission condition
+const (
+	operationObserve = "observe"
+	operationCreate  = "create"
+	operationUpdate  = "update"
+	operationDelete  = "delete"
+)
+
+const msgInsufficientPermissions = "credentials lack permission to %s %s: %s"
+
+// InsufficientPermissions indicates that the credentials of the ProviderConfig of a resource are not permitted an operation on it
+func InsufficientPermissions(operation string, mg resource.Managed, err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonInsufficientPermissions,
+		Message:            fmt.Sprintf(msgInsufficientPermissions, operation, plural(mg), err),
+	}
+}
+
+// PermissionsGranted indicates that an operation denied before is permitted to the credentials now
+func PermissionsGranted() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDegraded,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPermissionsGranted,
+	}
+}
+
+// checkPermission Reports an operation denied to the credentials of mg as Degraded, & clears the report once an operation succeeds. Degraded
+// conditions reported by the controller of mg for other reasons are left alone
+func checkPermission(mg resource.Managed, operation string, err error) {
+	switch {
+	case clients.IsForbidden(err):
+		mg.SetConditions(InsufficientPermissions(operation, mg, err))
+	case err == nil && mg.GetCondition(TypeDegraded).Reason == ReasonInsufficientPermissions:
+		mg.SetConditions(PermissionsGranted())
+	}
+}
+
+// plural Returns the kind of mg in plural words, e.g. service accounts for a ServiceAccount or api keys for an APIKey
+func plural(mg resource.Managed) string {
+	kind := []rune(reflect.TypeOf(mg).Elem().Name())
+
+	var words []string
+	start := 0
+	for i := 1; i < len(kind); i++ {
+		// A word starts at an upper case letter following a lower case one, or ending a run of upper case letters
+		if unicode.IsUpper(kind[i]) && (unicode.IsLower(kind[i-1]) || i+1 < len(kind) && unicode.IsLower(kind[i+1])) {
+			words = append(words, string(kind[start:i]))
+			start = i
+		}
+	}
+	words = append(words, string(kind[start:]))
+
+	return strings.ToLower(strings.Join(words, " ")) + "s"
+}
@@ -0,0 +1,142 @@
+package retry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/controller/debuglog"
+	"github.com/dfds/provider-confluent/internal/controller/errorbudget"
+	"github.com/dfds/provider-confluent/internal/controller/managementpolicy"
+	"github.com/dfds/provider-confluent/internal/controller/shutdown"
+)
+
+var (
+	// TransientInterval is how long to wait before retrying a resource whose last Confluent command failed with a transient error
+	TransientInterval = 15 * time.Second
+
+	// PermanentInterval is how long to wait before retrying a resource whose last Confluent command failed with a permanent error. Changes to
+	// the resource are reconciled right away regardless, so it only limits retrying unchanged input
+	PermanentInterval = 5 * time.Minute
+)
+
+type failure int
+
+const (
+	failureNone failure = iota
+	failureTransient
+	failurePermanent
+)
+
+// Tracker remembers how the last external call of each managed resource failed, so its reconciler can schedule the retry accordingly
+type Tracker struct {
+	mu       sync.Mutex
+	failures map[types.NamespacedName]failure
+}
+
+// NewTracker is a factory method for Tracker
+func NewTracker() *Tracker {
+	return &Tracker{failures: make(map[types.NamespacedName]failure)}
+}
+
+func (t *Tracker) record(mg resource.Managed, err error) {
+	errorbudget.Record(err)
+
+	f := failureNone
+	switch {
+	case clients.IsTransient(err), clients.IsTemporary(err):
+		f = failureTransient
+	case clients.IsPermanent(err):
+		f = failurePermanent
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.failures[types.NamespacedName{Namespace: mg.GetNamespace(), Name: mg.GetName()}] = f
+}
+
+func (t *Tracker) take(nn types.NamespacedName) failure {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f := t.failures[nn]
+	delete(t.failures, nn)
+	return f
+}
+
+// Connecter wraps c so the errors of its external clients are recorded. The requests & responses of managed resources with the debug
+// annotation are logged as well, & only the calls allowed by their management policy reach Confluent
+func (t *Tracker) Connecter(c managed.ExternalConnecter) managed.ExternalConnecter {
+	c = managementpolicy.Connecter(debuglog.Connecter(c))
+	return managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		ec, err := c.Connect(ctx, mg)
+		t.record(mg, err)
+		if err != nil {
+			return nil, err
+		}
+		return &external{ExternalClient: ec, tracker: t}, nil
+	})
+}
+
+// Reconciler wraps inner so a resource is retried after TransientInterval or PermanentInterval when its last external call failed, instead of
+// after the backoff of the rate limiter. Retries are throttled along with all other reconciles when the error budget is used up, & a reconcile
+// in flight when the provider shuts down is given the shutdown grace period to finish
+func (t *Tracker) Reconciler(inner reconcile.Reconciler) reconcile.Reconciler {
+	inner = shutdown.Reconciler(inner)
+	return errorbudget.Reconciler(reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		t.take(req.NamespacedName)
+
+		res, err := inner.Reconcile(ctx, req)
+		if err != nil {
+			return res, err
+		}
+
+		switch t.take(req.NamespacedName) {
+		case failureTransient:
+			return reconcile.Result{RequeueAfter: TransientInterval}, nil
+		case failurePermanent:
+			return reconcile.Result{RequeueAfter: PermanentInterval}, nil
+		default:
+			return res, nil
+		}
+	}))
+}
+
+// external records the errors of an ExternalClient & reports the operations denied to its credentials
+type external struct {
+	managed.ExternalClient
+	tracker *Tracker
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	e.tracker.record(mg, err)
+	checkPermission(mg, operationObserve, err)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.ExternalClient.Create(ctx, mg)
+	e.tracker.record(mg, err)
+	checkPermission(mg, operationCreate, err)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.ExternalClient.Update(ctx, mg)
+	e.tracker.record(mg, err)
+	checkPermission(mg, operationUpdate, err)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	err := e.ExternalClient.Delete(ctx, mg)
+	e.tracker.record(mg, err)
+	checkPermission(mg, operationDelete, err)
+	return err
+}
@@ -0,0 +1,157 @@
+package retry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	aclapi "github.com/dfds/provider-confluent/apis/acl/v1alpha1"
+	apikeyapi "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+type fakeExternal struct {
+	managed.ExternalClient
+	err error
+}
+
+func (f *fakeExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	return managed.ExternalObservation{}, f.err
+}
+
+func (f *fakeExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, f.err
+}
+
+func TestReconcile(t *testing.T) {
+	cases := map[string]struct {
+		err  error
+		want reconcile.Result
+	}{
+		"Succeeded": {
+			want: reconcile.Result{RequeueAfter: time.Minute},
+		},
+		"BadRequest": {
+			err:  errors.Wrap(clients.CommandError([]byte(`{"errors":[{"status":"400","detail":"Invalid partition count."}]}`)), "unknown error"),
+			want: reconcile.Result{RequeueAfter: PermanentInterval},
+		},
+		"ServiceUnavailable": {
+			err:  errors.Wrap(clients.CommandError([]byte(`{"errors":[{"status":"503","detail":"Service Unavailable"}]}`)), "unknown error"),
+			want: reconcile.Result{RequeueAfter: TransientInterval},
+		},
+		"CredentialsMissing": {
+			err:  errors.Wrap(&clients.TemporaryError{Err: errors.New("credentials secret crossplane-system/confluent not found")}, "cannot get credentials"),
+			want: reconcile.Result{RequeueAfter: TransientInterval},
+		},
+		"NotACommandError": {
+			err:  errors.New("cannot update status"),
+			want: reconcile.Result{Requeue: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			tracker := NewTracker()
+			connecter := tracker.Connecter(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+				return &fakeExternal{err: tc.err}, nil
+			}))
+
+			// Stands in for the managed reconciler, which requeues errors & polls successful resources
+			inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+				cr := &v1alpha1.Topic{}
+				cr.SetName(req.Name)
+				ec, err := connecter.Connect(ctx, cr)
+				if err != nil {
+					return reconcile.Result{Requeue: true}, nil
+				}
+				if _, err := ec.Observe(ctx, cr); err != nil {
+					return reconcile.Result{Requeue: true}, nil
+				}
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			})
+
+			req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "orders"}}
+			res, err := tracker.Reconciler(inner).Reconcile(context.Background(), req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestConnectFailure(t *testing.T) {
+	tracker := NewTracker()
+	connecter := tracker.Connecter(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return nil, clients.CommandError([]byte("Error: dial tcp: i/o timeout"))
+	}))
+
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		cr := &v1alpha1.Topic{}
+		cr.SetName(req.Name)
+		_, err := connecter.Connect(ctx, cr)
+		assert.Error(t, err)
+		return reconcile.Result{Requeue: true}, nil
+	})
+
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "orders"}}
+	res, err := tracker.Reconciler(inner).Reconcile(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, reconcile.Result{RequeueAfter: TransientInterval}, res)
+}
+
+func TestInsufficientPermissions(t *testing.T) {
+	assert := assert.New(t)
+
+	tracker := NewTracker()
+	fake := &fakeExternal{err: errors.Wrap(clients.CommandError([]byte(`Error: REST request failed: {"errors":[{"status":"403","detail":"Forbidden Access"}]}`)), "unknown error")}
+	connecter := tracker.Connecter(managed.ExternalConnectorFn(func(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+		return fake, nil
+	}))
+
+	cr := &saapi.ServiceAccount{}
+	cr.SetName("orders")
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		ec, err := connecter.Connect(ctx, cr)
+		assert.NoError(err)
+		if _, err := ec.Create(ctx, cr); err != nil {
+			return reconcile.Result{Requeue: true}, nil
+		}
+		return reconcile.Result{RequeueAfter: time.Minute}, nil
+	})
+
+	// A denied create is not retried as if transient
+	req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "orders"}}
+	res, err := tracker.Reconciler(inner).Reconcile(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal(reconcile.Result{RequeueAfter: PermanentInterval}, res)
+
+	cond := cr.GetCondition(TypeDegraded)
+	assert.Equal(corev1.ConditionTrue, cond.Status)
+	assert.Equal(ReasonInsufficientPermissions, cond.Reason)
+	assert.Equal("credentials lack permission to create service accounts: unknown error: 403: Forbidden Access", cond.Message)
+
+	// The condition is cleared once the credentials are granted the permission
+	fake.err = nil
+	_, err = tracker.Reconciler(inner).Reconcile(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal(corev1.ConditionFalse, cr.GetCondition(TypeDegraded).Status)
+	assert.Equal(ReasonPermissionsGranted, cr.GetCondition(TypeDegraded).Reason)
+}
+
+func TestPlural(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("service accounts", plural(&saapi.ServiceAccount{}))
+	assert.Equal("topics", plural(&v1alpha1.Topic{}))
+	assert.Equal("api keys", plural(&apikeyapi.APIKey{}))
+	assert.Equal("acls", plural(&aclapi.ACL{}))
+}
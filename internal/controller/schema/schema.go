@@ -25,7 +25,6 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,6 +41,13 @@ import (
 
 	"github.com/dfds/provider-confluent/internal/clients"
 	"github.com/dfds/provider-confluent/internal/clients/schemaregistry"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
 )
 
 const (
@@ -49,21 +55,22 @@ const (
 	errTrackPCUsage    = "cannot track ProviderConfig usage"
 	errGetPC           = "cannot get ProviderConfig"
 	errGetCreds        = "cannot get credentials"
+	errGetCABundle     = "cannot get CA bundle"
 	errNewClient       = "cannot create new Service"
 	errAuthCredentials = "invalid client credentials"
 	errUnmarshalState  = "kubernetes state mismatch with type"
 )
 
 var (
-	createAndConvertClientFunc = func(clientCreds []byte, apiCreds clients.APICredentials) (interface{}, error) { //nolint
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
 		credParts := strings.Split(string(clientCreds), ":")
 
 		if len(credParts) != 2 {
 			return nil, errors.New(errAuthCredentials)
 		}
 
-		cClient := clients.NewClient()
-		authErr := cClient.Authenticate(credParts[0], credParts[1])
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
 
 		if authErr != nil {
 			return nil, authErr
@@ -87,20 +94,25 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
 	}
 
+	failures := retry.NewTracker()
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.SchemaGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithInitializers(managed.NewNameAsExternalName(mgr.GetClient()), providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.Schema).Spec.ForProvider.Environment })),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: createAndConvertClientFunc}),
+			newServiceFn: createAndConvertClientFunc})),
 		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o).
 		For(&v1alpha1.Schema{}).
-		Complete(r)
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.SchemaList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.Schema{} }, failures.Reconciler(r))))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -108,7 +120,7 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(creds []byte, apiCreds clients.APICredentials) (interface{}, error)
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -127,11 +139,11 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	clientCredentialData, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -146,7 +158,26 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		}
 	}
 
-	svc, err := c.newServiceFn(clientCredentialData, apiCredentials)
+	// A schema registry key of an APIKey takes the place of the API credentials of the ProviderConfig
+	if ref := cr.Spec.ForProvider.APIKeyRef; ref != nil {
+		apiCredentials, err = schemaRegistryCredentials(ctx, c.kube, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
@@ -171,7 +202,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	// Confluent
 	var client = c.service.(schemaregistry.IClient)
-	ccschema, err := client.SchemaDescribe(cr.Spec.ForProvider.Subject, "latest", cr.Spec.ForProvider.Environment)
+	ccschema, err := client.SchemaDescribe(ctx, cr.Spec.ForProvider.Subject, "latest", cr.Spec.ForProvider.Environment)
 
 	if err != nil {
 		if err.Error() == schemaregistry.ErrNotFound {
@@ -206,9 +237,6 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.SetConditions(xpv1.Available())
-	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalObservation{}, err
-	}
 
 	return managed.ExternalObservation{
 		// Return false when the external resource does not exist. This lets
@@ -239,18 +267,22 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	var client = c.service.(schemaregistry.IClient)
-	_, err := client.SchemaCreate(cr.Spec.ForProvider.Subject, cr.Spec.ForProvider.Schema, cr.Spec.ForProvider.SchemaType, cr.Spec.ForProvider.Environment)
+	_, err := client.SchemaCreate(ctx, cr.Spec.ForProvider.Subject, cr.Spec.ForProvider.Schema, cr.Spec.ForProvider.SchemaType, cr.Spec.ForProvider.Environment)
 
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
-	_, err = client.SchemaSubjectUpdateCommand(cr.Spec.ForProvider.Subject, cr.Spec.ForProvider.Compatibility, cr.Spec.ForProvider.Environment)
+	_, err = client.SchemaSubjectUpdateCommand(ctx, cr.Spec.ForProvider.Subject, cr.Spec.ForProvider.Compatibility, cr.Spec.ForProvider.Environment)
 
 	if err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -266,12 +298,16 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	var client = c.service.(schemaregistry.IClient)
 
-	_, err := client.SchemaCreate(cr.Spec.ForProvider.Subject, cr.Spec.ForProvider.Schema, cr.Spec.ForProvider.SchemaType, cr.Spec.ForProvider.Environment)
+	_, err := client.SchemaCreate(ctx, cr.Spec.ForProvider.Subject, cr.Spec.ForProvider.Schema, cr.Spec.ForProvider.SchemaType, cr.Spec.ForProvider.Environment)
 
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -292,11 +328,11 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	var client = c.service.(schemaregistry.IClient)
 
-	_, err := client.SchemaDelete(cr.Spec.ForProvider.Subject, "all", false, cr.Spec.ForProvider.Environment)
+	_, err := client.SchemaDelete(ctx, cr.Spec.ForProvider.Subject, "all", false, cr.Spec.ForProvider.Environment)
 	if err != nil {
 		return err
 	}
-	_, err = client.SchemaDelete(cr.Spec.ForProvider.Subject, "all", true, cr.Spec.ForProvider.Environment)
+	_, err = client.SchemaDelete(ctx, cr.Spec.ForProvider.Subject, "all", true, cr.Spec.ForProvider.Environment)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,51 @@
+package schema
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikeyv1alpha1 "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	"github.com/dfds/provider-confluent/apis/schema/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+)
+
+const (
+	errGetAPIKey        = "cannot get APIKey %s"
+	errAPIKeyScope      = "APIKey %s is not scoped to a schema registry"
+	errAPIKeyNoSecret   = "APIKey %s does not write a connection secret"
+	errGetAPIKeySecret  = "cannot get the connection secret of APIKey %s"
+	errAPIKeySecretKeys = "connection secret of APIKey %s does not hold the key %q & secret %q"
+)
+
+// schemaRegistryCredentials Returns the key & secret of the APIKey name from its connection secret. The key must be scoped to a schema
+// registry, a key of a Kafka cluster is rejected by the schema registry
+func schemaRegistryCredentials(ctx context.Context, kube client.Reader, name string) (clients.APICredentials, error) {
+	ak := &apikeyv1alpha1.APIKey{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name}, ak); err != nil {
+		return clients.APICredentials{}, errors.Wrapf(err, errGetAPIKey, name)
+	}
+	if ak.Status.AtProvider.Scope != apikeyv1alpha1.ScopeSchemaRegistry {
+		return clients.APICredentials{}, errors.Errorf(errAPIKeyScope, name)
+	}
+
+	ref := ak.Spec.WriteConnectionSecretToReference
+	if ref == nil {
+		return clients.APICredentials{}, errors.Errorf(errAPIKeyNoSecret, name)
+	}
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return clients.APICredentials{}, errors.Wrapf(err, errGetAPIKeySecret, name)
+	}
+
+	keyName, secretName := ak.ConnectionSecretKeys()
+	key, secret := s.Data[keyName], s.Data[secretName]
+	if len(key) == 0 || len(secret) == 0 {
+		return clients.APICredentials{}, errors.Errorf(errAPIKeySecretKeys, name, keyName, secretName)
+	}
+
+	return clients.APICredentials{Identifier: v1alpha1.SchemeGroupVersion.Identifier(), Key: string(key), Secret: string(secret)}, nil
+}
@@ -0,0 +1,52 @@
+package schema
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apikeyv1alpha1 "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+)
+
+func TestSchemaRegistryCredentials(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	ak := apikeyv1alpha1.APIKey{}
+	ak.Spec.WriteConnectionSecretToReference = &xpv1.SecretReference{Name: "schema-registry-key", Namespace: "crossplane-system"}
+	ak.Spec.ForProvider.ConnectionSecretKeyMapping = &apikeyv1alpha1.ConnectionSecretKeyMapping{Key: "sr-key", Secret: "sr-secret"}
+	ak.Status.AtProvider.Scope = apikeyv1alpha1.ScopeSchemaRegistry
+
+	kube := &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *apikeyv1alpha1.APIKey:
+				ak.DeepCopyInto(o)
+			case *corev1.Secret:
+				assert.Equal("crossplane-system", key.Namespace)
+				o.Data = map[string][]byte{"sr-key": []byte("KEY"), "sr-secret": []byte("SECRET")}
+			}
+			return nil
+		},
+	}
+
+	creds, err := schemaRegistryCredentials(ctx, kube, "schema-registry")
+	assert.NoError(err)
+	assert.Equal("KEY", creds.Key)
+	assert.Equal("SECRET", creds.Secret)
+
+	// A key of a Kafka cluster is rejected
+	ak.Status.AtProvider.Scope = apikeyv1alpha1.ScopeKafka
+	_, err = schemaRegistryCredentials(ctx, kube, "schema-registry")
+	assert.EqualError(err, "APIKey schema-registry is not scoped to a schema registry")
+
+	ak.Status.AtProvider.Scope = apikeyv1alpha1.ScopeSchemaRegistry
+	ak.Spec.ForProvider.ConnectionSecretKeyMapping = nil
+	_, err = schemaRegistryCredentials(ctx, kube, "schema-registry")
+	assert.EqualError(err, `connection secret of APIKey schema-registry does not hold the key "username" & secret "password"`)
+}
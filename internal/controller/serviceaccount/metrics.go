@@ -0,0 +1,40 @@
+package serviceaccount
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Reconcile outcomes of a ServiceAccount
+const (
+	OutcomeCreate = "create"
+	OutcomeImport = "import"
+	OutcomeUpdate = "update"
+	OutcomeNoop   = "noop"
+	OutcomeDelete = "delete"
+	OutcomeError  = "error"
+)
+
+var reconcileOutcomes = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "provider_confluent",
+	Subsystem: "serviceaccount",
+	Name:      "reconcile_outcomes_total",
+	Help:      "Number of ServiceAccount reconciles by outcome.",
+}, []string{"outcome"})
+
+func init() {
+	metrics.Registry.MustRegister(reconcileOutcomes)
+}
+
+// recordOutcome Counts a reconcile with the given outcome
+func recordOutcome(outcome string) {
+	reconcileOutcomes.WithLabelValues(outcome).Inc()
+}
+
+// recordError Counts err as an error outcome, if set, & returns it unchanged
+func recordError(err error) error {
+	if err != nil {
+		recordOutcome(OutcomeError)
+	}
+	return err
+}
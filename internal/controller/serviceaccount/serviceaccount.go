@@ -18,6 +18,7 @@ package serviceaccount
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/crossplane/crossplane-runtime/pkg/event"
@@ -27,7 +28,6 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -38,7 +38,16 @@ import (
 	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
 
 	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/apikey"
 	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+	"github.com/dfds/provider-confluent/internal/externalname"
 )
 
 const (
@@ -46,20 +55,28 @@ const (
 	errTrackPCUsage    = "cannot track ProviderConfig usage"
 	errGetPC           = "cannot get ProviderConfig"
 	errGetCreds        = "cannot get credentials"
+	errGetCABundle     = "cannot get CA bundle"
 	errNewClient       = "cannot create new Service"
 	errAuthCredentials = "invalid client credentials"
+
+	errAPIKeyWithoutEnv = "API key of cluster %s needs the environment of the cluster"
+	errCreateAPIKey     = "cannot create API key of service account %s"
+	errOrphanedAPIKey   = "cannot delete API key %s, which could not be recorded"
+	errUnauthorized     = "Confluent Cloud rejected the credentials of the ProviderConfig"
+	errNameInUse        = "service account %s exists already, set it as external name to adopt it"
+	errRenamed          = "service account %s cannot be renamed to %s, Confluent does not support renaming service accounts. Restore the name or recreate the resource"
 )
 
 var (
-	createAndConvertClientFunc = func(clientCreds []byte, apiCreds clients.APICredentials) (interface{}, error) { //nolint
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
 		credParts := strings.Split(string(clientCreds), ":")
 
 		if len(credParts) != 2 {
 			return nil, errors.New(errAuthCredentials)
 		}
 
-		cClient := clients.NewClient()
-		authErr := cClient.Authenticate(credParts[0], credParts[1])
+		cClient := clients.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
 
 		if authErr != nil {
 			return nil, authErr
@@ -67,6 +84,8 @@ var (
 
 		srConfig := serviceaccount.Config{
 			APICredentials: apiCreds,
+			MaxRetries:     serviceaccount.DefaultMaxRetries,
+			BaseDelay:      serviceaccount.DefaultBaseDelay,
 		}
 
 		return serviceaccount.NewClient(srConfig).(interface{}), nil
@@ -81,20 +100,25 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
 	}
 
+	failures := retry.NewTracker()
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.ServiceAccountGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(failures.Connecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: createAndConvertClientFunc}),
+			newServiceFn: createAndConvertClientFunc})),
 		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient(), v1alpha1.ServiceAccountKind)),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o).
 		For(&v1alpha1.ServiceAccount{}).
-		Complete(r)
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.ServiceAccountList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.ServiceAccount{} }, failures.Reconciler(r))))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -102,7 +126,7 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(creds []byte, apiCreds clients.APICredentials) (interface{}, error)
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -121,11 +145,11 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	clientCredentialData, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -140,12 +164,23 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		}
 	}
 
-	svc, err := c.newServiceFn(clientCredentialData, apiCredentials)
+	caBundle, err := clients.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := clients.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
-	return &external{service: svc, kube: c.kube}, nil
+	return &external{service: svc, apiKeyService: apikey.NewClient(apikey.Config{APICredentials: apiCredentials}), kube: c.kube}, nil
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
@@ -153,8 +188,9 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 type external struct {
 	// A 'client' used to connect to the external resource API. In practice this
 	// would be something like an AWS SDK client.
-	service interface{}
-	kube    client.Client
+	service       interface{}
+	apiKeyService interface{}
+	kube          client.Client
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -164,11 +200,18 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	// Support for importing resource using exernal name
-	name, _ := ExternalNameHelper(cr)
+	name, _ := externalname.ServiceAccount(cr)
 
 	// Confluent
 	var client = c.service.(serviceaccount.IClient)
-	observe, err := client.ServiceAccountByName(name)
+	observe, err := client.ServiceAccountByName(ctx, name)
+
+	// A changed name would otherwise create or adopt another service account
+	if id := cr.Status.AtProvider.ID; id != "" && (serviceaccount.IsNotFound(err) || err == nil && observe.ID != id) {
+		if err := c.checkRenamed(ctx, id, name); err != nil {
+			return managed.ExternalObservation{}, recordError(err)
+		}
+	}
 
 	// Check if resource require creation
 	create, err := ObserveCreateResource(cr, err)
@@ -176,7 +219,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{
 			ResourceExists:    false,
 			ConnectionDetails: managed.ConnectionDetails{},
-		}, err
+		}, recordError(err)
 	}
 
 	if create {
@@ -186,25 +229,35 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
+	// The marker is not part of the desired description
+	marked := unmark(&observe)
+
+	// Fill an unset description before diffing, so late-init never overrides an explicitly cleared one
+	lateInitialized := LateInitialize(cr, observe)
+
 	// Check if resource require update
-	update := ObserveUpdateResource(cr, observe)
+	update := ObserveUpdateResource(cr, observe, marked) || needsAPIKey(cr)
 	if update {
 		return managed.ExternalObservation{
-			ResourceExists:    true,
-			ResourceUpToDate:  false,
-			ConnectionDetails: managed.ConnectionDetails{},
+			ResourceExists:          true,
+			ResourceUpToDate:        false,
+			ResourceLateInitialized: lateInitialized,
+			ConnectionDetails:       managed.ConnectionDetails{},
 		}, nil
 	}
 
 	cr.Status.SetConditions(xpv1.Available())
-	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalObservation{}, err
+	if Marker != "" {
+		cr.Status.SetConditions(ownershipCondition(observe, marked))
 	}
 
+	recordOutcome(OutcomeNoop)
+
 	return managed.ExternalObservation{
-		ResourceExists:    true,
-		ResourceUpToDate:  true,
-		ConnectionDetails: managed.ConnectionDetails{},
+		ResourceExists:          true,
+		ResourceUpToDate:        true,
+		ResourceLateInitialized: lateInitialized,
+		ConnectionDetails:       managed.ConnectionDetails{},
 	}, nil
 }
 
@@ -214,49 +267,84 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, errors.New(errNotMyType)
 	}
 
-	cr.Status.SetConditions(xpv1.Creating())
-	if err := c.kube.Status().Update(ctx, cr); err != nil {
+	if err := validateAPIKey(cr.Spec.ForProvider.APIKey); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
-	name, exists := ExternalNameHelper(cr)
+	// Record the name before creating the service account, so a retry after a failure between its creation & the status write adopts it
+	// instead of creating another one
+	name, exists := externalname.ServiceAccount(cr)
+	if pending := cr.Status.AtProvider.PendingName; pending != "" {
+		name, exists = pending, true
+	}
+	cr.Status.AtProvider.PendingName = name
+
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, recordError(err)
+	}
 
 	var createIsImport bool
 
 	var client = c.service.(serviceaccount.IClient)
 
 	if exists {
-		observe, err := client.ServiceAccountByName(name) // not sure if ExternalName is empty
+		observe, err := client.ServiceAccountByName(ctx, name) // not sure if ExternalName is empty
 		createIsImport, err = CreateResourceIsImport(err)
 		if err != nil {
-			return managed.ExternalCreation{}, err
+			return managed.ExternalCreation{}, recordError(err)
 		}
 		if createIsImport {
 			cr.Status.AtProvider.ID = observe.ID
+			// An adopted account without the marker may be managed by another tool as well, which is reported rather than refused
+			if Marker != "" {
+				cr.Status.SetConditions(ownershipCondition(observe, unmark(&observe)))
+			}
 		}
 	}
 
 	if !createIsImport {
-		out, err := client.ServiceAccountCreate(name, cr.Spec.ForProvider.Description)
+		if Marker != "" {
+			cr.Status.SetConditions(MarkerFound())
+		}
+		out, err := client.ServiceAccountCreate(ctx, name, markedDescription(cr))
 		if err != nil {
-			return managed.ExternalCreation{}, err
+			return managed.ExternalCreation{}, recordError(createError(name, err))
 		}
 		cr.Status.AtProvider.ID = out.ID
 	}
 
 	meta.SetExternalName(cr, name)
 	if err := c.kube.Update(ctx, cr); err != nil {
-		return managed.ExternalCreation{}, err
+		return managed.ExternalCreation{}, recordError(err)
 	}
 
+	cr.Status.AtProvider.PendingName = ""
 	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalCreation{}, err
+		return managed.ExternalCreation{}, recordError(err)
+	}
+
+	// The last sync is recorded before the API key is created, as its secret is lost unless returned
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, recordError(err)
+	}
+
+	// The service account is recorded before its API key is created, so a failure to create the key is retried by an update
+	conn, err := c.createAPIKey(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, recordError(err)
+	}
+
+	if createIsImport {
+		recordOutcome(OutcomeImport)
+	} else {
+		recordOutcome(OutcomeCreate)
 	}
 
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: conn,
 	}, nil
 }
 
@@ -266,18 +354,84 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotMyType)
 	}
 
+	if err := validateAPIKey(cr.Spec.ForProvider.APIKey); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	var client = c.service.(serviceaccount.IClient)
 
 	// Update description
-	err := client.ServiceAccountUpdate(cr.Status.AtProvider.ID, cr.Spec.ForProvider.Description)
+	err := client.ServiceAccountUpdate(ctx, cr.Status.AtProvider.ID, markedDescription(cr))
 	if err != nil {
-		return managed.ExternalUpdate{}, err
+		return managed.ExternalUpdate{}, recordError(err)
 	}
 
+	// The last sync is recorded before the API key is created, as its secret is lost unless returned
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, recordError(err)
+	}
+
+	conn, err := c.createAPIKey(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, recordError(err)
+	}
+
+	recordOutcome(OutcomeUpdate)
+
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
-		ConnectionDetails: managed.ConnectionDetails{},
+		ConnectionDetails: conn,
+	}, nil
+}
+
+// checkRenamed Refuses a name that differs from the name of the recorded service account, which Confluent cannot rename. A recorded service
+// account that was deleted out-of-band is created again under the new name
+func (c *external) checkRenamed(ctx context.Context, id string, name string) error {
+	var client = c.service.(serviceaccount.IClient)
+
+	current, err := client.ServiceAccountByID(ctx, id)
+	if serviceaccount.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if current.Name != name {
+		return errors.Errorf(errRenamed, current.Name, name)
+	}
+	return nil
+}
+
+// createAPIKey Creates the API key a ServiceAccount asks for unless it was created before, & returns it as connection details. The key is
+// recorded right away, as Confluent never returns its secret again
+func (c *external) createAPIKey(ctx context.Context, cr *v1alpha1.ServiceAccount) (managed.ConnectionDetails, error) {
+	if !needsAPIKey(cr) {
+		return managed.ConnectionDetails{}, nil
+	}
+
+	var client = c.apiKeyService.(apikey.IClient)
+
+	k := cr.Spec.ForProvider.APIKey
+	out, err := client.APIKeyCreate(ctx, k.Resource, fmt.Sprintf(apiKeyDescription, cr.GetName()), cr.Status.AtProvider.ID, k.Environment)
+	if err != nil {
+		return nil, errors.Wrapf(err, errCreateAPIKey, cr.Status.AtProvider.ID)
+	}
+
+	// Confluent returns the secret only once. A key that cannot be recorded is deleted, so the next reconcile creates another one
+	cr.Status.AtProvider.APIKey = out.Key
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		cr.Status.AtProvider.APIKey = ""
+		if derr := client.APIKeyDelete(ctx, out.Key); derr != nil {
+			return nil, errors.Wrapf(derr, errOrphanedAPIKey, out.Key)
+		}
+		return nil, err
+	}
+
+	return managed.ConnectionDetails{
+		ConnectionSecretAPIKey:    []byte(out.Key),
+		ConnectionSecretAPISecret: []byte(out.Secret),
 	}, nil
 }
 
@@ -289,15 +443,18 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	cr.Status.SetConditions(xpv1.Deleting())
 	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return err
+		return recordError(err)
 	}
 
 	var client = c.service.(serviceaccount.IClient)
 
-	err := client.ServiceAccountDelete(cr.Status.AtProvider.ID)
-	if err != nil {
-		return err
+	// A service account deleted out-of-band is gone already, so the finalizer can be removed
+	err := client.ServiceAccountDelete(ctx, cr.Status.AtProvider.ID)
+	if err != nil && !serviceaccount.IsNotFound(err) {
+		return recordError(err)
 	}
 
+	recordOutcome(OutcomeDelete)
+
 	return nil
 }
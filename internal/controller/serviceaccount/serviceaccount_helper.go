@@ -1,16 +1,113 @@
 package serviceaccount
 
 import (
-	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"fmt"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
 	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
 )
 
+// Marker prefixes the description of the service accounts created by the provider, so adopted accounts managed by another tool, e.g.
+// Terraform, can be told apart. Empty disables the marker
+var Marker = ""
+
+// Condition type & reasons of the ownership marker
+const (
+	TypeProviderManaged xpv1.ConditionType = "ProviderManaged"
+
+	ReasonMarkerFound   xpv1.ConditionReason = "MarkerFound"
+	ReasonMarkerMissing xpv1.ConditionReason = "MarkerMissing"
+)
+
+// Connection secret keys of the API key of a ServiceAccount
+const (
+	ConnectionSecretAPIKey    = "api-key"
+	ConnectionSecretAPISecret = "api-secret"
+)
+
+// resourceCloud is the resource of API keys granting access to the Confluent Cloud API rather than a cluster
+const resourceCloud = "cloud"
+
+const apiKeyDescription = "API key of ServiceAccount %s"
+
+// maxDescriptionLength is the most characters Confluent Cloud accepts in the description of a service account
+const maxDescriptionLength = 128
+
+const msgMarkerMissing = "description of service account %s does not start with the marker %q, it may be managed by another tool"
+
+// MarkerFound indicates that a service account carries the marker, i.e. it was created by the provider
+func MarkerFound() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeProviderManaged,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMarkerFound,
+	}
+}
+
+// MarkerMissing indicates that an adopted service account lacks the marker, so another tool may manage it as well
+func MarkerMissing(id string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeProviderManaged,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMarkerMissing,
+		Message:            fmt.Sprintf(msgMarkerMissing, id, Marker),
+	}
+}
+
+// unmark Strips Marker from the description of a service account in Confluent Cloud & reports whether it carried the marker
+func unmark(sac *serviceaccount.ServiceAccount) bool {
+	if Marker == "" || !strings.HasPrefix(sac.Description, Marker) {
+		return false
+	}
+	sac.Description = strings.TrimPrefix(sac.Description, Marker)
+	return true
+}
+
+// ownershipCondition Returns the ProviderManaged condition of a service account, depending on whether it carried the marker
+func ownershipCondition(sac serviceaccount.ServiceAccount, marked bool) xpv1.Condition {
+	if marked {
+		return MarkerFound()
+	}
+	return MarkerMissing(sac.ID)
+}
+
+// markedDescription Returns the description of a ServiceAccount as stored in Confluent Cloud. The marker is only kept on marked service
+// accounts, so adopting an account never marks it
+func markedDescription(sa *v1alpha1.ServiceAccount) string {
+	if Marker == "" || sa.GetCondition(TypeProviderManaged).Reason != ReasonMarkerFound {
+		return Description(sa)
+	}
+	return Marker + fitMarker(Description(sa))
+}
+
+// fitMarker Truncates the description of a marked service account, so it still fits in maxDescriptionLength after the marker
+func fitMarker(description string) string {
+	n := maxDescriptionLength - len([]rune(Marker))
+	if n < 0 {
+		n = 0
+	}
+	if r := []rune(description); len(r) > n {
+		return string(r[:n])
+	}
+	return description
+}
+
 // ObserveCreateResource Checks if a ServiceAccount should be created
 func ObserveCreateResource(sa *v1alpha1.ServiceAccount, err error) (bool, error) {
 	if err != nil {
-		if err.Error() == serviceaccount.ErrNotExists {
+		switch {
+		case serviceaccount.IsNotFound(err):
 			return true, nil
+		case serviceaccount.IsUnauthorized(err):
+			return true, errors.Wrap(err, errUnauthorized)
 		}
 
 		return true, err
@@ -24,29 +121,78 @@ func ObserveCreateResource(sa *v1alpha1.ServiceAccount, err error) (bool, error)
 	return false, nil
 }
 
-// ObserveUpdateResource Checks if a ServiceAccount should be updated
-func ObserveUpdateResource(sa *v1alpha1.ServiceAccount, sac serviceaccount.ServiceAccount) bool {
+// ObserveUpdateResource Checks if a ServiceAccount should be updated. An unset description is never considered a diff, it is late-initialized instead.
+// The description of a marked service account is compared as truncated to fit the marker
+func ObserveUpdateResource(sa *v1alpha1.ServiceAccount, sac serviceaccount.ServiceAccount, marked bool) bool {
+	if sa.Spec.ForProvider.Description == nil {
+		return false
+	}
+
+	desired := *sa.Spec.ForProvider.Description
+	if marked {
+		desired = fitMarker(desired)
+	}
+
 	// Diff
-	return sa.Spec.ForProvider.Description != sac.Description
+	return desired != sac.Description
+}
+
+// LateInitialize Fills an unset description of a ServiceAccount from Confluent Cloud. An explicitly set description, including the empty string, takes precedence and is left untouched
+func LateInitialize(sa *v1alpha1.ServiceAccount, sac serviceaccount.ServiceAccount) bool {
+	if sa.Spec.ForProvider.Description != nil {
+		return false
+	}
+
+	description := sac.Description
+	sa.Spec.ForProvider.Description = &description
+
+	return true
 }
 
-// ExternalNameHelper Checks if a ServiceAccount k8s object has an external-name attached. If it does, return that external-name, if it doesn't, return the name of the k8s object
-func ExternalNameHelper(sa *v1alpha1.ServiceAccount) (string, bool) {
-	extName := meta.GetExternalName(sa)
-	if extName != "" {
-		return extName, true
+// Description Returns the desired description of a ServiceAccount, an unset description is created as empty
+func Description(sa *v1alpha1.ServiceAccount) string {
+	if sa.Spec.ForProvider.Description == nil {
+		return ""
 	}
-	return sa.Name, false
+	return *sa.Spec.ForProvider.Description
 }
 
 // CreateResourceIsImport Checks if a ServiceAccount k8s object is considered an import
 func CreateResourceIsImport(err error) (bool, error) {
 	if err != nil {
-		if err.Error() == serviceaccount.ErrNotExists {
+		switch {
+		case serviceaccount.IsNotFound(err):
 			return false, nil
+		case serviceaccount.IsUnauthorized(err):
+			return false, errors.Wrap(err, errUnauthorized)
 		}
 
 		return false, err
 	}
 	return true, err
 }
+
+// createError Explains a failure to create a ServiceAccount. A name taken by another service account is adopted only through the external
+// name, so an account of another owner is never taken over by accident
+func createError(name string, err error) error {
+	switch {
+	case serviceaccount.IsAlreadyExists(err):
+		return errors.Wrapf(err, errNameInUse, name)
+	case serviceaccount.IsUnauthorized(err):
+		return errors.Wrap(err, errUnauthorized)
+	}
+	return err
+}
+
+// needsAPIKey Checks if a ServiceAccount asks for an API key that was not created yet
+func needsAPIKey(cr *v1alpha1.ServiceAccount) bool {
+	return cr.Spec.ForProvider.APIKey != nil && cr.Status.AtProvider.APIKey == ""
+}
+
+// validateAPIKey Checks that the API key of a ServiceAccount can be created, so the service account is not created without it
+func validateAPIKey(k *v1alpha1.ServiceAccountAPIKey) error {
+	if k == nil || k.Resource == resourceCloud || k.Environment != "" {
+		return nil
+	}
+	return errors.Errorf(errAPIKeyWithoutEnv, k.Resource)
+}
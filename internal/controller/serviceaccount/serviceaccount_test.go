@@ -1,40 +1,35 @@
 package serviceaccount
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/apikey"
 	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func TestExternalNameHelper(t *testing.T) {
-	assert := assert.New(t)
-
-	// No external name
-	sa := v1alpha1.ServiceAccount{}
-	sa.Name = "name"
-	name, exists := ExternalNameHelper(&sa)
-	assert.Equal(sa.Name, name)
-	assert.False(exists)
-
-	// With external name
-	extName := make(map[string]string)
-	extName["crossplane.io/external-name"] = "extname"
-	sa.SetAnnotations(extName)
-	name, exists = ExternalNameHelper(&sa)
-	assert.NotEqual(sa.Name, name, "external name not used")
-	assert.Equal("extname", name, "external name not used")
-	assert.True(exists)
-}
-
 func TestCreateResource(t *testing.T) {
 	assert := assert.New(t)
 
 	// Resource do not exists
 	sa := v1alpha1.ServiceAccount{}
-	create, err := ObserveCreateResource(&sa, errors.New(serviceaccount.ErrNotExists))
+	create, err := ObserveCreateResource(&sa, serviceaccount.ErrNotFound)
 	if err != nil {
 		t.Errorf("no error expected when ErrorNotExists is passed to function")
 	} else {
@@ -58,6 +53,10 @@ func TestCreateResource(t *testing.T) {
 		assert.False(create, "resource has status set so it should not create")
 	}
 
+	// Rejected credentials
+	_, err = ObserveCreateResource(&sa, errors.Wrap(serviceaccount.ErrUnauthorized, "401"))
+	assert.True(serviceaccount.IsUnauthorized(err))
+
 	// Unknow error
 	const uErr = "unknown"
 	_, err = ObserveCreateResource(&sa, errors.New(uErr))
@@ -74,32 +73,865 @@ func TestObserveUpdateResource(t *testing.T) {
 	// Descriptions match
 	description := "my description"
 	sa := v1alpha1.ServiceAccount{}
-	sa.Spec.ForProvider.Description = description
+	sa.Spec.ForProvider.Description = &description
 	sac := serviceaccount.ServiceAccount{}
 	sac.Description = description
-	assert.False(ObserveUpdateResource(&sa, sac), "no update required when descriptions match")
+	assert.False(ObserveUpdateResource(&sa, sac, false), "no update required when descriptions match")
 
 	// Descriptions do not match
-	sa.Spec.ForProvider.Description = "almost my description"
-	assert.True(ObserveUpdateResource(&sa, sac), "update required when descriptions do not match")
+	almost := "almost my description"
+	sa.Spec.ForProvider.Description = &almost
+	assert.True(ObserveUpdateResource(&sa, sac, false), "update required when descriptions do not match")
+
+	// Explicitly empty description clears the remote one
+	empty := ""
+	sa.Spec.ForProvider.Description = &empty
+	assert.True(ObserveUpdateResource(&sa, sac, false), "update required when description is explicitly cleared")
+
+	// Unset description is late-initialized, not updated
+	sa.Spec.ForProvider.Description = nil
+	assert.False(ObserveUpdateResource(&sa, sac, false), "no update required when description is unset")
+}
+
+func TestLateInitialize(t *testing.T) {
+	assert := assert.New(t)
+
+	sac := serviceaccount.ServiceAccount{Description: "remote description"}
+
+	// Unset description is filled from the remote
+	sa := v1alpha1.ServiceAccount{}
+	assert.True(LateInitialize(&sa, sac))
+	assert.Equal("remote description", *sa.Spec.ForProvider.Description)
+	assert.False(LateInitialize(&sa, sac), "late-init only happens once")
+
+	// Explicitly empty description wins over late-init
+	empty := ""
+	sa.Spec.ForProvider.Description = &empty
+	assert.False(LateInitialize(&sa, sac))
+	assert.Equal("", *sa.Spec.ForProvider.Description)
+	assert.True(ObserveUpdateResource(&sa, sac, false))
+
+	// Populated description wins over late-init
+	populated := "my description"
+	sa.Spec.ForProvider.Description = &populated
+	assert.False(LateInitialize(&sa, sac))
+	assert.Equal("my description", *sa.Spec.ForProvider.Description)
+}
+
+func TestDescription(t *testing.T) {
+	assert := assert.New(t)
+
+	sa := v1alpha1.ServiceAccount{}
+	assert.Equal("", Description(&sa), "unset description is created as empty")
+
+	description := "my description"
+	sa.Spec.ForProvider.Description = &description
+	assert.Equal(description, Description(&sa))
 }
 
 func TestCreateResourceIsImport(t *testing.T) {
 	assert := assert.New(t)
 
-	// ErrNotExists
-	isImport, err := CreateResourceIsImport(errors.New(serviceaccount.ErrNotExists))
+	// ErrNotFound
+	isImport, err := CreateResourceIsImport(serviceaccount.ErrNotFound)
 	assert.False(isImport)
 	assert.NoError(err)
 
+	// ErrNotFound wrapped
+	isImport, err = CreateResourceIsImport(errors.Wrap(serviceaccount.ErrNotFound, "lookup"))
+	assert.False(isImport)
+	assert.NoError(err)
+
+	// error with the same message but not the sentinel
+	isImport, err = CreateResourceIsImport(errors.New(serviceaccount.ErrNotExists))
+	assert.False(isImport)
+	assert.Error(err)
+
 	// unknow error
 	const uErr = "unknow error"
 	isImport, err = CreateResourceIsImport(errors.New(uErr))
 	assert.False(isImport)
 	assert.Equal(err.Error(), uErr)
 
+	// ErrUnauthorized
+	isImport, err = CreateResourceIsImport(errors.Wrap(serviceaccount.ErrUnauthorized, "401"))
+	assert.False(isImport)
+	assert.True(serviceaccount.IsUnauthorized(err))
+	assert.Contains(err.Error(), errUnauthorized)
+
 	// nil error
 	isImport, err = CreateResourceIsImport(nil)
 	assert.True(isImport)
 	assert.NoError(err)
 }
+
+func TestCreateError(t *testing.T) {
+	assert := assert.New(t)
+
+	err := createError("taken", errors.Wrap(serviceaccount.ErrAlreadyExists, "409"))
+	assert.True(serviceaccount.IsAlreadyExists(err))
+	assert.Contains(err.Error(), "service account taken exists already")
+
+	err = createError("denied", errors.Wrap(serviceaccount.ErrUnauthorized, "401"))
+	assert.True(serviceaccount.IsUnauthorized(err))
+	assert.Contains(err.Error(), errUnauthorized)
+
+	const uErr = "unknown"
+	assert.EqualError(createError("other", errors.New(uErr)), uErr)
+}
+
+// fakeServiceAccountClient keeps service accounts in memory. errs injects the error returned by a method, keyed by its name
+type fakeServiceAccountClient struct {
+	serviceaccount.IClient
+	accounts []serviceaccount.ServiceAccount
+	errs     map[string]error
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountByName(_ context.Context, name string) (serviceaccount.ServiceAccount, error) {
+	if err := f.errs["ServiceAccountByName"]; err != nil {
+		return serviceaccount.ServiceAccount{}, err
+	}
+	for _, sa := range f.accounts {
+		if sa.Name == name {
+			return sa, nil
+		}
+	}
+	return serviceaccount.ServiceAccount{}, serviceaccount.ErrNotFound
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountByID(_ context.Context, id string) (serviceaccount.ServiceAccount, error) {
+	if err := f.errs["ServiceAccountByID"]; err != nil {
+		return serviceaccount.ServiceAccount{}, err
+	}
+	for _, sa := range f.accounts {
+		if sa.ID == id {
+			return sa, nil
+		}
+	}
+	return serviceaccount.ServiceAccount{}, serviceaccount.ErrNotFound
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountCreate(_ context.Context, name string, description string) (serviceaccount.ServiceAccount, error) {
+	if err := f.errs["ServiceAccountCreate"]; err != nil {
+		return serviceaccount.ServiceAccount{}, err
+	}
+	sa := serviceaccount.ServiceAccount{Name: name, Description: description, ID: "sa-" + name}
+	f.accounts = append(f.accounts, sa)
+	return sa, nil
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountUpdate(_ context.Context, id string, description string) error {
+	if err := f.errs["ServiceAccountUpdate"]; err != nil {
+		return err
+	}
+	for i, sa := range f.accounts {
+		if sa.ID == id {
+			f.accounts[i].Description = description
+			return nil
+		}
+	}
+	return serviceaccount.ErrNotFound
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountDelete(_ context.Context, id string) error {
+	if err := f.errs["ServiceAccountDelete"]; err != nil {
+		return err
+	}
+	for i, sa := range f.accounts {
+		if sa.ID == id {
+			f.accounts = append(f.accounts[:i], f.accounts[i+1:]...)
+			return nil
+		}
+	}
+	return serviceaccount.ErrNotFound
+}
+
+func TestDeleteNotFound(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// The service account was deleted out-of-band, so the fake has no accounts
+	e := &external{service: &fakeServiceAccountClient{}, kube: test.NewMockClient()}
+
+	cr := v1alpha1.ServiceAccount{}
+	cr.Name = "deleted"
+	cr.Status.AtProvider.ID = "sa-12345"
+	assert.NoError(e.Delete(ctx, &cr))
+
+	// Other errors still fail the deletion
+	e.service = &fakeServiceAccountClient{errs: map[string]error{"ServiceAccountDelete": errors.New("500: Internal Server Error")}}
+	assert.Error(e.Delete(ctx, &cr))
+}
+
+func TestObserveRename(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeServiceAccountClient{accounts: []serviceaccount.ServiceAccount{
+		{Name: "old", Description: "description", ID: "sa-11111"},
+		{Name: "other", Description: "description", ID: "sa-22222"},
+	}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	renamed := func(name string) *v1alpha1.ServiceAccount {
+		cr := &v1alpha1.ServiceAccount{}
+		cr.Name = "account"
+		cr.SetAnnotations(map[string]string{"crossplane.io/external-name": name})
+		cr.Status.AtProvider.ID = "sa-11111"
+		return cr
+	}
+
+	// A new name is refused instead of creating another service account
+	_, err := e.Observe(ctx, renamed("new"))
+	assert.EqualError(err, "service account old cannot be renamed to new, Confluent does not support renaming service accounts. Restore the name or recreate the resource")
+	assert.Len(fake.accounts, 2)
+
+	// A name of another service account is refused instead of adopting it
+	_, err = e.Observe(ctx, renamed("other"))
+	assert.Error(err)
+
+	// Restoring the name observes the recorded service account again
+	description := "description"
+	cr := renamed("old")
+	cr.Spec.ForProvider.Description = &description
+	o, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(o.ResourceExists)
+
+	// A recorded service account that was deleted out-of-band is created under the new name
+	fake.accounts = fake.accounts[1:]
+	o, err = e.Observe(ctx, renamed("new"))
+	assert.NoError(err)
+	assert.False(o.ResourceExists)
+}
+
+func TestObserveDoesNotWriteStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	writes := 0
+	kube := test.NewMockClient()
+	kube.MockUpdate = func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+		writes++
+		return nil
+	}
+	kube.MockStatusUpdate = func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+		writes++
+		return nil
+	}
+
+	e := &external{
+		service: &fakeServiceAccountClient{accounts: []serviceaccount.ServiceAccount{{Name: "name", Description: "description", ID: "sa-55555"}}},
+		kube:    kube,
+	}
+
+	sa := v1alpha1.ServiceAccount{}
+	sa.Name = "name"
+	description := "description"
+	sa.Spec.ForProvider.Description = &description
+	sa.Status.AtProvider.ID = "sa-55555"
+
+	// The condition is only set in memory, the managed reconciler persists it. The last-sync annotations are only recorded by Create &
+	// Update
+	obs, err := e.Observe(context.Background(), &sa)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.True(sa.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+	assert.Empty(sa.GetAnnotations()[syncinfo.AnnotationKeyLastSyncTime])
+	assert.Equal(0, writes)
+
+	obs, err = e.Observe(context.Background(), &sa)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(0, writes, "no write expected when the resource is up to date")
+}
+
+func TestReconcileOutcomes(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	count := func(outcome string) float64 {
+		return testutil.ToFloat64(reconcileOutcomes.WithLabelValues(outcome))
+	}
+	before := map[string]float64{}
+	for _, o := range []string{OutcomeCreate, OutcomeImport, OutcomeUpdate, OutcomeNoop, OutcomeDelete, OutcomeError} {
+		before[o] = count(o)
+	}
+
+	fake := &fakeServiceAccountClient{accounts: []serviceaccount.ServiceAccount{{Name: "existing", Description: "description", ID: "sa-55555"}}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	// Create
+	created := v1alpha1.ServiceAccount{}
+	created.Name = "new"
+	_, err := e.Create(ctx, &created)
+	assert.NoError(err)
+	assert.Equal(before[OutcomeCreate]+1, count(OutcomeCreate))
+
+	// Import through external name
+	imported := v1alpha1.ServiceAccount{}
+	imported.Name = "imported"
+	imported.SetAnnotations(map[string]string{"crossplane.io/external-name": "existing"})
+	description := "description"
+	imported.Spec.ForProvider.Description = &description
+	_, err = e.Create(ctx, &imported)
+	assert.NoError(err)
+	assert.Equal(before[OutcomeImport]+1, count(OutcomeImport))
+
+	// Noop
+	_, err = e.Observe(ctx, &imported)
+	assert.NoError(err)
+	assert.Equal(before[OutcomeNoop]+1, count(OutcomeNoop))
+
+	// Update
+	changed := "changed"
+	imported.Spec.ForProvider.Description = &changed
+	_, err = e.Update(ctx, &imported)
+	assert.NoError(err)
+	assert.Equal(before[OutcomeUpdate]+1, count(OutcomeUpdate))
+
+	// Delete
+	assert.NoError(e.Delete(ctx, &imported))
+	assert.Equal(before[OutcomeDelete]+1, count(OutcomeDelete))
+
+	// Error
+	_, err = e.Update(ctx, &imported)
+	assert.Error(err)
+	assert.Equal(before[OutcomeError]+1, count(OutcomeError))
+}
+
+func TestObserveLateInitDescription(t *testing.T) {
+	assert := assert.New(t)
+
+	fake := &fakeServiceAccountClient{accounts: []serviceaccount.ServiceAccount{{Name: "name", Description: "remote description", ID: "sa-55555"}}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	// Unset description is late-initialized
+	sa := v1alpha1.ServiceAccount{}
+	sa.Name = "name"
+	sa.Status.AtProvider.ID = "sa-55555"
+	obs, err := e.Observe(context.Background(), &sa)
+	assert.NoError(err)
+	assert.True(obs.ResourceLateInitialized)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal("remote description", *sa.Spec.ForProvider.Description)
+
+	// Explicitly empty description is not late-initialized and is pushed to the remote
+	empty := ""
+	sa.Spec.ForProvider.Description = &empty
+	obs, err = e.Observe(context.Background(), &sa)
+	assert.NoError(err)
+	assert.False(obs.ResourceLateInitialized)
+	assert.False(obs.ResourceUpToDate)
+
+	_, err = e.Update(context.Background(), &sa)
+	assert.NoError(err)
+	assert.Equal("", fake.accounts[0].Description)
+
+	obs, err = e.Observe(context.Background(), &sa)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal("", *sa.Spec.ForProvider.Description)
+}
+
+func TestConnectProviderConfigNotFound(t *testing.T) {
+	assert := assert.New(t)
+
+	kube := test.NewMockClient()
+	kube.MockGet = func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+		return kerrors.NewNotFound(schema.GroupResource{Resource: "providerconfigs"}, key.Name)
+	}
+	c := &connector{
+		kube:  kube,
+		usage: resource.TrackerFn(func(context.Context, resource.Managed) error { return nil }),
+		newServiceFn: func(context.Context, []byte, clients.APICredentials, clients.Config) (interface{}, error) {
+			t.Fatal("no client must be created without ProviderConfig")
+			return nil, nil
+		},
+	}
+
+	sa := &v1alpha1.ServiceAccount{}
+	sa.SetProviderConfigReference(&xpv1.Reference{Name: "confluent-provider"})
+	_, err := c.Connect(context.Background(), sa)
+	assert.EqualError(err, `cannot get ProviderConfig: referenced ProviderConfig "confluent-provider" not found`)
+}
+
+func TestMarker(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	Marker = "[crossplane] "
+	defer func() { Marker = "" }()
+
+	fake := &fakeServiceAccountClient{accounts: []serviceaccount.ServiceAccount{
+		{Name: "terraform", Description: "managed by terraform", ID: "sa-11111"},
+		{Name: "previous", Description: "[crossplane] created by the provider", ID: "sa-22222"},
+	}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	// Created service accounts are marked, the marker is not part of the desired description
+	description := "orders"
+	created := v1alpha1.ServiceAccount{}
+	created.Name = "new"
+	created.Spec.ForProvider.Description = &description
+	_, err := e.Create(ctx, &created)
+	assert.NoError(err)
+	assert.Equal("[crossplane] orders", fake.accounts[2].Description)
+	obs, err := e.Observe(ctx, &created)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(ReasonMarkerFound, created.GetCondition(TypeProviderManaged).Reason)
+
+	// The marker is kept on update
+	changed := "payments"
+	created.Spec.ForProvider.Description = &changed
+	_, err = e.Update(ctx, &created)
+	assert.NoError(err)
+	assert.Equal("[crossplane] payments", fake.accounts[2].Description)
+
+	// Adopting a marked service account
+	marked := v1alpha1.ServiceAccount{}
+	marked.Name = "previous"
+	marked.SetAnnotations(map[string]string{"crossplane.io/external-name": "previous"})
+	_, err = e.Create(ctx, &marked)
+	assert.NoError(err)
+	assert.Equal(ReasonMarkerFound, marked.GetCondition(TypeProviderManaged).Reason)
+	obs, err = e.Observe(ctx, &marked)
+	assert.NoError(err)
+	assert.True(obs.ResourceLateInitialized)
+	assert.Equal("created by the provider", *marked.Spec.ForProvider.Description)
+
+	// Adopting an unmarked service account is reported, & does not mark it
+	unmarked := v1alpha1.ServiceAccount{}
+	unmarked.Name = "terraform"
+	unmarked.SetAnnotations(map[string]string{"crossplane.io/external-name": "terraform"})
+	_, err = e.Create(ctx, &unmarked)
+	assert.NoError(err)
+	cond := unmarked.GetCondition(TypeProviderManaged)
+	assert.Equal(corev1.ConditionFalse, cond.Status)
+	assert.Equal(ReasonMarkerMissing, cond.Reason)
+	assert.Contains(cond.Message, "sa-11111")
+
+	changed = "managed by crossplane"
+	unmarked.Spec.ForProvider.Description = &changed
+	_, err = e.Update(ctx, &unmarked)
+	assert.NoError(err)
+	assert.Equal("managed by crossplane", fake.accounts[0].Description)
+	obs, err = e.Observe(ctx, &unmarked)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal(ReasonMarkerMissing, unmarked.GetCondition(TypeProviderManaged).Reason)
+}
+
+func TestMarkedDescriptionLength(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	Marker = "[crossplane] "
+	defer func() { Marker = "" }()
+
+	fits := strings.Repeat("a", maxDescriptionLength-len(Marker))
+	cases := map[string]struct {
+		description string
+		want        string
+	}{
+		"Fits":      {description: fits, want: Marker + fits},
+		"TooLong":   {description: fits + "b", want: Marker + fits},
+		"Multibyte": {description: strings.Repeat("ø", maxDescriptionLength), want: Marker + strings.Repeat("ø", maxDescriptionLength-len(Marker))},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			fake := &fakeServiceAccountClient{}
+			e := &external{service: fake, kube: test.NewMockClient()}
+
+			description := tc.description
+			sa := v1alpha1.ServiceAccount{}
+			sa.Name = "orders"
+			sa.Spec.ForProvider.Description = &description
+			_, err := e.Create(ctx, &sa)
+			assert.NoError(err)
+			assert.Equal(tc.want, fake.accounts[0].Description)
+			assert.LessOrEqual(len([]rune(fake.accounts[0].Description)), maxDescriptionLength)
+
+			// The truncated description is not a diff
+			obs, err := e.Observe(ctx, &sa)
+			assert.NoError(err)
+			assert.True(obs.ResourceUpToDate)
+		})
+	}
+}
+
+func TestCreateRetryAfterCrash(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	// The status persisted in the cluster, the in-memory status of a crashed reconcile is lost
+	var persisted *v1alpha1.ServiceAccount
+	kube := test.NewMockClient()
+	kube.MockStatusUpdate = func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+		persisted = obj.(*v1alpha1.ServiceAccount).DeepCopy()
+		return nil
+	}
+	kube.MockUpdate = test.NewMockUpdateFn(errors.New("provider crashed"))
+
+	fake := &fakeServiceAccountClient{}
+	e := &external{service: fake, kube: kube}
+
+	sa := v1alpha1.ServiceAccount{}
+	sa.Name = "orders"
+	_, err := e.Create(ctx, &sa)
+	assert.Error(err)
+	assert.Len(fake.accounts, 1)
+	assert.Empty(persisted.Status.AtProvider.ID)
+	assert.Equal("orders", persisted.Status.AtProvider.PendingName)
+
+	// The retry adopts the service account of the pending name instead of creating another one
+	kube.MockUpdate = test.NewMockUpdateFn(nil)
+	retried := persisted.DeepCopy()
+	obs, err := e.Observe(ctx, retried)
+	assert.NoError(err)
+	assert.False(obs.ResourceExists)
+	_, err = e.Create(ctx, retried)
+	assert.NoError(err)
+	assert.Len(fake.accounts, 1)
+	assert.Equal("sa-orders", persisted.Status.AtProvider.ID)
+	assert.Empty(persisted.Status.AtProvider.PendingName)
+}
+
+type fakeAPIKeyClient struct {
+	apikey.IClient
+	created []string
+	deleted []string
+	err     error
+}
+
+func (f *fakeAPIKeyClient) APIKeyDelete(_ context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+	return nil
+}
+
+func (f *fakeAPIKeyClient) APIKeyCreate(_ context.Context, resource string, description string, owner string, environment string) (apikey.APIKey, error) {
+	if f.err != nil {
+		return apikey.APIKey{}, f.err
+	}
+	f.created = append(f.created, owner+"/"+resource)
+	return apikey.APIKey{Key: fmt.Sprintf("KEY%d", len(f.created)), Secret: "SECRET"}, nil
+}
+
+func TestAPIKeyConnectionDetails(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	keys := &fakeAPIKeyClient{err: errors.New("boom")}
+	e := &external{service: &fakeServiceAccountClient{}, apiKeyService: keys, kube: test.NewMockClient()}
+
+	sa := v1alpha1.ServiceAccount{}
+	sa.Name = "orders"
+	sa.Spec.ForProvider.APIKey = &v1alpha1.ServiceAccountAPIKey{Resource: "lkc-abc123"}
+
+	// A cluster key needs its environment, which is checked before the service account is created
+	_, err := e.Create(ctx, &sa)
+	assert.EqualError(err, fmt.Sprintf(errAPIKeyWithoutEnv, "lkc-abc123"))
+	assert.Empty(sa.Status.AtProvider.ID)
+
+	// A failure to create the key is retried by an update
+	sa.Spec.ForProvider.APIKey.Environment = "env-abc123"
+	_, err = e.Create(ctx, &sa)
+	assert.Error(err)
+	assert.Equal("sa-orders", sa.Status.AtProvider.ID)
+	obs, err := e.Observe(ctx, &sa)
+	assert.NoError(err)
+	assert.False(obs.ResourceUpToDate)
+
+	keys.err = nil
+	upd, err := e.Update(ctx, &sa)
+	assert.NoError(err)
+	assert.Equal([]byte("KEY1"), upd.ConnectionDetails[ConnectionSecretAPIKey])
+	assert.Equal([]byte("SECRET"), upd.ConnectionDetails[ConnectionSecretAPISecret])
+	assert.Equal("KEY1", sa.Status.AtProvider.APIKey)
+
+	// The key is never regenerated
+	obs, err = e.Observe(ctx, &sa)
+	assert.NoError(err)
+	assert.True(obs.ResourceUpToDate)
+	upd, err = e.Update(ctx, &sa)
+	assert.NoError(err)
+	assert.Empty(upd.ConnectionDetails)
+	assert.Equal([]string{"sa-orders/lkc-abc123"}, keys.created)
+
+	// A new service account gets its key when it is created
+	created := v1alpha1.ServiceAccount{}
+	created.Name = "payments"
+	created.Spec.ForProvider.APIKey = &v1alpha1.ServiceAccountAPIKey{Resource: "cloud"}
+	cre, err := e.Create(ctx, &created)
+	assert.NoError(err)
+	assert.Equal([]byte("KEY2"), cre.ConnectionDetails[ConnectionSecretAPIKey])
+	assert.Equal("KEY2", created.Status.AtProvider.APIKey)
+}
+
+func TestAPIKeyNotLost(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		kube        *test.MockClient
+		wantCreated int
+		wantDeleted int
+	}{
+		"LastSyncFailed": {
+			kube:        &test.MockClient{MockUpdate: test.NewMockUpdateFn(errBoom), MockStatusUpdate: test.NewMockStatusUpdateFn(nil)},
+			wantCreated: 0,
+		},
+		"StatusUpdateFailed": {
+			kube:        &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil), MockStatusUpdate: test.NewMockStatusUpdateFn(errBoom)},
+			wantCreated: 1,
+			wantDeleted: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			keys := &fakeAPIKeyClient{}
+			accounts := &fakeServiceAccountClient{accounts: []serviceaccount.ServiceAccount{{Name: "orders", ID: "sa-orders"}}}
+			e := &external{service: accounts, apiKeyService: keys, kube: tc.kube}
+
+			sa := v1alpha1.ServiceAccount{}
+			sa.Name = "orders"
+			sa.Status.AtProvider.ID = "sa-orders"
+			sa.Spec.ForProvider.APIKey = &v1alpha1.ServiceAccountAPIKey{Resource: "cloud"}
+
+			// A key is either returned or not kept, so its secret is never lost
+			_, err := e.Update(context.Background(), &sa)
+			assert.Error(err)
+			assert.Len(keys.created, tc.wantCreated)
+			assert.Len(keys.deleted, tc.wantDeleted)
+			assert.True(needsAPIKey(&sa), "the next reconcile should create a key")
+		})
+	}
+}
+
+// newServiceAccount Returns a ServiceAccount named name. A non-empty external name, ID or description is set as well
+func newServiceAccount(name string, externalName string, id string, description string) *v1alpha1.ServiceAccount {
+	cr := &v1alpha1.ServiceAccount{}
+	cr.Name = name
+	if externalName != "" {
+		cr.SetAnnotations(map[string]string{"crossplane.io/external-name": externalName})
+	}
+	cr.Status.AtProvider.ID = id
+	if description != "" {
+		cr.Spec.ForProvider.Description = &description
+	}
+	return cr
+}
+
+func TestExternalObserve(t *testing.T) {
+	existing := serviceaccount.ServiceAccount{Name: "existing", Description: "description", ID: "sa-11111"}
+
+	cases := map[string]struct {
+		accounts []serviceaccount.ServiceAccount
+		errs     map[string]error
+		cr       *v1alpha1.ServiceAccount
+		want     managed.ExternalObservation
+		wantErr  string
+	}{
+		"NeedsCreation": {
+			cr:   newServiceAccount("missing", "", "", "description"),
+			want: managed.ExternalObservation{ResourceExists: false},
+		},
+		"ExistsWithoutID": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			cr:       newServiceAccount("existing", "", "", "description"),
+			want:     managed.ExternalObservation{ResourceExists: false},
+		},
+		"NeedsUpdate": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			cr:       newServiceAccount("existing", "", "sa-11111", "changed"),
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"UpToDate": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			cr:       newServiceAccount("existing", "", "sa-11111", "description"),
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"UpToDateThroughExternalName": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			cr:       newServiceAccount("k8s-name", "existing", "sa-11111", "description"),
+			want:     managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"LookupFails": {
+			errs:    map[string]error{"ServiceAccountByName": errors.New("500: Internal Server Error")},
+			cr:      newServiceAccount("existing", "", "", "description"),
+			wantErr: "500: Internal Server Error",
+		},
+		"Unauthorized": {
+			errs:    map[string]error{"ServiceAccountByName": errors.Wrap(serviceaccount.ErrUnauthorized, "401")},
+			cr:      newServiceAccount("existing", "", "", "description"),
+			wantErr: errUnauthorized,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			e := &external{service: &fakeServiceAccountClient{accounts: tc.accounts, errs: tc.errs}, kube: test.NewMockClient()}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if tc.wantErr != "" {
+				assert.Error(err)
+				assert.Contains(err.Error(), tc.wantErr)
+				return
+			}
+
+			assert.NoError(err)
+			assert.Equal(tc.want.ResourceExists, got.ResourceExists)
+			assert.Equal(tc.want.ResourceUpToDate, got.ResourceUpToDate)
+		})
+	}
+}
+
+func TestExternalCreate(t *testing.T) {
+	existing := serviceaccount.ServiceAccount{Name: "existing", Description: "description", ID: "sa-11111"}
+
+	cases := map[string]struct {
+		accounts []serviceaccount.ServiceAccount
+		errs     map[string]error
+		cr       *v1alpha1.ServiceAccount
+		wantID   string
+		wantLen  int
+		wantErr  string
+	}{
+		"Creates": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			cr:       newServiceAccount("new", "", "", "description"),
+			wantID:   "sa-new",
+			wantLen:  2,
+		},
+		"ImportsThroughExternalName": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			cr:       newServiceAccount("k8s-name", "existing", "", "description"),
+			wantID:   "sa-11111",
+			wantLen:  1,
+		},
+		"CreatesMissingExternalName": {
+			cr:      newServiceAccount("k8s-name", "missing", "", "description"),
+			wantID:  "sa-missing",
+			wantLen: 1,
+		},
+		"ImportLookupFails": {
+			accounts: []serviceaccount.ServiceAccount{existing},
+			errs:     map[string]error{"ServiceAccountByName": errors.New("500: Internal Server Error")},
+			cr:       newServiceAccount("k8s-name", "existing", "", "description"),
+			wantErr:  "500: Internal Server Error",
+			wantLen:  1,
+		},
+		"NameTaken": {
+			errs:    map[string]error{"ServiceAccountCreate": errors.Wrap(serviceaccount.ErrAlreadyExists, "409")},
+			cr:      newServiceAccount("taken", "", "", "description"),
+			wantErr: "service account taken exists already",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			fake := &fakeServiceAccountClient{accounts: tc.accounts, errs: tc.errs}
+			e := &external{service: fake, kube: test.NewMockClient()}
+			_, err := e.Create(context.Background(), tc.cr)
+			if tc.wantErr != "" {
+				assert.Error(err)
+				assert.Contains(err.Error(), tc.wantErr)
+			} else {
+				assert.NoError(err)
+				assert.Equal(tc.wantID, tc.cr.Status.AtProvider.ID)
+				assert.Empty(tc.cr.Status.AtProvider.PendingName)
+			}
+			assert.Len(fake.accounts, tc.wantLen)
+		})
+	}
+}
+
+func TestExternalUpdate(t *testing.T) {
+	cases := map[string]struct {
+		errs            map[string]error
+		wantDescription string
+		wantErr         string
+	}{
+		"UpdatesDescription": {
+			wantDescription: "changed",
+		},
+		"UpdateFails": {
+			errs:            map[string]error{"ServiceAccountUpdate": errors.New("500: Internal Server Error")},
+			wantDescription: "description",
+			wantErr:         "500: Internal Server Error",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			fake := &fakeServiceAccountClient{
+				accounts: []serviceaccount.ServiceAccount{{Name: "existing", Description: "description", ID: "sa-11111"}},
+				errs:     tc.errs,
+			}
+			e := &external{service: fake, kube: test.NewMockClient()}
+			_, err := e.Update(context.Background(), newServiceAccount("existing", "", "sa-11111", "changed"))
+			if tc.wantErr != "" {
+				assert.EqualError(err, tc.wantErr)
+			} else {
+				assert.NoError(err)
+			}
+			assert.Equal(tc.wantDescription, fake.accounts[0].Description)
+		})
+	}
+}
+
+func TestExternalDelete(t *testing.T) {
+	cases := map[string]struct {
+		id      string
+		errs    map[string]error
+		wantLen int
+		wantErr string
+	}{
+		"Deletes": {
+			id: "sa-11111",
+		},
+		"AlreadyDeleted": {
+			id:      "sa-22222",
+			wantLen: 1,
+		},
+		"DeleteFails": {
+			id:      "sa-11111",
+			errs:    map[string]error{"ServiceAccountDelete": errors.New("500: Internal Server Error")},
+			wantLen: 1,
+			wantErr: "500: Internal Server Error",
+		},
+		"ForbiddenOnExistingAccount": {
+			id:      "sa-11111",
+			errs:    map[string]error{"ServiceAccountDelete": errors.New("403: Forbidden")},
+			wantLen: 1,
+			wantErr: "403: Forbidden",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			fake := &fakeServiceAccountClient{
+				accounts: []serviceaccount.ServiceAccount{{Name: "existing", Description: "description", ID: "sa-11111"}},
+				errs:     tc.errs,
+			}
+			e := &external{service: fake, kube: test.NewMockClient()}
+			err := e.Delete(context.Background(), newServiceAccount("existing", "", tc.id, ""))
+			if tc.wantErr != "" {
+				assert.EqualError(err, tc.wantErr)
+			} else {
+				assert.NoError(err)
+			}
+			assert.Len(fake.accounts, tc.wantLen)
+		})
+	}
+}
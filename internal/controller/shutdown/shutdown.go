@@ -0,0 +1,67 @@
+package shutdown
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GracePeriod is how long a reconcile in flight when the provider is shut down is given to finish. A reconcile that does not finish within it
+// is canceled, abandoning the Confluent command it waits on. The pod must be given longer than GracePeriod to terminate for it to take effect
+var GracePeriod = 30 * time.Second
+
+// ManagerTimeout Returns how long the manager waits for its controllers to stop. It outlasts GracePeriod so reconciles canceled at the end of
+// it can still return
+func ManagerTimeout() time.Duration {
+	return GracePeriod + 5*time.Second
+}
+
+// Reconciler wraps inner so a reconcile in flight when the provider is shut down keeps running for up to GracePeriod. Without it the context
+// of the reconcile is canceled at once, so a resource created in Confluent could not be recorded on its managed resource & would be orphaned
+func Reconciler(inner reconcile.Reconciler) reconcile.Reconciler {
+	return reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		// No reconcile is started once the provider is shutting down, the resource is reconciled again after the restart
+		if ctx.Err() != nil {
+			return reconcile.Result{}, nil
+		}
+
+		ctx, cancel := detach(ctx)
+		defer cancel()
+
+		return inner.Reconcile(ctx, req)
+	})
+}
+
+// detached carries the values of its parent context, but is only canceled GracePeriod after its parent is done
+type detached struct {
+	context.Context
+	parent context.Context
+}
+
+func (d detached) Value(key interface{}) interface{} {
+	return d.parent.Value(key)
+}
+
+// detach Returns a context with the values of parent that is canceled GracePeriod after parent is done, or when the returned cancel is called
+func detach(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	go func() {
+		select {
+		case <-parent.Done():
+		case <-ctx.Done():
+			return
+		}
+
+		t := time.NewTimer(GracePeriod)
+		defer t.Stop()
+		select {
+		case <-t.C:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return detached{Context: ctx, parent: parent}, cancel
+}
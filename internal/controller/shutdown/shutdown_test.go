@@ -0,0 +1,75 @@
+package shutdown
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type key struct{}
+
+func TestShutdownMidReconcile(t *testing.T) {
+	assert := assert.New(t)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var created, recorded bool
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		created = true
+		close(started)
+		<-release
+		// The create is recorded on the managed resource after the provider started shutting down
+		recorded = ctx.Err() == nil
+		assert.Equal("value", ctx.Value(key{}))
+		return reconcile.Result{}, ctx.Err()
+	})
+
+	ctx, stop := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	done := make(chan error)
+	go func() {
+		_, err := Reconciler(inner).Reconcile(ctx, reconcile.Request{})
+		done <- err
+	}()
+
+	<-started
+	stop()
+	close(release)
+	assert.NoError(<-done)
+	assert.True(created)
+	assert.True(recorded)
+
+	// No reconcile is started once shutting down
+	created = false
+	_, err := Reconciler(inner).Reconcile(ctx, reconcile.Request{})
+	assert.NoError(err)
+	assert.False(created)
+}
+
+func TestShutdownGracePeriodExceeded(t *testing.T) {
+	GracePeriod = 10 * time.Millisecond
+	defer func() { GracePeriod = 30 * time.Second }()
+
+	started := make(chan struct{})
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		close(started)
+		<-ctx.Done()
+		return reconcile.Result{}, ctx.Err()
+	})
+
+	ctx, stop := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := Reconciler(inner).Reconcile(ctx, reconcile.Request{})
+		done <- err
+	}()
+
+	// A reconcile still running at the end of the grace period is canceled
+	<-started
+	stopped := time.Now()
+	stop()
+	assert.Equal(t, context.Canceled, <-done)
+	assert.GreaterOrEqual(t, int64(time.Since(stopped)), int64(GracePeriod))
+}
@@ -0,0 +1,71 @@
+package startup
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Stagger is the window over which the first reconcile of each managed resource is spread after the provider starts. Every existing
+// resource is observed once within this window instead of waiting for the poll interval, without all of them hitting Confluent at once.
+// Zero (the default) reconciles every resource as soon as the controller receives it.
+var Stagger time.Duration
+
+// now is replaced in tests
+var now = time.Now
+
+// Reconciler delays the first reconcile of each resource to a stable offset within the stagger window after the provider started. Resources
+// received once the window is over, e.g. created later on, are reconciled right away
+type Reconciler struct {
+	inner   reconcile.Reconciler
+	stagger time.Duration
+	start   time.Time
+
+	mu sync.Mutex
+	// delayed are the requests waiting for their offset, they are removed again once passed to the inner reconciler
+	delayed map[reconcile.Request]bool
+}
+
+// NewReconciler is a factory method for Reconciler. The inner reconciler is returned as is when Stagger is zero
+func NewReconciler(inner reconcile.Reconciler) reconcile.Reconciler {
+	if Stagger <= 0 {
+		return inner
+	}
+	return &Reconciler{inner: inner, stagger: Stagger, start: now(), delayed: make(map[reconcile.Request]bool)}
+}
+
+// Reconcile requeues the first request for a resource until its stagger offset after start & passes all later requests to the inner reconciler
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if d := r.delay(req); d > 0 {
+		return reconcile.Result{RequeueAfter: d}, nil
+	}
+
+	return r.inner.Reconcile(ctx, req)
+}
+
+// delay Returns how long the first request for a resource still waits for its offset, or zero once it waited or the window is over
+func (r *Reconciler) delay(req reconcile.Request) time.Duration {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.delayed[req] {
+		delete(r.delayed, req)
+		return 0
+	}
+
+	d := r.start.Add(offset(req, r.stagger)).Sub(now())
+	if d > 0 {
+		r.delayed[req] = true
+	}
+	return d
+}
+
+// offset Returns a stable delay in [0, stagger) for req so restarts spread resources the same way
+func offset(req reconcile.Request, stagger time.Duration) time.Duration {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(req.Namespace + "/" + req.Name))
+	return time.Duration(h.Sum64() % uint64(stagger))
+}
@@ -0,0 +1,89 @@
+package startup
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestNewReconcilerWithoutStagger(t *testing.T) {
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		return reconcile.Result{}, nil
+	})
+
+	Stagger = 0
+	_, wrapped := NewReconciler(inner).(*Reconciler)
+	assert.False(t, wrapped, "no stagger should return the inner reconciler")
+}
+
+func TestReconcileStagger(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := 0
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		calls++
+		return reconcile.Result{}, nil
+	})
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+	defer func() { now = time.Now }()
+
+	Stagger = time.Minute
+	defer func() { Stagger = 0 }()
+	r := NewReconciler(inner)
+
+	var req reconcile.Request
+	for i := 0; ; i++ {
+		req = reconcile.Request{NamespacedName: types.NamespacedName{Name: "resource-" + string(rune('a'+i))}}
+		if offset(req, Stagger) > 0 {
+			break
+		}
+	}
+
+	// First reconcile is delayed within the stagger window
+	res, err := r.Reconcile(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal(0, calls)
+	assert.True(res.RequeueAfter > 0 && res.RequeueAfter < Stagger)
+	assert.Equal(offset(req, Stagger), res.RequeueAfter, "offset should be stable")
+
+	// Later reconciles go straight through, & the resource is forgotten once it was reconciled
+	_, err = r.Reconcile(context.Background(), req)
+	assert.NoError(err)
+	assert.Equal(1, calls)
+	assert.Empty(r.(*Reconciler).delayed)
+}
+
+func TestReconcileAfterWindow(t *testing.T) {
+	assert := assert.New(t)
+
+	calls := 0
+	inner := reconcile.Func(func(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+		calls++
+		return reconcile.Result{}, nil
+	})
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return start }
+	defer func() { now = time.Now }()
+
+	Stagger = time.Minute
+	defer func() { Stagger = 0 }()
+	r := NewReconciler(inner)
+
+	// Resources received after the window, e.g. created hours after the start, are not delayed
+	now = func() time.Time { return start.Add(time.Hour) }
+	for i := 0; i < 10; i++ {
+		req := reconcile.Request{NamespacedName: types.NamespacedName{Name: "resource-" + string(rune('a'+i))}}
+		res, err := r.Reconcile(context.Background(), req)
+		assert.NoError(err)
+		assert.Zero(res.RequeueAfter)
+	}
+	assert.Equal(10, calls)
+	assert.Empty(r.(*Reconciler).delayed)
+}
@@ -0,0 +1,66 @@
+package syncinfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Annotations used to expose the outcome of the last successful reconcile
+const (
+	AnnotationKeyLastSyncTime  = "confluent.crossplane.io/last-sync-time"
+	AnnotationKeyLastOperation = "confluent.crossplane.io/last-operation"
+)
+
+// Operation is the kind of operation performed by the last successful reconcile
+type Operation string
+
+// Operations. Observe records none, so observing a resource never writes to the API server
+const (
+	OperationCreate Operation = "create"
+	OperationUpdate Operation = "update"
+)
+
+// Interval is the minimum time between two writes of the annotations when the operation did not change
+var Interval = 10 * time.Minute
+
+var now = time.Now
+
+// SetLastSync Sets the last-sync annotations on o if the operation changed or Interval elapsed since the last write & returns true if o was changed
+func SetLastSync(o metav1.Object, op Operation) bool {
+	t := now().UTC()
+	a := o.GetAnnotations()
+
+	if a[AnnotationKeyLastOperation] == string(op) {
+		last, err := time.Parse(time.RFC3339, a[AnnotationKeyLastSyncTime])
+		if err == nil && t.Sub(last) < Interval {
+			return false
+		}
+	}
+
+	meta.AddAnnotations(o, map[string]string{
+		AnnotationKeyLastSyncTime:  t.Format(time.RFC3339),
+		AnnotationKeyLastOperation: string(op),
+	})
+
+	return true
+}
+
+// RecordLastSync Sets the last-sync annotations on mg & persists them when they changed. A copy of mg is written, so the status set in memory
+// is kept for the managed reconciler to persist
+func RecordLastSync(ctx context.Context, kube client.Client, mg resource.Managed, op Operation) error {
+	if !SetLastSync(mg, op) {
+		return nil
+	}
+
+	cp := mg.DeepCopyObject().(resource.Managed)
+	if err := kube.Update(ctx, cp); err != nil {
+		return err
+	}
+	mg.SetResourceVersion(cp.GetResourceVersion())
+	return nil
+}
@@ -0,0 +1,73 @@
+package syncinfo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+)
+
+func TestSetLastSync(t *testing.T) {
+	assert := assert.New(t)
+
+	current := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	o := &metav1.ObjectMeta{}
+
+	// First sync sets both annotations
+	assert.True(SetLastSync(o, OperationCreate))
+	assert.Equal("create", o.GetAnnotations()[AnnotationKeyLastOperation])
+	assert.Equal("2021-09-01T12:00:00Z", o.GetAnnotations()[AnnotationKeyLastSyncTime])
+
+	// Operation changed
+	current = current.Add(time.Minute)
+	assert.True(SetLastSync(o, OperationUpdate))
+	assert.Equal("update", o.GetAnnotations()[AnnotationKeyLastOperation])
+	assert.Equal("2021-09-01T12:01:00Z", o.GetAnnotations()[AnnotationKeyLastSyncTime])
+
+	// Same operation within interval is skipped
+	current = current.Add(Interval - time.Second)
+	assert.False(SetLastSync(o, OperationUpdate))
+	assert.Equal("2021-09-01T12:01:00Z", o.GetAnnotations()[AnnotationKeyLastSyncTime])
+
+	// Same operation after interval
+	current = current.Add(time.Second)
+	assert.True(SetLastSync(o, OperationUpdate))
+	assert.Equal("2021-09-01T12:11:00Z", o.GetAnnotations()[AnnotationKeyLastSyncTime])
+
+	// Unparsable time is overwritten
+	o.SetAnnotations(map[string]string{AnnotationKeyLastOperation: "update", AnnotationKeyLastSyncTime: "yesterday"})
+	assert.True(SetLastSync(o, OperationUpdate))
+	assert.Equal("2021-09-01T12:11:00Z", o.GetAnnotations()[AnnotationKeyLastSyncTime])
+}
+
+func TestRecordLastSyncKeepsStatus(t *testing.T) {
+	assert := assert.New(t)
+
+	// The API server returns the stored object, without the status set in memory
+	kube := &test.MockClient{
+		MockUpdate: func(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+			obj.(*v1alpha1.Topic).Status = v1alpha1.TopicStatus{}
+			obj.SetResourceVersion("2")
+			return nil
+		},
+	}
+
+	cr := &v1alpha1.Topic{}
+	cr.SetResourceVersion("1")
+	cr.Status.SetConditions(xpv1.Available())
+
+	assert.NoError(RecordLastSync(context.Background(), kube, cr, OperationUpdate))
+	assert.Equal("2", cr.GetResourceVersion())
+	assert.Equal("update", cr.GetAnnotations()[AnnotationKeyLastOperation])
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+}
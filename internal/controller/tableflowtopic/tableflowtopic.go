@@ -0,0 +1,373 @@
+/*
+Copyright 2020 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tableflowtopic
+
+import (
+	"context"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	apisv1alpha1 "github.com/dfds/provider-confluent/apis/v1alpha1"
+
+	"github.com/dfds/provider-confluent/internal/clients"
+	confluentClient "github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/tableflowtopic"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/provisioning"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
+)
+
+const (
+	errNotMyType                                     = "managed resource is not a TableflowTopic custom resource"
+	errTrackPCUsage                                  = "cannot track ProviderConfig usage"
+	errGetPC                                         = "cannot get ProviderConfig"
+	errGetCreds                                      = "cannot get credentials"
+	errGetCABundle                                   = "cannot get CA bundle"
+	errNewClient                                     = "cannot create new Service"
+	errAuthCredentials                               = "invalid client credentials"
+	errTopicNotResolved                              = "topic name is not set and could not be resolved from topicRef or topicSelector"
+	errExternalNameAndForProviderTopicNameDoNotMatch = "external name and topic name specified do not match"
+	errStorageImmutable                              = "cannot update storage of a tableflow topic, disable tableflow by deleting the resource and create it again"
+)
+
+// Enabled enables the TableflowTopic controller. Tableflow is not available for every cluster, so it is disabled by default
+var Enabled = false
+
+var (
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, error) { //nolint
+		credParts := strings.Split(string(clientCreds), ":")
+
+		if len(credParts) != 2 {
+			return nil, errors.New(errAuthCredentials)
+		}
+
+		cClient := confluentClient.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
+
+		if authErr != nil {
+			return nil, authErr
+		}
+
+		tfConfig := tableflowtopic.Config{
+			APICredentials: apiCreds,
+		}
+
+		return tableflowtopic.NewClient(tfConfig).(interface{}), nil
+	}
+)
+
+// Setup adds a controller that reconciles TableflowTopic managed resources. It does nothing unless Enabled is set.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
+	if !Enabled {
+		return nil
+	}
+
+	name := managed.ControllerName(v1alpha1.TableflowTopicGroupKind)
+
+	o := controller.Options{
+		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+	}
+
+	failures := retry.NewTracker()
+
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.TableflowTopicGroupVersionKind),
+		managed.WithExternalConnecter(failures.Connecter(&connector{
+			kube:         mgr.GetClient(),
+			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newServiceFn: createAndConvertClientFunc})),
+		managed.WithReferenceResolver(dependency.ResolveReferences(managed.NewAPISimpleReferenceResolver(mgr.GetClient()))),
+		managed.WithLogger(l.WithValues("controller", name)),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.TableflowTopic).Spec.ForProvider.Environment })),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
+
+	newObject := func() client.Object { return &v1alpha1.TableflowTopic{} }
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o).
+		For(&v1alpha1.TableflowTopic{}).
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.TableflowTopicList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), newObject, failures.Reconciler(provisioning.NewReconciler(mgr.GetClient(), newObject, r)))))
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube         client.Client
+	usage        resource.Tracker
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds confluentClient.APICredentials, cfg confluentClient.Config) (interface{}, error)
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.TableflowTopic)
+	if !ok {
+		return nil, errors.New(errNotMyType)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, errors.Wrap(err, errTrackPCUsage)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
+		return nil, errors.Wrap(err, errGetPC)
+	}
+
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCreds)
+	}
+
+	var apiCredentials confluentClient.APICredentials
+
+	for _, value := range pc.Spec.APICredentials {
+		if value.Identifier == v1alpha1.SchemeGroupVersion.Identifier() {
+			apiCredentials = value
+
+			break
+		}
+	}
+
+	caBundle, err := confluentClient.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := confluentClient.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewClient)
+	}
+
+	return &external{service: svc, kube: c.kube}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	// A 'client' used to connect to the external resource API. In practice this
+	// would be something like an AWS SDK client.
+	service interface{}
+	kube    client.Client
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.TableflowTopic)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMyType)
+	}
+
+	if meta.GetExternalName(cr) == "" {
+		return managed.ExternalObservation{}, nil
+	}
+
+	if meta.GetExternalName(cr) != cr.Spec.ForProvider.Topic {
+		return managed.ExternalObservation{}, errors.New(errExternalNameAndForProviderTopicNameDoNotMatch)
+	}
+
+	if cr.Status.AtProvider.Topic == "" {
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil // returning nil because we want create on not found
+	}
+
+	// Confluent
+	var client = c.service.(tableflowtopic.IClient)
+	td, err := client.TableflowTopicDescribe(ctx, cr.Status.AtProvider)
+
+	if err != nil {
+		if tableflowtopic.IsNotFound(err) {
+			return managed.ExternalObservation{
+				ResourceExists:    false,
+				ConnectionDetails: managed.ConnectionDetails{},
+			}, nil // returning nil because we want create on not found
+		}
+		return managed.ExternalObservation{
+			ResourceExists:    false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, err
+	}
+
+	// Materialization state
+	updateObservation(cr, td)
+	cr.Status.SetConditions(phaseCondition(cr.Status.AtProvider))
+	if err := provisioning.Record(ctx, c.kube, cr, cr.Status.AtProvider.Phase == phasePending); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// Diff
+	if storageChanged(cr.Spec.ForProvider, td) || !configMatches(cr.Spec.ForProvider, td) {
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  false,
+			ConnectionDetails: managed.ConnectionDetails{},
+		}, nil
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.TableflowTopic)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMyType)
+	}
+
+	if cr.Spec.ForProvider.Topic == "" {
+		return managed.ExternalCreation{}, errors.New(errTopicNotResolved)
+	}
+
+	extName := meta.GetExternalName(cr)
+	if extName != "" && extName != cr.Spec.ForProvider.Topic {
+		return managed.ExternalCreation{}, errors.New(errExternalNameAndForProviderTopicNameDoNotMatch)
+	}
+
+	cr.Status.SetConditions(xpv1.Creating())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	var client = c.service.(tableflowtopic.IClient)
+	observation := v1alpha1.TableflowTopicObservation{
+		Topic:       cr.Spec.ForProvider.Topic,
+		Cluster:     cr.Spec.ForProvider.Cluster,
+		Environment: cr.Spec.ForProvider.Environment,
+	}
+
+	// Tableflow may already be enabled for the topic, in which case it is imported
+	_, err := client.TableflowTopicDescribe(ctx, observation)
+	if err != nil {
+		if !tableflowtopic.IsNotFound(err) {
+			return managed.ExternalCreation{}, err
+		}
+
+		if err := client.TableflowTopicEnable(ctx, cr.Spec.ForProvider); err != nil {
+			return managed.ExternalCreation{}, err
+		}
+	}
+
+	meta.SetExternalName(cr, cr.Spec.ForProvider.Topic)
+	if err := c.kube.Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.Status.AtProvider.Topic = observation.Topic
+	cr.Status.AtProvider.Cluster = observation.Cluster
+	cr.Status.AtProvider.Environment = observation.Environment
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	return managed.ExternalCreation{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.TableflowTopic)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMyType)
+	}
+
+	var client = c.service.(tableflowtopic.IClient)
+
+	td, err := client.TableflowTopicDescribe(ctx, cr.Status.AtProvider)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if storageChanged(cr.Spec.ForProvider, td) {
+		return managed.ExternalUpdate{}, errors.New(errStorageImmutable)
+	}
+
+	if err := client.TableflowTopicUpdate(ctx, cr.Spec.ForProvider); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	return managed.ExternalUpdate{
+		// Optionally return any details that may be required to connect to the
+		// external resource. These will be stored as the connection secret.
+		ConnectionDetails: managed.ConnectionDetails{},
+	}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.TableflowTopic)
+	if !ok {
+		return errors.New(errNotMyType)
+	}
+
+	cr.Status.SetConditions(xpv1.Deleting())
+	if err := c.kube.Status().Update(ctx, cr); err != nil {
+		return err
+	}
+
+	var client = c.service.(tableflowtopic.IClient)
+
+	err := client.TableflowTopicDisable(ctx, cr.Status.AtProvider)
+	if err != nil && !tableflowtopic.IsNotFound(err) {
+		return err
+	}
+
+	return nil
+}
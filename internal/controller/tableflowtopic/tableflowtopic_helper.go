@@ -0,0 +1,93 @@
+package tableflowtopic
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/tableflowtopic"
+)
+
+// Tableflow materialization phases
+const (
+	phasePending   = "PENDING"
+	phaseRunning   = "RUNNING"
+	phaseSuspended = "SUSPENDED"
+	phaseFailed    = "FAILED"
+)
+
+// updateObservation Sets the observed materialization state on the TableflowTopic status and reports whether it changed
+func updateObservation(cr *v1alpha1.TableflowTopic, td tableflowtopic.DescribeResponse) bool {
+	phase := strings.ToUpper(td.Phase)
+	if td.Suspended && phase == "" {
+		phase = phaseSuspended
+	}
+
+	if cr.Status.AtProvider.Phase == phase && cr.Status.AtProvider.ErrorMessage == td.ErrorMessage {
+		return false
+	}
+
+	cr.Status.AtProvider.Phase = phase
+	cr.Status.AtProvider.ErrorMessage = td.ErrorMessage
+	return true
+}
+
+// phaseCondition Returns the Ready condition matching the observed materialization phase
+func phaseCondition(o v1alpha1.TableflowTopicObservation) xpv1.Condition {
+	return clients.PhaseCondition(o.Phase, "tableflow materialization", o.ErrorMessage)
+}
+
+// storageChanged Reports whether the storage configured in tp differs from the observed one. Storage can only be changed by disabling and enabling Tableflow again
+func storageChanged(tp v1alpha1.TableflowTopicParameters, td tableflowtopic.DescribeResponse) bool {
+	if tp.Storage.Type != "" && td.StorageType != "" && !strings.EqualFold(tp.Storage.Type, td.StorageType) {
+		return true
+	}
+	if tp.Storage.ProviderIntegration != "" && tp.Storage.ProviderIntegration != td.ProviderIntegrationID {
+		return true
+	}
+	if tp.Storage.BucketName != "" && tp.Storage.BucketName != td.BucketName {
+		return true
+	}
+	return false
+}
+
+// configMatches Reports whether the updatable configuration in tp matches the observed one. Fields left empty in tp are not managed
+func configMatches(tp v1alpha1.TableflowTopicParameters, td tableflowtopic.DescribeResponse) bool {
+	if len(tp.TableFormats) > 0 && !tableFormatsEqual(tp.TableFormats, td.TableFormats) {
+		return false
+	}
+	if tp.RetentionMs > 0 {
+		retention, err := strconv.ParseInt(td.RetentionMs, 10, 64)
+		if err != nil || retention != tp.RetentionMs {
+			return false
+		}
+	}
+	return true
+}
+
+func tableFormatsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	normalize := func(formats []string) []string {
+		n := make([]string, len(formats))
+		for i, f := range formats {
+			n[i] = strings.ToUpper(f)
+		}
+		sort.Strings(n)
+		return n
+	}
+
+	na, nb := normalize(a), normalize(b)
+	for i := range na {
+		if na[i] != nb[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,64 @@
+package tableflowtopic
+
+import (
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/tableflowtopic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/tableflowtopic"
+)
+
+func TestUpdateObservation(t *testing.T) {
+	assert := assert.New(t)
+
+	cr := v1alpha1.TableflowTopic{}
+	td := tableflowtopic.DescribeResponse{Phase: "pending"}
+
+	assert.True(updateObservation(&cr, td))
+	assert.Equal(phasePending, cr.Status.AtProvider.Phase)
+	assert.False(updateObservation(&cr, td))
+
+	td = tableflowtopic.DescribeResponse{Phase: phaseFailed, ErrorMessage: "access denied to bucket"}
+	assert.True(updateObservation(&cr, td))
+	assert.Equal("access denied to bucket", cr.Status.AtProvider.ErrorMessage)
+
+	// Suspended without a reported phase
+	assert.True(updateObservation(&cr, tableflowtopic.DescribeResponse{Suspended: true}))
+	assert.Equal(phaseSuspended, cr.Status.AtProvider.Phase)
+	assert.Empty(cr.Status.AtProvider.ErrorMessage)
+}
+
+func TestPhaseCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(phaseCondition(v1alpha1.TableflowTopicObservation{Phase: phaseRunning}).Equal(xpv1.Available()))
+	assert.True(phaseCondition(v1alpha1.TableflowTopicObservation{Phase: phasePending}).Equal(xpv1.Creating()))
+	assert.True(phaseCondition(v1alpha1.TableflowTopicObservation{}).Equal(xpv1.Creating()))
+
+	failed := phaseCondition(v1alpha1.TableflowTopicObservation{Phase: phaseFailed, ErrorMessage: "access denied to bucket"})
+	assert.Equal(xpv1.ReasonUnavailable, failed.Reason)
+	assert.Equal("tableflow materialization is failed: access denied to bucket", failed.Message)
+}
+
+func TestConfigMatches(t *testing.T) {
+	assert := assert.New(t)
+
+	td := tableflowtopic.DescribeResponse{
+		StorageType:  "MANAGED",
+		TableFormats: []string{"ICEBERG", "DELTA"},
+		RetentionMs:  "604800000",
+	}
+
+	// Unmanaged fields always match
+	assert.True(configMatches(v1alpha1.TableflowTopicParameters{}, td))
+
+	// Table formats are compared regardless of order and case
+	assert.True(configMatches(v1alpha1.TableflowTopicParameters{TableFormats: []string{"delta", "iceberg"}, RetentionMs: 604800000}, td))
+	assert.False(configMatches(v1alpha1.TableflowTopicParameters{TableFormats: []string{"ICEBERG"}}, td))
+	assert.False(configMatches(v1alpha1.TableflowTopicParameters{RetentionMs: 86400000}, td))
+
+	assert.False(storageChanged(v1alpha1.TableflowTopicParameters{Storage: v1alpha1.TableflowStorage{Type: "MANAGED"}}, td))
+	assert.True(storageChanged(v1alpha1.TableflowTopicParameters{Storage: v1alpha1.TableflowStorage{Type: "BYOS", BucketName: "bucket"}}, td))
+}
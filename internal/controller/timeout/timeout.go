@@ -0,0 +1,11 @@
+package timeout
+
+import "time"
+
+// Reconcile is the deadline of a single reconcile of a managed resource. A reconcile exceeding it is aborted & requeued instead of holding a
+// worker of its controller. One minute (the default) matches the default of crossplane-runtime
+var Reconcile = time.Minute
+
+// Upload is the deadline of downloading the artifact of a custom connector plugin & uploading it to Confluent Cloud. It is separate from
+// Reconcile, as an artifact of up to 200 MiB takes longer to transfer than a reconcile may run
+var Upload = 30 * time.Minute
@@ -28,7 +28,6 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/pkg/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -40,7 +39,15 @@ import (
 
 	"github.com/dfds/provider-confluent/internal/clients"
 	confluentClient "github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster"
 	"github.com/dfds/provider-confluent/internal/clients/topic"
+	"github.com/dfds/provider-confluent/internal/controller/providerconfig"
+	"github.com/dfds/provider-confluent/internal/controller/reconcilenow"
+	"github.com/dfds/provider-confluent/internal/controller/refresh"
+	"github.com/dfds/provider-confluent/internal/controller/retry"
+	"github.com/dfds/provider-confluent/internal/controller/startup"
+	"github.com/dfds/provider-confluent/internal/controller/syncinfo"
+	"github.com/dfds/provider-confluent/internal/controller/timeout"
 )
 
 const (
@@ -48,32 +55,36 @@ const (
 	errTrackPCUsage                                  = "cannot track ProviderConfig usage"
 	errGetPC                                         = "cannot get ProviderConfig"
 	errGetCreds                                      = "cannot get credentials"
+	errGetCABundle                                   = "cannot get CA bundle"
 	errNewClient                                     = "cannot create new Service"
 	errAuthCredentials                               = "invalid client credentials"
 	errExternalNameAndForProviderTopicNameDoNotMatch = "external name and topic name specified do not match"
 	errDestructiveUpdateNotAllowed                   = "cannot update resource. DeletionPolicy is set to Orphan, but update is destructive"
+	errUnknownConfigKey                              = "managedConfigKeys: unknown config key %s, the provider manages %s"
+	errReservedConfigKey                             = "config.settings: %s is set by config.retention"
+	errPartitionDecrease                             = "cannot decrease the partitions of topic %s from %d to %d, Kafka cannot remove partitions"
 )
 
 var (
-	createAndConvertClientFunc = func(clientCreds []byte, apiCreds clients.APICredentials) (interface{}, error) { //nolint
+	createAndConvertClientFunc = func(ctx context.Context, clientCreds []byte, apiCreds clients.APICredentials, cfg clients.Config) (interface{}, interface{}, error) { //nolint
 		credParts := strings.Split(string(clientCreds), ":")
 
 		if len(credParts) != 2 {
-			return nil, errors.New(errAuthCredentials)
+			return nil, nil, errors.New(errAuthCredentials)
 		}
 
-		cClient := confluentClient.NewClient()
-		authErr := cClient.Authenticate(credParts[0], credParts[1])
+		cClient := confluentClient.NewClient(cfg)
+		authErr := cClient.Authenticate(ctx, credParts[0], credParts[1])
 
 		if authErr != nil {
-			return nil, authErr
+			return nil, nil, authErr
 		}
 
 		srConfig := topic.Config{
 			APICredentials: apiCreds,
 		}
 
-		return topic.NewClient(srConfig).(interface{}), nil
+		return topic.NewClient(srConfig).(interface{}), kafkacluster.NewClient(kafkacluster.Config(srConfig)).(interface{}), nil
 	}
 )
 
@@ -85,21 +96,25 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 		RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
 	}
 
+	failures := retry.NewTracker()
+
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.TopicGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		managed.WithExternalConnecter(failures.Connecter(&connector{
 			kube:         mgr.GetClient(),
 			usage:        resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
-			newServiceFn: createAndConvertClientFunc}),
+			newServiceFn: createAndConvertClientFunc})),
 		managed.WithLogger(l.WithValues("controller", name)),
-		managed.WithInitializers(),
+		managed.WithTimeout(timeout.Reconcile),
+		managed.WithInitializers(providerconfig.NewEnvironmentDefaulter(mgr.GetClient(), func(mg resource.Managed) string { return mg.(*v1alpha1.Topic).Spec.ForProvider.Environment })),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(o).
 		For(&v1alpha1.Topic{}).
-		Complete(r)
+		Watches(refresh.Source(func() resource.ManagedList { return &v1alpha1.TopicList{} }), &refresh.Handler{}).
+		Complete(startup.NewReconciler(reconcilenow.NewReconciler(mgr.GetClient(), func() client.Object { return &v1alpha1.Topic{} }, failures.Reconciler(r))))
 }
 
 // A connector is expected to produce an ExternalClient when its Connect method
@@ -107,7 +122,7 @@ func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter) error {
 type connector struct {
 	kube         client.Client
 	usage        resource.Tracker
-	newServiceFn func(creds []byte, apiCreds confluentClient.APICredentials) (interface{}, error)
+	newServiceFn func(ctx context.Context, creds []byte, apiCreds confluentClient.APICredentials, cfg confluentClient.Config) (interface{}, interface{}, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -126,11 +141,11 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	}
 
 	pc := &apisv1alpha1.ProviderConfig{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+	if err := providerconfig.Get(ctx, c.kube, cr, pc); err != nil {
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	clientCredentialData, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c.kube, pc.Spec.Credentials.CommonCredentialSelectors)
+	clientCredentialData, err := providerconfig.Credentials(ctx, c.kube, mg, pc)
 	if err != nil {
 		return nil, errors.Wrap(err, errGetCreds)
 	}
@@ -145,11 +160,26 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		}
 	}
 
-	svc, err := c.newServiceFn(clientCredentialData, apiCredentials)
+	caBundle, err := confluentClient.LoadCABundle(ctx, c.kube, pc.Spec.CABundleRef)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetCABundle)
+	}
+	cfg := confluentClient.Config{
+		CABundle:       caBundle,
+		OrganizationID: pc.Spec.OrganizationID,
+		Endpoint:       pc.Spec.Endpoint,
+		Proxy:          pc.Spec.Proxy,
+	}
+
+	svc, clusterSvc, err := c.newServiceFn(ctx, clientCredentialData, apiCredentials, cfg)
 	if err != nil {
 		return nil, errors.Wrap(err, errNewClient)
 	}
 
+	if err := waitForCluster(ctx, cr, clusterSvc.(kafkacluster.IClient)); err != nil {
+		return nil, err
+	}
+
 	return &external{service: svc, kube: c.kube}, nil
 }
 
@@ -186,7 +216,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	// Confluent
 	var client = c.service.(topic.IClient)
-	ccsa, err := client.TopicDescribe(cr.Status.AtProvider)
+	ccsa, err := client.TopicDescribe(ctx, cr.Status.AtProvider)
 
 	if err != nil {
 		if err.Error() == topic.ErrUnknownTopic {
@@ -202,6 +232,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	}
 
+	// Observe-only partition assignment, only replaced when the assignment changed
+	updatePartitionObservation(cr, ccsa)
+
 	// Diff
 	requireUpdate, err := updateStrategy(cr.Spec.ForProvider, ccsa, cr.Status.AtProvider)
 	if err != nil {
@@ -220,9 +253,6 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.SetConditions(xpv1.Available())
-	if err := c.kube.Status().Update(ctx, cr); err != nil {
-		return managed.ExternalObservation{}, err
-	}
 
 	return managed.ExternalObservation{
 		ResourceExists:    true,
@@ -238,6 +268,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	fmt.Println("CREATE")
 
+	if err := validateConfigKeys(cr.Spec.ForProvider.Topic); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	cr.Status.SetConditions(xpv1.Creating())
 	if err := c.kube.Status().Update(ctx, cr); err != nil {
 		return managed.ExternalCreation{}, err
@@ -254,7 +288,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		}
 		createObj.Topic.Name = extName
 		resourceNew = false
-		_, err := client.TopicDescribe(v1alpha1.TopicObservation{Cluster: cr.Spec.ForProvider.Cluster, Environment: cr.Spec.ForProvider.Environment, Name: meta.GetExternalName(cr)})
+		_, err := client.TopicDescribe(ctx, v1alpha1.TopicObservation{Cluster: cr.Spec.ForProvider.Cluster, Environment: cr.Spec.ForProvider.Environment, Name: meta.GetExternalName(cr)})
 		if err != nil {
 			if err.Error() == topic.ErrUnknownTopic {
 				resourceNew = true
@@ -267,7 +301,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	fmt.Println("CREATE is resource new:", resourceNew)
 
 	if resourceNew {
-		err := client.TopicCreate(*createObj)
+		err := client.TopicCreate(ctx, *createObj)
 		if err != nil {
 			return managed.ExternalCreation{}, err
 		}
@@ -287,6 +321,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	conn := managed.ConnectionDetails{}
 
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationCreate); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -301,10 +339,14 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 	fmt.Println("UPDATE")
 
+	if err := validateConfigKeys(cr.Spec.ForProvider.Topic); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	var client = c.service.(topic.IClient)
 
 	// Update description
-	observed, err := client.TopicDescribe(cr.Status.AtProvider)
+	observed, err := client.TopicDescribe(ctx, cr.Status.AtProvider)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
@@ -322,13 +364,13 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			return managed.ExternalUpdate{}, errors.New(errDestructiveUpdateNotAllowed)
 		}
 
-		err := client.TopicDelete(v1alpha1.TopicParameters{Cluster: cr.Status.AtProvider.Cluster, Environment: cr.Status.AtProvider.Environment, Topic: v1alpha1.TopicConfig{Name: cr.Status.AtProvider.Name}})
+		err := client.TopicDelete(ctx, v1alpha1.TopicParameters{Cluster: cr.Status.AtProvider.Cluster, Environment: cr.Status.AtProvider.Environment, Topic: v1alpha1.TopicConfig{Name: cr.Status.AtProvider.Name}})
 
 		if err != nil {
 			return managed.ExternalUpdate{}, err
 		}
 
-		err = client.TopicCreate(cr.Spec.ForProvider)
+		err = client.TopicCreate(ctx, cr.Spec.ForProvider)
 		if err != nil {
 			return managed.ExternalUpdate{}, err
 		}
@@ -339,10 +381,23 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			return managed.ExternalUpdate{}, err
 		}
 	} else {
-		err := client.TopicUpdate(cr.Spec.ForProvider)
-		if err != nil {
-			return managed.ExternalUpdate{}, err
+		if requireUpdate.PartitionDecrease {
+			return managed.ExternalUpdate{}, errors.Errorf(errPartitionDecrease, cr.Spec.ForProvider.Topic.Name, requireUpdate.Partitions, cr.Spec.ForProvider.Topic.Partitions)
 		}
+		if !requireUpdate.ConfigMatch {
+			if err := client.TopicUpdate(ctx, cr.Spec.ForProvider); err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+		}
+		if !requireUpdate.PartitionsMatch {
+			if err := client.TopicUpdatePartitions(ctx, cr.Spec.ForProvider); err != nil {
+				return managed.ExternalUpdate{}, err
+			}
+		}
+	}
+
+	if err := syncinfo.RecordLastSync(ctx, c.kube, cr, syncinfo.OperationUpdate); err != nil {
+		return managed.ExternalUpdate{}, err
 	}
 
 	return managed.ExternalUpdate{
@@ -366,7 +421,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) error {
 
 	var client = c.service.(topic.IClient)
 
-	err := client.TopicDelete(cr.Spec.ForProvider)
+	err := client.TopicDelete(ctx, cr.Spec.ForProvider)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,104 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster"
+	"github.com/dfds/provider-confluent/internal/clients/topic"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+)
+
+// observePartitions Converts the partitions of a TopicDescribe response to PartitionObservations ordered by partition
+func observePartitions(td topic.DescribeResponse) []v1alpha1.PartitionObservation {
+	if len(td.Partitions) == 0 {
+		return nil
+	}
+
+	partitions := make([]v1alpha1.PartitionObservation, 0, len(td.Partitions))
+	for _, p := range td.Partitions {
+		partitions = append(partitions, v1alpha1.PartitionObservation{
+			Partition: p.Partition,
+			Leader:    p.Leader,
+			Replicas:  append([]int(nil), p.Replicas...),
+			ISR:       append([]int(nil), p.ISR...),
+		})
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Partition < partitions[j].Partition
+	})
+
+	return partitions
+}
+
+// updatePartitionObservation Sets the observed partition assignment on the Topic status and reports whether it changed
+func updatePartitionObservation(cr *v1alpha1.Topic, td topic.DescribeResponse) bool {
+	partitions := observePartitions(td)
+	if reflect.DeepEqual(cr.Status.AtProvider.Partitions, partitions) {
+		return false
+	}
+
+	cr.Status.AtProvider.Partitions = partitions
+	return true
+}
+
+// waitForCluster Blocks the topic until its cluster is up, so topics declared alongside a cluster that is still provisioning don't fail every
+// reconcile. The cluster is only looked up until the topic became ready, clusters don't go back to provisioning
+func waitForCluster(ctx context.Context, cr *v1alpha1.Topic, client kafkacluster.IClient) error {
+	if meta.WasDeleted(cr) || cr.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue {
+		return nil
+	}
+
+	id := cr.Spec.ForProvider.Cluster
+	c, err := client.ClusterDescribe(ctx, id, cr.Spec.ForProvider.Environment)
+	switch {
+	case kafkacluster.IsNotFound(err):
+		return dependency.Wait(cr, fmt.Sprintf("cluster %s (not found)", id))
+	case err != nil:
+		return err
+	case clients.NormalizePhase(c.Status) != clients.PhaseReady:
+		status := strings.ToLower(c.Status)
+		if status == "" {
+			status = strings.ToLower(string(clients.PhaseProvisioning))
+		}
+		return dependency.Wait(cr, fmt.Sprintf("cluster %s (%s)", id, status))
+	}
+
+	return dependency.Wait(cr)
+}
+
+// validateConfigKeys Checks that the provider knows every config key it is asked to manage, so a misspelled key isn't silently left alone,
+// & that the settings don't set a key that has a field of its own
+func validateConfigKeys(tc v1alpha1.TopicConfig) error {
+	for _, k := range tc.ManagedConfigKeys {
+		if !contains(v1alpha1.ConfigKeys, k) {
+			return errors.Errorf(errUnknownConfigKey, k, strings.Join(v1alpha1.ConfigKeys, ", "))
+		}
+	}
+	for k := range tc.Config.Settings {
+		if contains(v1alpha1.ConfigKeys, k) {
+			return errors.Errorf(errReservedConfigKey, k)
+		}
+	}
+	return nil
+}
+
+func contains(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
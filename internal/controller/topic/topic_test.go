@@ -0,0 +1,199 @@
+package topic
+
+import (
+	"context"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/dfds/provider-confluent/apis/topic/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/kafkacluster"
+	"github.com/dfds/provider-confluent/internal/clients/topic"
+	"github.com/dfds/provider-confluent/internal/clients/topic/commands"
+	"github.com/dfds/provider-confluent/internal/controller/dependency"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUpdatePartitionObservation(t *testing.T) {
+	assert := assert.New(t)
+
+	cr := v1alpha1.Topic{}
+	td := topic.DescribeResponse{
+		TopicName: "topic",
+		Partitions: []topic.PartitionResponse{
+			{Partition: 1, Leader: 2, Replicas: []int{2, 0, 1}, ISR: []int{2, 0, 1}},
+			{Partition: 0, Leader: 1, Replicas: []int{1, 2, 0}, ISR: []int{1, 2}},
+		},
+	}
+
+	// First observation sets the assignment ordered by partition
+	assert.True(updatePartitionObservation(&cr, td))
+	assert.Len(cr.Status.AtProvider.Partitions, 2)
+	assert.Equal(0, cr.Status.AtProvider.Partitions[0].Partition)
+	assert.Equal(1, cr.Status.AtProvider.Partitions[0].Leader)
+	assert.Equal([]int{1, 2}, cr.Status.AtProvider.Partitions[0].ISR)
+
+	// Unchanged assignment does not report a change
+	assert.False(updatePartitionObservation(&cr, td))
+
+	// Leader moves
+	td.Partitions[1].Leader = 2
+	assert.True(updatePartitionObservation(&cr, td))
+	assert.Equal(2, cr.Status.AtProvider.Partitions[0].Leader)
+
+	// No partitions in response
+	assert.True(updatePartitionObservation(&cr, topic.DescribeResponse{}))
+	assert.Nil(cr.Status.AtProvider.Partitions)
+	assert.False(updatePartitionObservation(&cr, topic.DescribeResponse{}))
+}
+
+type fakeClusters struct {
+	kafkacluster.IClient
+	status    string
+	err       error
+	described int
+}
+
+func (f *fakeClusters) ClusterDescribe(_ context.Context, id string, environment string) (kafkacluster.Cluster, error) {
+	f.described++
+	return kafkacluster.Cluster{ID: id, Status: f.status}, f.err
+}
+
+func TestWaitForCluster(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	cr := &v1alpha1.Topic{Spec: v1alpha1.TopicSpec{ForProvider: v1alpha1.TopicParameters{Cluster: "lkc-1", Environment: "env-1"}}}
+
+	// The topic is deferred while the cluster is provisioning
+	clusters := &fakeClusters{status: "PROVISIONING"}
+	err := waitForCluster(ctx, cr, clusters)
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "cluster lkc-1 (provisioning)")
+	}
+	assert.Equal(corev1.ConditionTrue, cr.GetCondition(dependency.TypeBlocked).Status)
+
+	clusters = &fakeClusters{err: kafkacluster.ErrNotFound}
+	assert.Error(waitForCluster(ctx, cr, clusters))
+	assert.Contains(cr.GetCondition(dependency.TypeBlocked).Message, "cluster lkc-1 (not found)")
+
+	clusters = &fakeClusters{err: errors.New("boom")}
+	assert.EqualError(waitForCluster(ctx, cr, clusters), "boom")
+
+	// A cluster that is up unblocks the topic
+	clusters = &fakeClusters{status: "UP"}
+	assert.NoError(waitForCluster(ctx, cr, clusters))
+	assert.Equal(corev1.ConditionFalse, cr.GetCondition(dependency.TypeBlocked).Status)
+
+	// The cluster is not looked up once the topic is ready, nor when it is deleted
+	cr.SetConditions(xpv1.Available())
+	assert.NoError(waitForCluster(ctx, cr, clusters))
+	assert.Equal(1, clusters.described)
+
+	deleted := &v1alpha1.Topic{}
+	now := metav1.Now()
+	deleted.SetDeletionTimestamp(&now)
+	assert.NoError(waitForCluster(ctx, deleted, &fakeClusters{status: "PROVISIONING"}))
+}
+
+func TestManagedConfigKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	tp := v1alpha1.TopicParameters{Cluster: "lkc-1", Environment: "env-1", Topic: v1alpha1.TopicConfig{Name: "orders", Partitions: 1, Config: v1alpha1.Config{Retention: 604800000}}}
+	to := v1alpha1.TopicObservation{Cluster: "lkc-1", Environment: "env-1", Name: "orders"}
+	td := topic.DescribeResponse{TopicName: "orders", Config: map[string]string{"num.partitions": "1", "retention.ms": "259200000"}}
+
+	// By default every key of the config is managed, so the drifted retention is updated
+	compare, err := updateStrategy(tp, td, to)
+	assert.NoError(err)
+	assert.False(compare.ConfigMatch)
+	assert.Contains(commands.NewTopicUpdateCommand(tp).Args, "--config")
+
+	// Another tool owns the retention, its drift is left alone
+	tp.Topic.ManagedConfigKeys = []string{}
+	compare, err = updateStrategy(tp, td, to)
+	assert.NoError(err)
+	assert.True(compare.ConfigMatch)
+	assert.NotContains(commands.NewTopicCreateCommand(tp).Args, "--config")
+	assert.NotContains(commands.NewTopicUpdateCommand(tp).Args, "--config")
+
+	tp.Topic.ManagedConfigKeys = []string{v1alpha1.ConfigKeyRetention}
+	compare, err = updateStrategy(tp, td, to)
+	assert.NoError(err)
+	assert.False(compare.ConfigMatch)
+
+	assert.NoError(validateConfigKeys(tp.Topic))
+	tp.Topic.ManagedConfigKeys = []string{"retention.bytes"}
+	assert.Error(validateConfigKeys(tp.Topic))
+}
+
+type fakeTopics struct {
+	topic.IClient
+	described topic.DescribeResponse
+	updated   []string
+}
+
+func (f *fakeTopics) TopicDescribe(_ context.Context, to v1alpha1.TopicObservation) (topic.DescribeResponse, error) {
+	return f.described, nil
+}
+
+func (f *fakeTopics) TopicUpdate(_ context.Context, tp v1alpha1.TopicParameters) error {
+	f.updated = append(f.updated, "config")
+	return nil
+}
+
+func (f *fakeTopics) TopicUpdatePartitions(_ context.Context, tp v1alpha1.TopicParameters) error {
+	f.updated = append(f.updated, "partitions")
+	return nil
+}
+
+func TestSettingsAndPartitions(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	fake := &fakeTopics{described: topic.DescribeResponse{TopicName: "orders", Config: map[string]string{
+		"num.partitions": "3",
+		"retention.ms":   "604800000",
+		"cleanup.policy": "delete",
+		"segment.ms":     "604800000",
+	}}}
+	e := &external{service: fake, kube: test.NewMockClient()}
+
+	cr := &v1alpha1.Topic{}
+	cr.Spec.ForProvider = v1alpha1.TopicParameters{Cluster: "lkc-1", Environment: "env-1", Topic: v1alpha1.TopicConfig{Name: "orders", Partitions: 3, Config: v1alpha1.Config{Retention: 604800000}}}
+	cr.Status.AtProvider = v1alpha1.TopicObservation{Cluster: "lkc-1", Environment: "env-1", Name: "orders"}
+
+	// Keys Confluent defaults are ignored unless they are in the settings
+	compare, err := updateStrategy(cr.Spec.ForProvider, fake.described, cr.Status.AtProvider)
+	assert.NoError(err)
+	assert.True(compare.ConfigMatch)
+
+	cr.Spec.ForProvider.Topic.Config.Settings = map[string]string{"cleanup.policy": "compact"}
+	compare, err = updateStrategy(cr.Spec.ForProvider, fake.described, cr.Status.AtProvider)
+	assert.NoError(err)
+	assert.False(compare.ConfigMatch)
+	assert.Contains(commands.NewTopicUpdateCommand(cr.Spec.ForProvider).Args, `"retention.ms=604800000","cleanup.policy=compact"`)
+
+	// More partitions are added in place, together with the changed settings
+	cr.Spec.ForProvider.Topic.Partitions = 6
+	compare, err = updateStrategy(cr.Spec.ForProvider, fake.described, cr.Status.AtProvider)
+	assert.NoError(err)
+	assert.False(compare.IsDestructive())
+	_, err = e.Update(ctx, cr)
+	assert.NoError(err)
+	assert.Equal([]string{"config", "partitions"}, fake.updated)
+
+	// Partitions can't be removed
+	fake.updated = nil
+	cr.Spec.ForProvider.Topic.Partitions = 1
+	_, err = e.Update(ctx, cr)
+	assert.EqualError(err, "cannot decrease the partitions of topic orders from 3 to 1, Kafka cannot remove partitions")
+	assert.Empty(fake.updated)
+
+	// retention.ms has a field of its own
+	cr.Spec.ForProvider.Topic.Config.Settings = map[string]string{"retention.ms": "1"}
+	assert.Error(validateConfigKeys(cr.Spec.ForProvider.Topic))
+}
@@ -8,13 +8,19 @@ import (
 	"github.com/dfds/provider-confluent/internal/clients/topic"
 )
 
+// configKeyPartitions is the config key Confluent reports the partitions of a topic as
+const configKeyPartitions = "num.partitions"
+
 // Compare helper struct
 type Compare struct {
 	TopicNamesMatch  bool
 	ClusterMatch     bool
 	EnvironmentMatch bool
 	PartitionsMatch  bool
-	// PartitionDecrease bool
+	// PartitionDecrease is set when the topic has more partitions than desired, which Kafka cannot remove
+	PartitionDecrease bool
+	// Partitions is the observed number of partitions of the topic
+	Partitions  int
 	ConfigMatch bool
 }
 
@@ -33,24 +39,23 @@ func updateStrategy(tp v1alpha1.TopicParameters, td topic.DescribeResponse, to v
 		compare.EnvironmentMatch = true
 	}
 
-	if strconv.FormatInt(tp.Topic.Config.Retention, 10) == td.Config.RetentionMs {
-		compare.ConfigMatch = true
+	// Config keys the provider doesn't manage are owned by other tools & always match. Of the other keys Confluent reports, only the ones
+	// in the settings are compared, the rest keep their defaults
+	compare.ConfigMatch = !tp.Topic.ManagesConfigKey(v1alpha1.ConfigKeyRetention) || strconv.FormatInt(tp.Topic.Config.Retention, 10) == td.Config[v1alpha1.ConfigKeyRetention]
+	for k, v := range tp.Topic.Config.Settings {
+		if td.Config[k] != v {
+			compare.ConfigMatch = false
+		}
 	}
 
-	numPartitions, err := strconv.Atoi(td.Config.NumPartitions)
+	numPartitions, err := strconv.Atoi(td.Config[configKeyPartitions])
 	if err != nil {
 		return compare, err
 	}
 
-	if tp.Topic.Partitions == numPartitions {
-		compare.PartitionsMatch = true
-
-	} else {
-		// if tp.Topic.Partitions < numPartitions {
-		// 	compare.PartitionDecrease = true
-		// }
-		compare.PartitionsMatch = false
-	}
+	compare.Partitions = numPartitions
+	compare.PartitionsMatch = tp.Topic.Partitions == numPartitions
+	compare.PartitionDecrease = tp.Topic.Partitions < numPartitions
 
 	return compare, nil
 }
@@ -70,10 +75,6 @@ func (tc *Compare) IsDestructive() bool {
 		isDestructive = true
 	}
 
-	if !tc.PartitionsMatch {
-		isDestructive = true
-	}
-
 	return isDestructive
 }
 
@@ -0,0 +1,160 @@
+package usagereport
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/dfds/provider-confluent/apis/usagereport/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/billing"
+)
+
+// Defaults of the UsageReport parameters, applied when the CRD defaults were not
+const (
+	defaultDays           = 30
+	defaultRefreshMinutes = 360
+	minRefreshMinutes     = 60
+)
+
+const dateLayout = "2006-01-02"
+
+var now = time.Now
+
+// period Returns the first reported day & the day after the last reported day at t. Days are in UTC like the costs of the billing API
+func period(fp v1alpha1.UsageReportParameters, t time.Time) (string, string) {
+	days := fp.Days
+	if days <= 0 {
+		days = defaultDays
+	}
+
+	y, m, d := t.UTC().Date()
+	end := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+
+	return end.AddDate(0, 0, -days).Format(dateLayout), end.Format(dateLayout)
+}
+
+// refreshInterval Returns the minimum time between two requests of the costs of fp, which is never below minRefreshMinutes
+func refreshInterval(fp v1alpha1.UsageReportParameters) time.Duration {
+	minutes := fp.RefreshMinutes
+	if minutes <= 0 {
+		minutes = defaultRefreshMinutes
+	}
+	if minutes < minRefreshMinutes {
+		minutes = minRefreshMinutes
+	}
+
+	return time.Duration(minutes) * time.Minute
+}
+
+// refreshDue Checks if the reported usage must be requested again at t, because it was never requested, the reported period or
+// environment changed or the refresh interval elapsed
+func refreshDue(fp v1alpha1.UsageReportParameters, o v1alpha1.UsageReportObservation, start string, end string, t time.Time) bool {
+	if o.Environment != fp.Environment || o.StartDate != start || o.EndDate != end {
+		return true
+	}
+
+	last, err := time.Parse(time.RFC3339, o.LastRefreshTime)
+	return err != nil || t.Sub(last) >= refreshInterval(fp)
+}
+
+// observation Returns the usage of environment, or of the whole organization when it is empty, summed up per product line. Lines are
+// sorted by product, line type & unit, so unchanged usage yields an unchanged status
+func observation(environment string, costs []billing.Cost, start string, end string, fetched time.Time) v1alpha1.UsageReportObservation {
+	type line struct {
+		quantity float64
+		amount   float64
+	}
+
+	lines := map[v1alpha1.UsageLine]*line{}
+	var total float64
+	for _, c := range costs {
+		if environment != "" && c.EnvironmentID != environment {
+			continue
+		}
+
+		k := v1alpha1.UsageLine{Product: c.Product, LineType: c.LineType, Unit: c.Unit}
+		if lines[k] == nil {
+			lines[k] = &line{}
+		}
+		lines[k].quantity += c.Quantity
+		lines[k].amount += c.Amount
+		total += c.Amount
+	}
+
+	o := v1alpha1.UsageReportObservation{
+		Environment:     environment,
+		StartDate:       start,
+		EndDate:         end,
+		LastRefreshTime: fetched.UTC().Format(time.RFC3339),
+		TotalAmount:     formatAmount(total),
+	}
+	for k, l := range lines {
+		k.Quantity = strconv.FormatFloat(math.Round(l.quantity*10000)/10000, 'f', -1, 64)
+		k.Amount = formatAmount(l.amount)
+		o.Usage = append(o.Usage, k)
+	}
+
+	sort.Slice(o.Usage, func(i, j int) bool {
+		if o.Usage[i].Product != o.Usage[j].Product {
+			return o.Usage[i].Product < o.Usage[j].Product
+		}
+		if o.Usage[i].LineType != o.Usage[j].LineType {
+			return o.Usage[i].LineType < o.Usage[j].LineType
+		}
+		return o.Usage[i].Unit < o.Usage[j].Unit
+	})
+
+	return o
+}
+
+func formatAmount(amount float64) string {
+	return fmt.Sprintf("%.2f", amount)
+}
+
+// costCache holds the last costs of each period, so usage reports of different environments over the same period share a single
+// request to the billing API
+type costCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedCosts
+}
+
+type cachedCosts struct {
+	end     string
+	costs   []billing.Cost
+	fetched time.Time
+}
+
+func newCostCache() *costCache {
+	return &costCache{entries: map[string]cachedCosts{}}
+}
+
+// list Returns the costs from start to end & when they were requested. They are only requested from the billing API when the cached costs
+// are older than maxAge at t
+func (c *costCache) list(ctx context.Context, client billing.IClient, start string, end string, maxAge time.Duration, t time.Time) ([]billing.Cost, time.Time, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := start + "/" + end
+	if e, ok := c.entries[key]; ok && t.Sub(e.fetched) < maxAge {
+		return e.costs, e.fetched, nil
+	}
+
+	costs, err := client.CostList(ctx, start, end)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	// Costs of periods ending before today are never requested again
+	for k, e := range c.entries {
+		if e.end != end {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = cachedCosts{end: end, costs: costs, fetched: t}
+
+	return costs, t, nil
+}
@@ -0,0 +1,133 @@
+package usagereport
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/dfds/provider-confluent/apis/usagereport/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/billing"
+)
+
+func TestPeriod(t *testing.T) {
+	assert := assert.New(t)
+
+	at := time.Date(2021, 9, 1, 23, 30, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	start, end := period(v1alpha1.UsageReportParameters{Days: 7}, at)
+	assert.Equal("2021-08-25", start)
+	assert.Equal("2021-09-01", end)
+
+	start, _ = period(v1alpha1.UsageReportParameters{}, at)
+	assert.Equal("2021-08-02", start)
+}
+
+func TestRefreshDue(t *testing.T) {
+	assert := assert.New(t)
+
+	at := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	fp := v1alpha1.UsageReportParameters{Environment: "env-12345", RefreshMinutes: 120}
+	o := v1alpha1.UsageReportObservation{Environment: "env-12345", StartDate: "2021-08-02", EndDate: "2021-09-01", LastRefreshTime: "2021-09-01T10:30:00Z"}
+
+	assert.False(refreshDue(fp, o, "2021-08-02", "2021-09-01", at))
+	assert.True(refreshDue(fp, o, "2021-08-03", "2021-09-02", at))
+	assert.True(refreshDue(fp, o, "2021-08-02", "2021-09-01", at.Add(30*time.Minute)))
+	assert.True(refreshDue(fp, v1alpha1.UsageReportObservation{}, "2021-08-02", "2021-09-01", at))
+
+	fp.Environment = ""
+	assert.True(refreshDue(fp, o, "2021-08-02", "2021-09-01", at))
+
+	// The refresh interval is never shorter than an hour
+	fp = v1alpha1.UsageReportParameters{Environment: "env-12345", RefreshMinutes: 1}
+	assert.False(refreshDue(fp, o, "2021-08-02", "2021-09-01", at.Add(-time.Hour)))
+}
+
+func TestObservation(t *testing.T) {
+	assert := assert.New(t)
+
+	costs := []billing.Cost{
+		{EnvironmentID: "env-12345", Product: "KAFKA", LineType: "KAFKA_STORAGE", Unit: "GB-hour", Quantity: 0.1, Amount: 0.25},
+		{EnvironmentID: "env-12345", Product: "KAFKA", LineType: "KAFKA_STORAGE", Unit: "GB-hour", Quantity: 0.2, Amount: 0.5},
+		{EnvironmentID: "env-12345", Product: "KAFKA", LineType: "KAFKA_NETWORK_WRITE", Unit: "GB", Quantity: 12, Amount: 0.6},
+		{EnvironmentID: "env-67890", Product: "CONNECT", LineType: "CONNECT_CAPACITY", Unit: "Task-hour", Quantity: 24, Amount: 4},
+	}
+	fetched := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+
+	o := observation("env-12345", costs, "2021-08-02", "2021-09-01", fetched)
+	assert.Equal("env-12345", o.Environment)
+	assert.Equal("2021-09-01T12:00:00Z", o.LastRefreshTime)
+	assert.Equal("1.35", o.TotalAmount)
+	assert.Equal([]v1alpha1.UsageLine{
+		{Product: "KAFKA", LineType: "KAFKA_NETWORK_WRITE", Unit: "GB", Quantity: "12", Amount: "0.60"},
+		{Product: "KAFKA", LineType: "KAFKA_STORAGE", Unit: "GB-hour", Quantity: "0.3", Amount: "0.75"},
+	}, o.Usage)
+
+	// Without an environment the usage of the whole organization is reported
+	o = observation("", costs, "2021-08-02", "2021-09-01", fetched)
+	assert.Equal("5.35", o.TotalAmount)
+	assert.Len(o.Usage, 3)
+	assert.Equal("CONNECT", o.Usage[0].Product)
+}
+
+type fakeBillingClient struct {
+	costs []billing.Cost
+	calls int
+}
+
+func (f *fakeBillingClient) CostList(_ context.Context, startDate string, endDate string) ([]billing.Cost, error) {
+	f.calls++
+	return f.costs, nil
+}
+
+func TestObserveRateLimited(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	current := time.Date(2021, 9, 1, 12, 0, 0, 0, time.UTC)
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	fake := &fakeBillingClient{costs: []billing.Cost{
+		{EnvironmentID: "env-12345", Product: "KAFKA", LineType: "KAFKA_STORAGE", Unit: "GB-hour", Quantity: 10, Amount: 1},
+		{EnvironmentID: "env-67890", Product: "KAFKA", LineType: "KAFKA_STORAGE", Unit: "GB-hour", Quantity: 20, Amount: 2},
+	}}
+	e := &external{service: fake, kube: test.NewMockClient(), costs: newCostCache()}
+
+	cr := &v1alpha1.UsageReport{}
+	cr.Spec.ForProvider = v1alpha1.UsageReportParameters{Environment: "env-12345", Days: 30, RefreshMinutes: 360}
+
+	obs, err := e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.True(obs.ResourceExists)
+	assert.True(obs.ResourceUpToDate)
+	assert.Equal("1.00", cr.Status.AtProvider.TotalAmount)
+	assert.True(cr.GetCondition(xpv1.TypeReady).Equal(xpv1.Available()))
+	assert.Equal(1, fake.calls)
+
+	// Observes within the refresh interval keep the reported usage
+	current = current.Add(time.Hour)
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(1, fake.calls)
+
+	// Reports of other environments over the same period share the request
+	other := &v1alpha1.UsageReport{}
+	other.Spec.ForProvider = v1alpha1.UsageReportParameters{Environment: "env-67890", Days: 30, RefreshMinutes: 360}
+	_, err = e.Observe(ctx, other)
+	assert.NoError(err)
+	assert.Equal("2.00", other.Status.AtProvider.TotalAmount)
+	assert.Equal("2021-09-01T12:00:00Z", other.Status.AtProvider.LastRefreshTime)
+	assert.Equal(1, fake.calls)
+
+	current = current.Add(6 * time.Hour)
+	_, err = e.Observe(ctx, cr)
+	assert.NoError(err)
+	assert.Equal(2, fake.calls)
+
+	_, err = e.Create(ctx, cr)
+	assert.EqualError(err, errObserveOnly)
+}
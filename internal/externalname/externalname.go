@@ -0,0 +1,131 @@
+package externalname
+
+import (
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+
+	apikeyapi "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+const (
+	// Separator joins the parts of a composite external name. Confluent IDs and topic names cannot contain it
+	Separator = "/"
+
+	errMalformedTopic = "malformed topic external name, expected <environment>/<cluster>/<topic>"
+	errMalformedACL   = "malformed acl external name, expected <environment>/<cluster>/<principal>/<permission>/<operation>/<resourceType>/<patternType>/<resourceName>"
+	errEmptyPart      = "external name has an empty part"
+	errChanged        = "external name was changed from %s to %s, restore it or recreate the managed resource to manage another resource"
+)
+
+// Get Returns the external name of a k8s object if it has one attached, otherwise fallback. The bool reports whether an external name was attached
+func Get(o resource.Object, fallback string) (string, bool) {
+	extName := meta.GetExternalName(o)
+	if extName != "" {
+		return extName, true
+	}
+	return fallback, false
+}
+
+// CheckID Returns an error when the external name of o no longer is id, the ID of the resource o was last observed to manage. An edited
+// external name would otherwise silently adopt another resource & abandon the managed one. An empty id, i.e. a resource that was never
+// observed, is not checked so it can still be imported
+func CheckID(o resource.Object, id string) error {
+	if extName := meta.GetExternalName(o); id != "" && extName != id {
+		return errors.Errorf(errChanged, id, extName)
+	}
+	return nil
+}
+
+// ServiceAccount Returns the external name of a ServiceAccount, which is the name of the service account. Falls back to the name of the k8s object
+func ServiceAccount(sa *saapi.ServiceAccount) (string, bool) {
+	return Get(sa, sa.Name)
+}
+
+// APIKey Returns the external name of an APIKey, which is the key. Falls back to the key recorded in the status
+func APIKey(ak *apikeyapi.APIKey) (string, bool) {
+	return Get(ak, ak.Status.AtProvider.Key)
+}
+
+// Topic is the external name of a topic, which is only unique within its cluster
+type Topic struct {
+	Environment string
+	Cluster     string
+	Name        string
+}
+
+// EncodeTopic Encodes a Topic as <environment>/<cluster>/<topic>
+func EncodeTopic(t Topic) string {
+	return strings.Join([]string{t.Environment, t.Cluster, t.Name}, Separator)
+}
+
+// DecodeTopic Decodes an external name encoded by EncodeTopic. A plain topic name decodes to a Topic without environment & cluster, so external names set before they were encoded keep working
+func DecodeTopic(s string) (Topic, error) {
+	if !strings.Contains(s, Separator) {
+		if s == "" {
+			return Topic{}, errors.New(errEmptyPart)
+		}
+		return Topic{Name: s}, nil
+	}
+
+	parts := strings.Split(s, Separator)
+	if len(parts) != 3 {
+		return Topic{}, errors.New(errMalformedTopic)
+	}
+	if err := nonEmpty(parts); err != nil {
+		return Topic{}, err
+	}
+
+	return Topic{Environment: parts[0], Cluster: parts[1], Name: parts[2]}, nil
+}
+
+// ACL is the external name of a single ACL binding. Kafka has no ID for bindings, so all of its fields make up the name
+type ACL struct {
+	Environment  string
+	Cluster      string
+	Principal    string
+	Permission   string
+	Operation    string
+	ResourceType string
+	PatternType  string
+	ResourceName string
+}
+
+// EncodeACL Encodes an ACL as <environment>/<cluster>/<principal>/<permission>/<operation>/<resourceType>/<patternType>/<resourceName>. The resource name goes last, as consumer group names may contain the separator
+func EncodeACL(a ACL) string {
+	return strings.Join([]string{a.Environment, a.Cluster, a.Principal, a.Permission, a.Operation, a.ResourceType, a.PatternType, a.ResourceName}, Separator)
+}
+
+// DecodeACL Decodes an external name encoded by EncodeACL
+func DecodeACL(s string) (ACL, error) {
+	parts := strings.SplitN(s, Separator, 8)
+	if len(parts) != 8 {
+		return ACL{}, errors.New(errMalformedACL)
+	}
+	if err := nonEmpty(parts); err != nil {
+		return ACL{}, err
+	}
+
+	return ACL{
+		Environment:  parts[0],
+		Cluster:      parts[1],
+		Principal:    parts[2],
+		Permission:   parts[3],
+		Operation:    parts[4],
+		ResourceType: parts[5],
+		PatternType:  parts[6],
+		ResourceName: parts[7],
+	}, nil
+}
+
+func nonEmpty(parts []string) error {
+	for _, p := range parts {
+		if p == "" {
+			return errors.New(errEmptyPart)
+		}
+	}
+	return nil
+}
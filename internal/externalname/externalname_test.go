@@ -0,0 +1,116 @@
+package externalname
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/stretchr/testify/assert"
+
+	apikeyapi "github.com/dfds/provider-confluent/apis/apikey/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+func TestServiceAccount(t *testing.T) {
+	assert := assert.New(t)
+
+	// No external name
+	sa := saapi.ServiceAccount{}
+	sa.Name = "name"
+	name, exists := ServiceAccount(&sa)
+	assert.Equal(sa.Name, name)
+	assert.False(exists)
+
+	// With external name
+	extName := make(map[string]string)
+	extName["crossplane.io/external-name"] = "extname"
+	sa.SetAnnotations(extName)
+	name, exists = ServiceAccount(&sa)
+	assert.NotEqual(sa.Name, name, "external name not used")
+	assert.Equal("extname", name, "external name not used")
+	assert.True(exists)
+}
+
+func TestAPIKey(t *testing.T) {
+	assert := assert.New(t)
+
+	// No external name
+	ak := apikeyapi.APIKey{}
+	ak.Status.AtProvider.Key = "name"
+	name, exists := APIKey(&ak)
+	assert.Equal(ak.Status.AtProvider.Key, name)
+	assert.False(exists)
+
+	// With external name
+	extName := make(map[string]string)
+	extName["crossplane.io/external-name"] = "extname"
+	ak.SetAnnotations(extName)
+	name, exists = APIKey(&ak)
+	assert.NotEqual(ak.Name, name, "external name not used")
+	assert.Equal("extname", name, "external name not used")
+	assert.True(exists)
+}
+
+func TestTopicRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	topic := Topic{Environment: "env-vvvvv", Cluster: "lkc-yyyyy", Name: "orders.v1_events-x"}
+	encoded := EncodeTopic(topic)
+	assert.Equal("env-vvvvv/lkc-yyyyy/orders.v1_events-x", encoded)
+
+	decoded, err := DecodeTopic(encoded)
+	assert.NoError(err)
+	assert.Equal(topic, decoded)
+
+	// Plain topic names keep working
+	decoded, err = DecodeTopic("orders")
+	assert.NoError(err)
+	assert.Equal(Topic{Name: "orders"}, decoded)
+
+	// Malformed names
+	for _, s := range []string{"", "env-vvvvv/orders", "env-vvvvv/lkc-yyyyy/orders/extra", "env-vvvvv//orders"} {
+		_, err = DecodeTopic(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestACLRoundTrip(t *testing.T) {
+	assert := assert.New(t)
+
+	acl := ACL{
+		Environment:  "env-vvvvv",
+		Cluster:      "lkc-yyyyy",
+		Principal:    "User:sa-55555",
+		Permission:   "ALLOW",
+		Operation:    "READ",
+		ResourceType: "CONSUMER_GROUP",
+		PatternType:  "PREFIXED",
+		ResourceName: "team/consumers",
+	}
+	encoded := EncodeACL(acl)
+	assert.Equal("env-vvvvv/lkc-yyyyy/User:sa-55555/ALLOW/READ/CONSUMER_GROUP/PREFIXED/team/consumers", encoded)
+
+	decoded, err := DecodeACL(encoded)
+	assert.NoError(err)
+	assert.Equal(acl, decoded, "resource name may contain the separator")
+
+	// Malformed names
+	for _, s := range []string{"", "env-vvvvv/lkc-yyyyy/User:sa-55555", "env-vvvvv/lkc-yyyyy//ALLOW/READ/TOPIC/LITERAL/orders"} {
+		_, err = DecodeACL(s)
+		assert.Error(err, s)
+	}
+}
+
+func TestCheckID(t *testing.T) {
+	assert := assert.New(t)
+
+	sa := saapi.ServiceAccount{}
+
+	// Never observed, e.g. an import
+	meta.SetExternalName(&sa, "sa-12345")
+	assert.NoError(CheckID(&sa, ""))
+
+	assert.NoError(CheckID(&sa, "sa-12345"))
+
+	meta.SetExternalName(&sa, "sa-67890")
+	assert.EqualError(CheckID(&sa, "sa-12345"), "external name was changed from sa-12345 to sa-67890, restore it or recreate the managed resource to manage another resource")
+}
@@ -0,0 +1,138 @@
+package externalname
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	flinkapi "github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+const (
+	errUnsupportedKind = "external name templates are not supported for kind %s, supported kinds are %s"
+	errParseTemplate   = "cannot parse the external name template of kind %s"
+	errRenderTemplate  = "cannot render the external name template of kind %s"
+	errEmptyName       = "the external name template of kind %s renders an empty name"
+	errUpdateManaged   = "cannot update managed resource"
+)
+
+// charset is the set of names Confluent accepts for a kind of resource
+type charset struct {
+	invalid   *regexp.Regexp
+	maxLength int
+	lower     bool
+}
+
+// charsets are the kinds whose Confluent name is derived from the k8s object. Names are only unique within the organization, so they are
+// never derived from the ID Confluent assigns
+var charsets = map[string]charset{
+	saapi.ServiceAccountKind:    {invalid: regexp.MustCompile(`[^a-zA-Z0-9._-]+`), maxLength: 64},
+	flinkapi.FlinkStatementKind: {invalid: regexp.MustCompile(`[^a-z0-9-]+`), maxLength: 100, lower: true},
+}
+
+// templates are the external name templates per kind. Kinds without a template use the name of the k8s object
+var templates = map[string]*template.Template{}
+
+// TemplateData is what an external name template is rendered with, e.g. {{ .Namespace }}-{{ .Name }} or {{ .Labels.team }}-{{ .Name }}
+type TemplateData struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// SetTemplates Parses the external name templates per kind & uses them for the external names generated from then on
+func SetTemplates(byKind map[string]string) error {
+	parsed := make(map[string]*template.Template, len(byKind))
+	for kind, text := range byKind {
+		if _, ok := charsets[kind]; !ok {
+			return errors.Errorf(errUnsupportedKind, kind, strings.Join(supportedKinds(), ", "))
+		}
+
+		t, err := template.New(kind).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return errors.Wrapf(err, errParseTemplate, kind)
+		}
+		parsed[kind] = t
+	}
+
+	templates = parsed
+	return nil
+}
+
+// Generate Returns the name of the Confluent resource of kind generated from o. It is the rendered template of kind, sanitized to the names
+// Confluent accepts, or the name of o when kind has no template
+func Generate(kind string, o metav1.Object) (string, error) {
+	t, ok := templates[kind]
+	if !ok {
+		return o.GetName(), nil
+	}
+
+	var buf bytes.Buffer
+	data := TemplateData{Name: o.GetName(), Namespace: o.GetNamespace(), Labels: o.GetLabels(), Annotations: o.GetAnnotations()}
+	if err := t.Execute(&buf, data); err != nil {
+		return "", errors.Wrapf(err, errRenderTemplate, kind)
+	}
+
+	name := Sanitize(kind, buf.String())
+	if name == "" {
+		return "", errors.Errorf(errEmptyName, kind)
+	}
+	return name, nil
+}
+
+// Sanitize Returns name with every run of characters Confluent does not accept for kind replaced by a dash, without leading & trailing
+// separators & truncated to the maximum length of kind
+func Sanitize(kind string, name string) string {
+	cs, ok := charsets[kind]
+	if !ok {
+		return name
+	}
+
+	if cs.lower {
+		name = strings.ToLower(name)
+	}
+	name = strings.Trim(cs.invalid.ReplaceAllString(name, "-"), "-._")
+	if len(name) > cs.maxLength {
+		name = strings.TrimRight(name[:cs.maxLength], "-._")
+	}
+	return name
+}
+
+// NewInitializer Returns an initializer that sets the external name of a managed resource of kind to the name generated for it, unless it
+// already has one. Without a template of kind it behaves like managed.NewNameAsExternalName
+func NewInitializer(kube client.Client, kind string) managed.Initializer {
+	return managed.InitializerFn(func(ctx context.Context, mg resource.Managed) error {
+		if meta.GetExternalName(mg) != "" {
+			return nil
+		}
+
+		name, err := Generate(kind, mg)
+		if err != nil {
+			return err
+		}
+
+		meta.SetExternalName(mg, name)
+		return errors.Wrap(kube.Update(ctx, mg), errUpdateManaged)
+	})
+}
+
+func supportedKinds() []string {
+	kinds := make([]string, 0, len(charsets))
+	for kind := range charsets {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
@@ -0,0 +1,104 @@
+package externalname
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	flinkapi "github.com/dfds/provider-confluent/apis/flinkstatement/v1alpha1"
+	saapi "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+)
+
+func TestGenerate(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { templates = map[string]*template.Template{} }()
+
+	sa := &saapi.ServiceAccount{}
+	sa.SetName("orders")
+	sa.SetLabels(map[string]string{"team": "payments"})
+
+	// Without a template the name of the k8s object is used
+	name, err := Generate(saapi.ServiceAccountKind, sa)
+	assert.NoError(err)
+	assert.Equal("orders", name)
+
+	assert.NoError(SetTemplates(map[string]string{
+		saapi.ServiceAccountKind:    `{{ .Labels.team }}-{{ .Name }}`,
+		flinkapi.FlinkStatementKind: `{{ .Name }}`,
+	}))
+	name, err = Generate(saapi.ServiceAccountKind, sa)
+	assert.NoError(err)
+	assert.Equal("payments-orders", name)
+
+	// Labels missing from the k8s object fail instead of rendering a placeholder
+	sa.SetLabels(nil)
+	_, err = Generate(saapi.ServiceAccountKind, sa)
+	assert.Error(err)
+
+	fs := &flinkapi.FlinkStatement{}
+	fs.SetName("Orders.Enrichment")
+	name, err = Generate(flinkapi.FlinkStatementKind, fs)
+	assert.NoError(err)
+	assert.Equal("orders-enrichment", name)
+
+	fs.SetName("...")
+	_, err = Generate(flinkapi.FlinkStatementKind, fs)
+	assert.Error(err)
+}
+
+func TestSetTemplates(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { templates = map[string]*template.Template{} }()
+
+	err := SetTemplates(map[string]string{"Topic": "{{ .Name }}"})
+	if assert.Error(err) {
+		assert.Contains(err.Error(), "FlinkStatement, ServiceAccount")
+	}
+	assert.Error(SetTemplates(map[string]string{saapi.ServiceAccountKind: "{{ .Name"}))
+	assert.Empty(templates)
+}
+
+func TestSanitize(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("team_a-orders.v2", Sanitize(saapi.ServiceAccountKind, "team_a/orders.v2"))
+	assert.Equal("orders-service", Sanitize(saapi.ServiceAccountKind, " orders  service! "))
+	assert.Equal("orders-v2", Sanitize(flinkapi.FlinkStatementKind, "Orders_V2"))
+	assert.Len(Sanitize(saapi.ServiceAccountKind, strings.Repeat("a", 100)), 64)
+	assert.Equal(strings.Repeat("a", 63), Sanitize(saapi.ServiceAccountKind, strings.Repeat("a", 63)+"-b"))
+
+	// Kinds without restrictions are left as is
+	assert.Equal("any name", Sanitize("Topic", "any name"))
+}
+
+func TestInitializer(t *testing.T) {
+	assert := assert.New(t)
+	defer func() { templates = map[string]*template.Template{} }()
+	assert.NoError(SetTemplates(map[string]string{saapi.ServiceAccountKind: `crossplane-{{ .Name }}`}))
+
+	updates := 0
+	kube := test.NewMockClient()
+	kube.MockUpdate = func(context.Context, client.Object, ...client.UpdateOption) error {
+		updates++
+		return nil
+	}
+	i := NewInitializer(kube, saapi.ServiceAccountKind)
+
+	sa := &saapi.ServiceAccount{}
+	sa.SetName("orders")
+	assert.NoError(i.Initialize(context.Background(), sa))
+	assert.Equal("crossplane-orders", meta.GetExternalName(sa))
+	assert.Equal(1, updates)
+
+	// An external name that is set is kept, so renaming never moves the resource
+	assert.NoError(SetTemplates(map[string]string{saapi.ServiceAccountKind: `renamed-{{ .Name }}`}))
+	assert.NoError(i.Initialize(context.Background(), sa))
+	assert.Equal("crossplane-orders", meta.GetExternalName(sa))
+	assert.Equal(1, updates)
+}
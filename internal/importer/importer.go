@@ -0,0 +1,248 @@
+package importer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+
+	aclv1alpha1 "github.com/dfds/provider-confluent/apis/acl/v1alpha1"
+	sav1alpha1 "github.com/dfds/provider-confluent/apis/serviceaccount/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/acl"
+	"github.com/dfds/provider-confluent/internal/clients/acl/commands"
+	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+	"github.com/dfds/provider-confluent/internal/externalname"
+)
+
+const (
+	errListServiceAccounts = "cannot list service accounts"
+	errListACLs            = "cannot list acls of service account %s"
+	errClusterRequired     = "acls can only be imported for an environment & cluster"
+	errNoBindings          = "principal %s has no acl bindings in cluster %s"
+
+	maxNameLength = 253
+)
+
+// Options controls which Confluent resources are imported and how the managed resources are written
+type Options struct {
+	// Environment & Cluster scope the ACLs. ACLs are only imported when both are set
+	Environment string
+	Cluster     string
+
+	// ProviderConfig is the name of the ProviderConfig the managed resources refer to
+	ProviderConfig string
+
+	// DeletionPolicy of the managed resources. Orphan keeps the imported resources in Confluent Cloud when the managed resource is deleted
+	DeletionPolicy xpv1.DeletionPolicy
+}
+
+// Importer enumerates existing Confluent resources and writes them as managed resources that adopt them
+type Importer struct {
+	ServiceAccounts serviceaccount.IClient
+	ACLs            acl.IClient
+}
+
+// Manifest is a managed resource as written by the Importer
+type Manifest struct {
+	APIVersion string   `json:"apiVersion"`
+	Kind       string   `json:"kind"`
+	Metadata   Metadata `json:"metadata"`
+	Spec       Spec     `json:"spec"`
+}
+
+// Metadata of a Manifest
+type Metadata struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Spec of a Manifest
+type Spec struct {
+	DeletionPolicy    xpv1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+	ForProvider       interface{}         `json:"forProvider"`
+	ProviderConfigRef *xpv1.Reference     `json:"providerConfigRef,omitempty"`
+}
+
+// Import Returns the managed resources of all service accounts, and of their ACLs when an environment & cluster are given. The result is sorted so importing unchanged Confluent state twice yields the same manifests
+func (i *Importer) Import(ctx context.Context, o Options) ([]Manifest, error) {
+	if (o.Environment == "") != (o.Cluster == "") {
+		return nil, errors.New(errClusterRequired)
+	}
+
+	sas, err := i.ServiceAccounts.ServiceAccountList(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errListServiceAccounts)
+	}
+	sort.Slice(sas, func(a, b int) bool { return sas[a].Name < sas[b].Name })
+
+	manifests := make([]Manifest, 0, len(sas))
+	for _, sa := range sas {
+		manifests = append(manifests, o.serviceAccount(sa))
+	}
+
+	if o.Cluster == "" {
+		return manifests, nil
+	}
+
+	for _, sa := range sas {
+		rules, err := i.ACLs.ACLList(ctx, sa.ID, o.Environment, o.Cluster)
+		if err != nil {
+			if err.Error() == acl.ErrACLNotExistsOrInvalidServiceAccount {
+				continue
+			}
+			return nil, errors.Wrapf(err, errListACLs, sa.ID)
+		}
+
+		acls := make([]Manifest, 0, len(rules))
+		for _, rule := range rules {
+			acls = append(acls, o.acl(rule))
+		}
+		sort.Slice(acls, func(a, b int) bool { return acls[a].Metadata.Name < acls[b].Metadata.Name })
+		manifests = append(manifests, acls...)
+	}
+
+	return manifests, nil
+}
+
+// ImportPrincipal Returns the managed resources of the ACL bindings of a single principal, e.g. sa-12345 or User:sa-12345. Bindings differing
+// only by operation are merged into one ACL with all of their operations, which is how an ACL adopting a service account's access is usually
+// written
+func (i *Importer) ImportPrincipal(ctx context.Context, o Options, principal string) ([]Manifest, error) {
+	if o.Environment == "" || o.Cluster == "" {
+		return nil, errors.New(errClusterRequired)
+	}
+
+	if !strings.HasPrefix(principal, "User:") {
+		principal = "User:" + principal
+	}
+	serviceAccount, err := commands.ParsePrincipal(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := i.ACLs.ACLList(ctx, serviceAccount, o.Environment, o.Cluster)
+	if err != nil {
+		if err.Error() == acl.ErrACLNotExistsOrInvalidServiceAccount {
+			return nil, errors.Errorf(errNoBindings, serviceAccount, o.Cluster)
+		}
+		return nil, errors.Wrapf(err, errListACLs, serviceAccount)
+	}
+
+	// Bindings are grouped by all of their fields but the operation
+	var grouped []aclv1alpha1.ACLRule
+	index := map[string]int{}
+	for _, rule := range rules {
+		key := strings.Join([]string{rule.Permission, rule.ResourceType, rule.PatternType, rule.ResourceName}, "/")
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(grouped)
+			rule.Operations = []string{rule.Operation}
+			rule.Operation = ""
+			grouped = append(grouped, rule)
+			continue
+		}
+		grouped[i].Operations = append(grouped[i].Operations, rule.Operation)
+	}
+
+	acls := make([]Manifest, 0, len(grouped))
+	for _, rule := range grouped {
+		sort.Strings(rule.Operations)
+		if len(rule.Operations) == 1 {
+			rule.Operation = rule.Operations[0]
+			rule.Operations = nil
+		}
+		acls = append(acls, o.acl(rule))
+	}
+	sort.Slice(acls, func(a, b int) bool { return acls[a].Metadata.Name < acls[b].Metadata.Name })
+
+	return acls, nil
+}
+
+// serviceAccount Returns the managed resource of a service account. Its external name is the service account name
+func (o Options) serviceAccount(sa serviceaccount.ServiceAccount) Manifest {
+	description := sa.Description
+	return o.manifest(sav1alpha1.SchemeGroupVersion.String(), sav1alpha1.ServiceAccountKind, resourceName(sa.Name, sa.Name), sa.Name,
+		sav1alpha1.ServiceAccountParameters{Description: &description})
+}
+
+// acl Returns the managed resource of an ACL binding, or of the bindings of all operations of rule. Its external name is the encoded binding,
+// with the operations joined by commas
+func (o Options) acl(rule aclv1alpha1.ACLRule) Manifest {
+	operation := rule.Operation
+	if len(rule.Operations) > 0 {
+		operation = strings.Join(rule.Operations, ",")
+	}
+	extName := externalname.EncodeACL(externalname.ACL{
+		Environment:  o.Environment,
+		Cluster:      o.Cluster,
+		Principal:    rule.Principal,
+		Permission:   rule.Permission,
+		Operation:    operation,
+		ResourceType: rule.ResourceType,
+		PatternType:  rule.PatternType,
+		ResourceName: rule.ResourceName,
+	})
+	name := strings.Join([]string{strings.TrimPrefix(rule.Principal, "User:"), rule.Permission, operation, rule.ResourceType, rule.ResourceName}, "-")
+	return o.manifest(aclv1alpha1.SchemeGroupVersion.String(), aclv1alpha1.ACLKind, resourceName(name, extName), extName,
+		aclv1alpha1.ACLParameters{ACLRule: rule, Environment: o.Environment, Cluster: o.Cluster})
+}
+
+func (o Options) manifest(apiVersion string, kind string, name string, extName string, forProvider interface{}) Manifest {
+	m := Manifest{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Metadata: Metadata{
+			Name:        name,
+			Annotations: map[string]string{meta.AnnotationKeyExternalName: extName},
+		},
+		Spec: Spec{
+			DeletionPolicy: o.DeletionPolicy,
+			ForProvider:    forProvider,
+		},
+	}
+	if o.ProviderConfig != "" {
+		m.Spec.ProviderConfigRef = &xpv1.Reference{Name: o.ProviderConfig}
+	}
+	return m
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// resourceName Returns a valid k8s object name for name. When name had to be changed, a hash of key is appended so distinct resources can't end up with the same name
+func resourceName(name string, key string) string {
+	valid := strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if valid == name {
+		return name
+	}
+
+	h := fnv.New32a()
+	h.Write([]byte(key)) //nolint:errcheck
+	suffix := fmt.Sprintf("-%08x", h.Sum32())
+	if len(valid) > maxNameLength-len(suffix) {
+		valid = strings.TrimRight(valid[:maxNameLength-len(suffix)], "-")
+	}
+	return valid + suffix
+}
+
+// Write Returns the manifests as a multi-document YAML stream
+func Write(manifests []Manifest) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, m := range manifests {
+		out, err := yaml.Marshal(m)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("---\n")
+		buf.Write(out)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,172 @@
+package importer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/dfds/provider-confluent/apis/acl/v1alpha1"
+	"github.com/dfds/provider-confluent/internal/clients/acl"
+	"github.com/dfds/provider-confluent/internal/clients/serviceaccount"
+)
+
+type fakeServiceAccountClient struct {
+	serviceaccount.IClient
+	sas []serviceaccount.ServiceAccount
+}
+
+func (f *fakeServiceAccountClient) ServiceAccountList(_ context.Context) ([]serviceaccount.ServiceAccount, error) {
+	// Confluent lists service accounts in no particular order
+	sas := make([]serviceaccount.ServiceAccount, len(f.sas))
+	for i := range f.sas {
+		sas[i] = f.sas[len(f.sas)-1-i]
+	}
+	return sas, nil
+}
+
+type fakeACLClient struct {
+	acl.IClient
+	rules map[string][]v1alpha1.ACLRule
+}
+
+func (f *fakeACLClient) ACLList(_ context.Context, serviceAccount string, environment string, cluster string) ([]v1alpha1.ACLRule, error) {
+	if environment != "env-12345" || cluster != "lkc-12345" || len(f.rules[serviceAccount]) == 0 {
+		return nil, errors.New(acl.ErrACLNotExistsOrInvalidServiceAccount)
+	}
+	return f.rules[serviceAccount], nil
+}
+
+func newImporter() *Importer {
+	return &Importer{
+		ServiceAccounts: &fakeServiceAccountClient{sas: []serviceaccount.ServiceAccount{
+			{ID: "sa-11111", Name: "orders", Description: "Orders service"},
+			{ID: "sa-22222", Name: "Payments API", Description: ""},
+		}},
+		ACLs: &fakeACLClient{rules: map[string][]v1alpha1.ACLRule{
+			"sa-11111": {
+				{Operation: "WRITE", PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders", ResourceType: "TOPIC"},
+				{Operation: "READ", PatternType: "PREFIXED", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders.", ResourceType: "CONSUMER_GROUP"},
+			},
+		}},
+	}
+}
+
+func TestImportServiceAccounts(t *testing.T) {
+	assert := assert.New(t)
+
+	manifests, err := newImporter().Import(context.Background(), Options{ProviderConfig: "confluent-provider", DeletionPolicy: xpv1.DeletionOrphan})
+	assert.NoError(err)
+	assert.Len(manifests, 2)
+
+	// Sorted by name, names that aren't valid object names are made valid
+	assert.Equal("ServiceAccount", manifests[0].Kind)
+	assert.Equal("iam.confluent.crossplane.io/v1alpha1", manifests[0].APIVersion)
+	assert.Equal("Payments API", manifests[0].Metadata.Annotations["crossplane.io/external-name"])
+	assert.Regexp(`^payments-api-[0-9a-f]{8}$`, manifests[0].Metadata.Name)
+	assert.Equal("orders", manifests[1].Metadata.Name)
+	assert.Equal("orders", manifests[1].Metadata.Annotations["crossplane.io/external-name"])
+
+	out, err := Write(manifests)
+	assert.NoError(err)
+	assert.Contains(string(out), "description: Orders service")
+	assert.Contains(string(out), "deletionPolicy: Orphan")
+	assert.Contains(string(out), "name: confluent-provider")
+}
+
+func TestImportACLs(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := newImporter().Import(context.Background(), Options{Environment: "env-12345"})
+	assert.EqualError(err, errClusterRequired)
+
+	manifests, err := newImporter().Import(context.Background(), Options{Environment: "env-12345", Cluster: "lkc-12345"})
+	assert.NoError(err)
+	assert.Len(manifests, 4)
+
+	acls := manifests[2:]
+	for _, m := range acls {
+		assert.Equal("ACL", m.Kind)
+		assert.Equal("kafka.confluent.crossplane.io/v1alpha1", m.APIVersion)
+		p := m.Spec.ForProvider.(v1alpha1.ACLParameters)
+		assert.Equal("env-12345", p.Environment)
+		assert.Equal("lkc-12345", p.Cluster)
+	}
+	assert.Contains(acls[0].Metadata.Annotations["crossplane.io/external-name"], "env-12345/lkc-12345/User:sa-11111/ALLOW/")
+	assert.NotEqual(acls[0].Metadata.Name, acls[1].Metadata.Name)
+
+	// Another environment has no ACLs
+	manifests, err = newImporter().Import(context.Background(), Options{Environment: "env-67890", Cluster: "lkc-67890"})
+	assert.NoError(err)
+	assert.Len(manifests, 2)
+}
+
+func TestImportPrincipal(t *testing.T) {
+	assert := assert.New(t)
+
+	i := &Importer{ACLs: &fakeACLClient{rules: map[string][]v1alpha1.ACLRule{
+		"sa-11111": {
+			{Operation: "WRITE", PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders", ResourceType: "TOPIC"},
+			{Operation: "READ", PatternType: "PREFIXED", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders.", ResourceType: "CONSUMER_GROUP"},
+			{Operation: "DESCRIBE", PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders", ResourceType: "TOPIC"},
+			{Operation: "READ", PatternType: "LITERAL", Permission: "ALLOW", Principal: "User:sa-11111", ResourceName: "orders", ResourceType: "TOPIC"},
+		},
+	}}}
+	o := Options{Environment: "env-12345", Cluster: "lkc-12345"}
+
+	_, err := i.ImportPrincipal(context.Background(), Options{Environment: "env-12345"}, "sa-11111")
+	assert.EqualError(err, errClusterRequired)
+
+	_, err = i.ImportPrincipal(context.Background(), o, "sa-22222")
+	assert.EqualError(err, "principal sa-22222 has no acl bindings in cluster lkc-12345")
+
+	manifests, err := i.ImportPrincipal(context.Background(), o, "sa-11111")
+	assert.NoError(err)
+	if !assert.Len(manifests, 2) {
+		return
+	}
+
+	// The operations on the topic are merged into one ACL
+	topic := manifests[0].Spec.ForProvider.(v1alpha1.ACLParameters)
+	assert.Equal("TOPIC", topic.ACLRule.ResourceType)
+	assert.Empty(topic.ACLRule.Operation)
+	assert.Equal([]string{"DESCRIBE", "READ", "WRITE"}, topic.ACLRule.Operations)
+	assert.Equal("env-12345/lkc-12345/User:sa-11111/ALLOW/DESCRIBE,READ,WRITE/TOPIC/LITERAL/orders", manifests[0].Metadata.Annotations["crossplane.io/external-name"])
+
+	group := manifests[1].Spec.ForProvider.(v1alpha1.ACLParameters)
+	assert.Equal("READ", group.ACLRule.Operation)
+	assert.Empty(group.ACLRule.Operations)
+
+	// The principal may be given with its User: prefix
+	prefixed, err := i.ImportPrincipal(context.Background(), o, "User:sa-11111")
+	assert.NoError(err)
+	assert.Equal(manifests, prefixed)
+}
+
+func TestImportIsIdempotent(t *testing.T) {
+	assert := assert.New(t)
+
+	o := Options{Environment: "env-12345", Cluster: "lkc-12345", ProviderConfig: "default"}
+	first, err := newImporter().Import(context.Background(), o)
+	assert.NoError(err)
+	second, err := newImporter().Import(context.Background(), o)
+	assert.NoError(err)
+
+	a, _ := Write(first)
+	b, _ := Write(second)
+	assert.Equal(string(a), string(b))
+}
+
+func TestResourceName(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("orders", resourceName("orders", "orders"))
+	assert.NotEqual(resourceName("Orders", "Orders"), resourceName("orders!", "orders!"))
+	assert.Equal(resourceName("Orders", "Orders"), resourceName("Orders", "Orders"))
+
+	long := resourceName(string(make([]byte, 300)), "key")
+	assert.LessOrEqual(len(long), maxNameLength)
+}